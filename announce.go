@@ -0,0 +1,206 @@
+package main
+
+import (
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thedadams/cahbot/game"
+)
+
+// Announce tells the players in a game about the events that happened in it.
+func (bot *CAHBot) Announce(g *game.Game, Events []game.Event) {
+	for _, e := range Events {
+		switch e := e.(type) {
+		case game.PlayerJoined:
+			bot.SendToGame(g, e.Player.Name+" has joined the game!")
+			bot.Messenger.SendText(e.Player.ChatID, "Welcome to the game!  Here are the currect game settings for your review.")
+			bot.SendGameSettings(g, e.Player.ChatID)
+		case game.PlayerLeft:
+			bot.Messenger.SendText(e.Player.ChatID, "Thanks for playing, "+e.Player.Name+"!  You collected "+strconv.Itoa(e.Player.Points)+" Awesome Points.")
+			bot.SendToGame(g, e.Player.Name+" has left the game with a score of "+strconv.Itoa(e.Player.Points)+".")
+		case game.GameBegan:
+			bot.SendToGame(g, "Get ready, we are starting the game!")
+		case game.RoundStarted:
+			log.Printf("Sending question card to game with ID %v...", g.ID)
+			bot.SendToGame(g, "Here is the question card:\n\n"+e.Question.String())
+			for _, p := range e.Waiting {
+				log.Printf("Asking %v for an answer card.", p.ID)
+				bot.ListCardsForUserWithMessage(g, p, "Please pick an answer for the question.")
+			}
+		case game.AnswerReceived:
+			if e.Complete {
+				log.Printf("We received a valid, complete answer from user with id %v.", e.Player.ID)
+				bot.SendToGame(g, "We received "+e.Player.Name+"'s answer.")
+			} else {
+				log.Printf("We received a valid answer from user with id %v, but we need another answer.", e.Player.ID)
+				bot.ListCardsForUserWithMessage(g, e.Player, "We received your answer, but this is a multi-answer questions.  Please choose another answer.")
+			}
+		case game.WriteInRequested:
+			bot.Messenger.SendText(e.Player.ChatID, "Type your answer for the blank card as your next message.  It can be up to "+strconv.Itoa(game.MaxWriteIn)+" characters long.  You can still pick another card instead.")
+		case game.AnswersCollected:
+			bot.ListAnswers(g, e.Czar, e.Answers)
+		case game.AnswerChosen:
+			if e.Random {
+				bot.SendToGame(g, "An answer was picked at random: "+e.Answer+"\n\n"+WinnerText(e.Player, e.ForFun))
+				continue
+			}
+			if g.Settings.Judging == game.SurvivalJudging {
+				bot.SendToGame(g, "The last answer standing: "+e.Answer+"\n\n"+WinnerText(e.Player, e.ForFun))
+				continue
+			}
+			if e.ForFun {
+				bot.SendToGame(g, "The czar chose the best answer: "+e.Answer+"\n\n"+WinnerText(e.Player, true))
+				continue
+			}
+			if e.Player.ID == game.MysteryID {
+				bot.SendToGame(g, "The czar chose the best answer: "+e.Answer+"\n\nThis was the mystery player's answer!  "+e.Player.Name+" gets one Awesome Point.")
+				continue
+			}
+			bot.SendToGame(g, "The czar chose the best answer: "+e.Answer+"\n\nThis was "+e.Player.Name+"'s answer.  You get one Awesome Point!")
+		case game.WorstRequested:
+			bot.ListWorstAnswers(g, e.Czar, e.Answers)
+		case game.WorstChosen:
+			summary := "The czar chose the worst answer: " + e.WorstAnswer + "\n\nThis was " + e.Worst.Name + "'s answer.  They lose one Awesome Point.\n\nThis round's picks:\n"
+			if e.Best != nil {
+				summary += "Best: " + e.BestAnswer + " - " + e.Best.Name + " (+1)\n"
+			}
+			bot.SendToGame(g, summary+"Worst: "+e.WorstAnswer+" - "+e.Worst.Name+" (-1)")
+		case game.RoundEnded:
+			if e.Czar == nil {
+				if g.Settings.Czarless && len(g.Players) != 0 {
+					bot.SendToGame(g, "The round is over.  Anyone can use the command /next to start the next round.")
+				}
+				continue
+			}
+			bot.SendToGame(g, "The new Card Czar is "+e.Czar.Name+".  They will start the new round soon.")
+			bot.Messenger.SendText(e.Czar.ChatID, "You are the Card Czar for the next round.  Use the command /next to start the next round.")
+		case game.DeadlineNear:
+			left := Minutes(int(math.Max(1, math.Ceil(time.Until(e.Deadline).Minutes()))))
+			for _, p := range e.Waiting {
+				switch g.Phase {
+				case game.CollectingAnswers:
+					bot.Messenger.SendText(p.ChatID, "Hurry up!  You have about "+left+" left to answer, or you will sit this round out.")
+				case game.Voting:
+					bot.Messenger.SendText(p.ChatID, "Hurry up!  You have about "+left+" left to vote for the best answer.")
+				case game.Eliminating:
+					bot.Messenger.SendText(p.ChatID, "Hurry up!  You have about "+left+" left to eliminate an answer, or one will be eliminated for you at random.")
+				default:
+					bot.Messenger.SendText(p.ChatID, "Czar, you have about "+left+" left to choose.  After that: "+strings.ToLower(CzarTimeoutText(g.Settings.CzarTimeout))+".")
+				}
+			}
+		case game.PlayersSkipped:
+			if len(e.Players) == 0 {
+				continue
+			}
+			names := make([]string, len(e.Players))
+			for i, p := range e.Players {
+				names[i] = p.Name
+			}
+			bot.SendToGame(g, "Time is up!  We are going on without answers from "+strings.Join(names, ", ")+".")
+		case game.CzarTimedOut:
+			if e.Worst {
+				bot.SendToGame(g, e.Czar.Name+" took too long to choose the worst answer, so nobody loses a point this round.")
+				continue
+			}
+			bot.SendToGame(g, e.Czar.Name+" took too long to choose the best answer.")
+		case game.CzarReplaced:
+			bot.SendToGame(g, e.New.Name+" is the Card Czar for the rest of this round.")
+			text := "You are the Card Czar for the rest of this round, and your answer went back in your hand.  Please choose the best answer."
+			if g.Settings.Judging == game.RankedJudging {
+				text = "You are the Card Czar for the rest of this round, and your answer went back in your hand.\n\n" + CzarPrompt(g, e.Answers)
+			}
+			bot.Messenger.SendChoices(e.New.ChatID, text, CzarChoices(g, e.Answers))
+		case game.VoteStarted:
+			if g.Settings.Czarless {
+				text := "Here are the submitted answers:\n\n"
+				for _, a := range e.Answers {
+					text += a.Text + "\n"
+				}
+				bot.SendToGame(g, text+"\nEveryone votes for the best one.")
+			} else {
+				bot.SendToGame(g, "Everyone will vote for the best answer instead.")
+			}
+			for _, p := range e.Voters {
+				bot.Messenger.SendChoices(p.ChatID, "Please vote for the best answer.  You cannot vote for your own.", VoteChoices(g, p, e.Answers))
+			}
+		case game.EliminationStarted:
+			left := make([]int, len(e.Answers))
+			for i := range left {
+				left[i] = i
+			}
+			bot.ShowEliminationBoards(g, e.Answers, left, TurnText(e.Turn), true)
+		case game.AnswerEliminated:
+			status := e.Player.Name + " eliminated: " + e.Answer
+			if e.Random {
+				status = e.Player.Name + " took too long, so an answer was eliminated at random: " + e.Answer
+			}
+			if e.Next == nil {
+				bot.ShowEliminationBoards(g, e.Answers, e.Left, status+"\n\nOne answer is left.", false)
+				continue
+			}
+			bot.ShowEliminationBoards(g, e.Answers, e.Left, status+"\n\n"+TurnText(e.Next), false)
+		case game.EliminationChanged:
+			bot.ShowEliminationBoards(g, e.Answers, e.Left, TurnText(e.Turn), false)
+		case game.VoteReceived:
+			bot.SendToGame(g, "We received "+e.Player.Name+"'s vote.")
+		case game.VoteWon:
+			bot.SendToGame(g, VoteWonText(e))
+		case game.AnswersRanked:
+			bot.SendToGame(g, RankedText(e))
+		case game.EditingSettings:
+			bot.Messenger.SendChoices(e.Player.ChatID, SettingsMenuText(g), SettingChoices(bot.Settings))
+		case game.SettingsChanged:
+			bot.SendToGame(g, e.Player.Name+" changed the settings:\n"+ChangedSettingsText(e.Old, e.New))
+		case game.TradesOffered:
+			for _, p := range e.Players {
+				text, choices := TradeInMenu(g, p)
+				bot.Messenger.SendChoices(p.ChatID, text, choices)
+			}
+		case game.CardsTraded:
+			if len(e.Discarded) == 0 {
+				bot.Messenger.SendText(e.Player.ChatID, "You kept your cards.")
+				continue
+			}
+			text := "You traded in " + CountCards(len(e.Discarded)) + ".  Here are your new cards:\n"
+			for _, card := range e.Drawn {
+				text += g.Deck.Answer(card).String() + "\n"
+			}
+			bot.Messenger.SendText(e.Player.ChatID, text)
+		case game.PackToggled:
+			if e.Included {
+				bot.SendToGame(g, e.Player.Name+" included the "+e.Pack+" pack.")
+			} else {
+				bot.SendToGame(g, e.Player.Name+" excluded the "+e.Pack+" pack.")
+			}
+		case game.HouseRuleToggled:
+			if e.On {
+				bot.SendToGame(g, e.Player.Name+" turned on the house rule "+e.Rule+".")
+			} else {
+				bot.SendToGame(g, e.Player.Name+" turned off the house rule "+e.Rule+".")
+			}
+		case game.FinalRoundAhead:
+			text := e.Winner.Name + " has won the game!  But " + e.Rule + " is a house rule, so there is one more round before the game ends.  Nobody scores in it."
+			if e.Rule == game.HappyEnding {
+				text += "  Everyone makes a haiku."
+			}
+			bot.SendToGame(g, text)
+		case game.UniverseRebooted:
+			bot.SendToGame(g, e.Player.Name+" spent an Awesome Point to reboot the universe and drew a new hand.")
+			bot.ListCardsForUserWithMessage(g, e.Player, "Here is your new hand.")
+		case game.GameEnded:
+			if e.Winner == nil {
+				// Someone ended the game.
+				bot.SendToGame(g, "The game has been stopped by "+e.StoppedBy+".\nHere are the scores:\n"+BuildScoreList(e.Scores)+"Thanks for playing!")
+			} else if e.Winner.ID == game.MysteryID {
+				// The mystery player won with random cards.
+				bot.SendToGame(g, "The game has ended, and the winner is... the mystery player, "+e.Winner.Name+"!  Random cards beat every one of you.\nHere are the scores:\n"+BuildScoreList(e.Scores)+"Better luck next time!")
+			} else {
+				// The game ended because someone won.
+				bot.SendToGame(g, "The game has ended.  Here are the scores:\n"+BuildScoreList(e.Scores)+"Thanks for playing!")
+			}
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/thedadams/cahbot/game"
+)
+
+// newRecordingBot creates a bot that keeps its games in memory and records what it sends.
+func newRecordingBot(t *testing.T) (*CAHBot, *RecordingMessenger) {
+	t.Helper()
+	Deck, err := LoadDeck("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	messenger := new(RecordingMessenger)
+	bot, err := NewCAHBot(messenger, Deck, NewMemoryStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bot, messenger
+}
+
+// newAnnouncedGame creates a game of three players, whose IDs and chats are 1, 2 and 3, and begins it.
+// It returns the events of beginning the game.
+func newAnnouncedGame(t *testing.T, bot *CAHBot, Settings game.Settings) (*game.Game, []game.Event) {
+	t.Helper()
+	g := game.New("abcde", bot.CurrentDeck(), Settings, rand.New(rand.NewSource(1)))
+	for ID := 1; ID <= 3; ID++ {
+		g.AddPlayer(ID, int64(ID), "player"+strconv.Itoa(ID))
+	}
+	events, err := g.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g, events
+}
+
+// answerRound has the players that are not the czar answer with the first cards in their hands.
+func answerRound(t *testing.T, g *game.Game) []game.Event {
+	t.Helper()
+	var events []game.Event
+	for g.Phase == game.CollectingAnswers {
+		for _, p := range g.Waiting() {
+			e, err := g.PlayCard(p.ID, g.Round, p.Hand[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			events = append(events, e...)
+		}
+	}
+	return events
+}
+
+func TestAnnounceRoundStarted(t *testing.T) {
+	bot, messenger := newRecordingBot(t)
+	g, events := newAnnouncedGame(t, bot, game.DefaultSettings)
+	bot.Announce(g, events)
+	for _, p := range g.Players {
+		sent := messenger.To(p.ChatID)
+		if len(sent) == 0 || sent[0].Text != "Get ready, we are starting the game!" {
+			t.Fatalf("player %v got %v, want to be told the game is starting first", p.ID, sent)
+		}
+		if !strings.Contains(sent[1].Text, g.QuestionCard().String()) {
+			t.Errorf("player %v got %q, want the question card", p.ID, sent[1].Text)
+		}
+		var prompts []Sent
+		for _, s := range sent {
+			if len(s.Choices) != 0 {
+				prompts = append(prompts, s)
+			}
+		}
+		if p.ID == g.Czar {
+			if len(prompts) != 0 {
+				t.Errorf("the czar was asked for an answer")
+			}
+			continue
+		}
+		if len(prompts) != 1 || len(prompts[0].Choices) != len(p.Hand) {
+			t.Fatalf("player %v got %v, want to be asked to pick one of their cards", p.ID, prompts)
+		}
+		if want := "Answer::1::" + strconv.Itoa(p.Hand[0]); prompts[0].Choices[0].Data != want {
+			t.Errorf("got choice data %q, want %q", prompts[0].Choices[0].Data, want)
+		}
+	}
+}
+
+func TestAnnounceAnswersCollected(t *testing.T) {
+	bot, messenger := newRecordingBot(t)
+	g, _ := newAnnouncedGame(t, bot, game.DefaultSettings)
+	events := answerRound(t, g)
+	bot.Announce(g, events)
+	czar := messenger.To(g.CzarPlayer().ChatID)
+	last := czar[len(czar)-1]
+	if last.Text != "Czar, please choose the best answer." || len(last.Choices) != 2 {
+		t.Fatalf("the czar got %v, want to choose from the 2 answers", last)
+	}
+	for i, a := range g.Answers() {
+		if c := last.Choices[i]; c.Text != a.Text || c.Data != "CzarBest::1::"+strconv.Itoa(i) {
+			t.Errorf("got choice %v, want %q for answer %v", c, a.Text, i)
+		}
+	}
+}
+
+func TestAnnounceRankedJudging(t *testing.T) {
+	bot, messenger := newRecordingBot(t)
+	g, _ := newAnnouncedGame(t, bot, game.Settings{CardsInHand: 7, PointsToWin: 7, Judging: game.RankedJudging})
+	answerRound(t, g)
+	first := g.Answers()[1]
+	if _, err := g.ChooseAnswer(g.Czar, g.Round, 1); err != nil {
+		t.Fatal(err)
+	}
+	if prompt := CzarPrompt(g, g.Answers()); !strings.Contains(prompt, "1st: "+first.Text) || !strings.HasSuffix(prompt, "Which answer is in 2nd place?") {
+		t.Errorf("got prompt %q, want the first place shown and the second asked for", prompt)
+	}
+	if choices := CzarChoices(g, g.Answers()); len(choices) != 1 || choices[0].Data != "CzarBest::1::0" {
+		t.Errorf("got choices %v, want only the answer that was not ranked", choices)
+	}
+	messenger.Reset()
+	events, err := g.ChooseAnswer(g.Czar, g.Round, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bot.Announce(g, events)
+	for _, p := range g.Players {
+		sent := messenger.To(p.ChatID)
+		if len(sent) == 0 || !strings.HasPrefix(sent[0].Text, "The czar ranked the answers:\n\n1st: "+first.Text) || !strings.Contains(sent[0].Text, "(+3)") {
+			t.Errorf("player %v got %v, want the ranking with the points", p.ID, sent)
+		}
+	}
+}
+
+func TestAnnounceWorstChosen(t *testing.T) {
+	bot, messenger := newRecordingBot(t)
+	g, _ := newAnnouncedGame(t, bot, game.Settings{CardsInHand: 7, PointsToWin: 7, PickWorst: true})
+	answerRound(t, g)
+	events, err := g.ChooseAnswer(g.Czar, g.Round, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bot.Announce(g, events)
+	czar := messenger.To(g.CzarPlayer().ChatID)
+	if last := czar[len(czar)-1]; len(last.Choices) != 1 || !strings.HasPrefix(last.Choices[0].Data, "CzarWorst::") {
+		t.Fatalf("the czar got %v, want to choose the worst of the answer that is left", last)
+	}
+	worst := g.Player(g.Answers()[0].PlayerID)
+	messenger.Reset()
+	czarID := g.Czar
+	events, err = g.ChooseWorst(czarID, g.Round, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bot.Announce(g, events)
+	sent := messenger.To(1)
+	if len(sent) == 0 || !strings.Contains(sent[0].Text, "Worst: ") || !strings.Contains(sent[0].Text, worst.Name+" (-1)") {
+		t.Errorf("got %v, want the round's picks with %v losing a point", sent, worst.Name)
+	}
+}
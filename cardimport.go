@@ -0,0 +1,331 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"html"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/thedadams/cahbot/game"
+)
+
+// ImportedCard is a card read from a deck in another format, before it is checked.
+type ImportedCard struct {
+	// Where is where the card was found, like "black card 3" or "line 12".
+	Where    string
+	Question bool
+	Text     string
+	// Pick is the number of answers a question asks for, or 0 if the deck does not say.
+	Pick int
+	// Pack is the name of the pack the card is in, or "" if the deck does not say.
+	Pack string
+	// Problem is why the card cannot be imported, if the reader found one.
+	Problem string
+}
+
+// ImportReport lists the cards that were left out of an import, and the cards that were imported but look wrong.
+type ImportReport struct {
+	Skipped    []string
+	Suspicious []string
+}
+
+// jahPack is a pack in the JSON Against Humanity format.  White cards are either text or objects with text.
+type jahPack struct {
+	Name  string            `json:"name"`
+	Black []jahBlack        `json:"black"`
+	White []json.RawMessage `json:"white"`
+}
+
+// jahBlack is a black card in the JSON Against Humanity format.
+type jahBlack struct {
+	Text string `json:"text"`
+	Pick int    `json:"pick"`
+}
+
+var (
+	// blanks matches the ways decks write a blank.
+	blanks = regexp.MustCompile(`_{2,}`)
+	// lineBreaks matches the HTML line breaks some decks put in cards.
+	lineBreaks = regexp.MustCompile(`(?i)\s*<br\s*/?>\s*`)
+	// formatting matches the HTML formatting some decks put in cards, which Telegram messages do not show.
+	formatting = regexp.MustCompile(`(?i)</?(i|b|em|strong|u|small|span)\s*>`)
+	// leftoverHTML matches HTML entities and tags that are still in a card after it is cleaned up.
+	leftoverHTML = regexp.MustCompile(`&#?[A-Za-z0-9]+;|</?[A-Za-z][^>]*>`)
+)
+
+// ReadJSONAgainstHumanity reads the cards of a deck in the JSON Against Humanity format: a pack like
+// {"name": "...", "black": [{"text": "...", "pick": 1}], "white": ["..."]}, or a list of packs.
+func ReadJSONAgainstHumanity(Data []byte) ([]ImportedCard, error) {
+	var packs []jahPack
+	if Data = bytes.TrimSpace(Data); len(Data) > 0 && Data[0] == '[' {
+		if err := json.Unmarshal(Data, &packs); err != nil {
+			return nil, err
+		}
+	} else {
+		var p jahPack
+		if err := json.Unmarshal(Data, &p); err != nil {
+			return nil, err
+		}
+		packs = []jahPack{p}
+	}
+	var cards []ImportedCard
+	black, white := 0, 0
+	for _, p := range packs {
+		for _, b := range p.Black {
+			black++
+			cards = append(cards, ImportedCard{Where: "black card " + strconv.Itoa(black), Question: true, Text: b.Text, Pick: b.Pick, Pack: p.Name})
+		}
+		for _, raw := range p.White {
+			white++
+			var text string
+			if err := json.Unmarshal(raw, &text); err != nil {
+				var w struct {
+					Text string `json:"text"`
+				}
+				if err := json.Unmarshal(raw, &w); err != nil {
+					return nil, fmt.Errorf("white card %v: %v", white, err)
+				}
+				text = w.Text
+			}
+			cards = append(cards, ImportedCard{Where: "white card " + strconv.Itoa(white), Text: text, Pack: p.Name})
+		}
+	}
+	return cards, nil
+}
+
+// ReadDelimited reads the cards of a deck exported as CSV or TSV, with Comma between the columns.
+// The first row names the columns: type (black or white) and text are needed, and pick and pack are optional.
+func ReadDelimited(In io.Reader, Comma rune) ([]ImportedCard, error) {
+	r := csv.NewReader(In)
+	r.Comma = Comma
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	columns := map[string]int{"type": -1, "text": -1, "pick": -1, "pack": -1}
+	names := map[string]string{"type": "type", "color": "type", "colour": "type", "kind": "type", "card": "type",
+		"text": "text", "pick": "pick", "numanswers": "pick", "blanks": "pick", "pack": "pack", "expansion": "pack", "set": "pack", "deck": "pack"}
+	for i, name := range header {
+		if column, ok := names[strings.ToLower(strings.TrimSpace(name))]; ok && columns[column] == -1 {
+			columns[column] = i
+		}
+	}
+	if columns["type"] == -1 || columns["text"] == -1 {
+		return nil, fmt.Errorf("the first row must name the columns, and there must be type and text columns")
+	}
+	get := func(Row []string, Column string) string {
+		if i := columns[Column]; i != -1 && i < len(Row) {
+			return strings.TrimSpace(Row[i])
+		}
+		return ""
+	}
+	var cards []ImportedCard
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		// Blank lines are skipped and a quoted field can span lines, so the line is asked for rather than counted.
+		line, _ := r.FieldPos(0)
+		c := ImportedCard{Where: "line " + strconv.Itoa(line), Text: get(row, "text"), Pack: get(row, "pack")}
+		switch kind := get(row, "type"); strings.ToLower(kind) {
+		case "black", "b", "question", "q":
+			c.Question = true
+		case "white", "w", "answer", "a":
+		default:
+			c.Problem = fmt.Sprintf("%q is not black or white", kind)
+		}
+		if pick := get(row, "pick"); pick != "" && c.Question {
+			if c.Pick, err = strconv.Atoi(pick); err != nil || c.Pick < 0 {
+				c.Problem = fmt.Sprintf("%q is not a number of answers", pick)
+			}
+		}
+		cards = append(cards, c)
+	}
+	return cards, nil
+}
+
+// NormalizeCardText decodes the HTML entities in the text of a card, turns the ways decks write a blank into _,
+// and takes out HTML line breaks and formatting.
+func NormalizeCardText(Text string) string {
+	Text = html.UnescapeString(Text)
+	Text = lineBreaks.ReplaceAllString(Text, " ")
+	Text = formatting.ReplaceAllString(Text, "")
+	Text = blanks.ReplaceAllString(Text, "_")
+	return strings.TrimSpace(Text)
+}
+
+// ImportCards turns imported cards into a pack called Name, with IDs from FirstID on.
+// Cards that cannot be played, or that are already in Existing or earlier in the import, are skipped.
+func ImportCards(Cards []ImportedCard, Name string, FirstID int, Existing *game.Deck) (game.Pack, ImportReport) {
+	pack := game.Pack{Name: Name}
+	var report ImportReport
+	skip := func(c ImportedCard, Reason string, Args ...interface{}) {
+		report.Skipped = append(report.Skipped, c.Where+": "+fmt.Sprintf(Reason, Args...))
+	}
+	questions, answers := make(map[string]int), make(map[string]int)
+	for _, q := range Existing.Questions {
+		questions[strings.ToLower(q.String())] = q.ID
+	}
+	for _, a := range Existing.Answers {
+		answers[strings.ToLower(a.String())] = a.ID
+	}
+	ID := FirstID
+	for _, c := range Cards {
+		text := NormalizeCardText(c.Text)
+		if text == "" {
+			skip(c, "it does not have any text")
+			continue
+		}
+		if c.Problem != "" {
+			skip(c, "%v: %q", c.Problem, text)
+			continue
+		}
+		seen := answers
+		if c.Question {
+			seen = questions
+		}
+		if other, ok := seen[strings.ToLower(text)]; ok {
+			skip(c, "it is the same as card %v: %q", other, text)
+			continue
+		}
+		var notes []string
+		if leftoverHTML.MatchString(text) {
+			notes = append(notes, "it still has HTML in it")
+		}
+		if c.Question {
+			q := game.QuestionCard{ID: ID, Text: text, NumAnswers: c.Pick, Expansion: c.Pack}
+			if q.NumAnswers == 0 {
+				q.NumAnswers = q.Blanks()
+			}
+			if q.NumAnswers == 0 {
+				q.NumAnswers = 1
+			}
+			if q.Blanks() != 0 && q.Blanks() != q.NumAnswers {
+				skip(c, "it has %v blanks but its pick is %v: %q", q.Blanks(), q.NumAnswers, text)
+				continue
+			}
+			if q.NumAnswers > 3 {
+				notes = append(notes, "it asks for "+strconv.Itoa(q.NumAnswers)+" answers")
+			}
+			pack.Questions = append(pack.Questions, q)
+		} else {
+			if strings.Contains(text, "_") {
+				notes = append(notes, "it is a white card with a blank")
+			}
+			pack.Answers = append(pack.Answers, game.AnswerCard{ID: ID, Text: text, Expansion: c.Pack})
+		}
+		seen[strings.ToLower(text)] = ID
+		for _, note := range notes {
+			report.Suspicious = append(report.Suspicious, fmt.Sprintf("%v (card %v): %v: %q", c.Where, ID, note, text))
+		}
+		ID++
+	}
+	return pack, report
+}
+
+// RunImport runs the cards import command, which turns a deck in another format into a pack for --cards-dir.
+// The IDs of the cards start after the highest ID in Deck, so that they do not clash with the cards the bot has.
+func RunImport(Args []string, Deck *game.Deck, CardsDir string, Out io.Writer) error {
+	flags := flag.NewFlagSet("cards import", flag.ExitOnError)
+	name := flags.String("name", "", "the name of the pack (default the name of the file)")
+	format := flags.String("format", "", "the format of the file: json, csv or tsv (default from the file's extension)")
+	output := flags.String("out", "", "the pack file to write (default <name>.json in --cards-dir, or in this directory)")
+	firstID := flags.Int("first-id", 0, "the ID of the first card (default one more than the highest ID in the deck)")
+	flags.Parse(Args)
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: %v cards import [--name name] [--format json|csv|tsv] [--out file] [--first-id id] file", os.Args[0])
+	}
+	file := flags.Arg(0)
+	if *name == "" {
+		*name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(file)), ".")
+	}
+	if *output == "" {
+		*output = filepath.Join(CardsDir, *name+".json")
+	}
+	if *firstID <= 0 {
+		for _, q := range Deck.Questions {
+			if q.ID >= *firstID {
+				*firstID = q.ID + 1
+			}
+		}
+		for _, a := range Deck.Answers {
+			if a.ID >= *firstID {
+				*firstID = a.ID + 1
+			}
+		}
+	}
+	if _, err := os.Stat(*output); err == nil {
+		return fmt.Errorf("%v already exists", *output)
+	}
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	var cards []ImportedCard
+	switch *format {
+	case "json":
+		cards, err = ReadJSONAgainstHumanity(data)
+	case "csv":
+		cards, err = ReadDelimited(bytes.NewReader(data), ',')
+	case "tsv":
+		cards, err = ReadDelimited(bytes.NewReader(data), '\t')
+	default:
+		return fmt.Errorf("unknown format %q: use json, csv or tsv", *format)
+	}
+	if err != nil {
+		return fmt.Errorf("%v: %v", file, err)
+	}
+
+	pack, report := ImportCards(cards, *name, *firstID, Deck)
+	// The pack is checked the way the bot will check it when it loads it.
+	check := &game.Deck{Questions: append([]game.QuestionCard(nil), Deck.Questions...), Answers: append([]game.AnswerCard(nil), Deck.Answers...)}
+	if err := check.Add(pack); err != nil {
+		return err
+	}
+	data, err = json.MarshalIndent(pack, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(*output, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+
+	imported := len(pack.Questions) + len(pack.Answers)
+	fmt.Fprintf(Out, "Imported %v from %v into %v.  Questions: %v.  Answers: %v.\n", CountCards(imported), file, *output, len(pack.Questions), len(pack.Answers))
+	if imported != 0 {
+		fmt.Fprintf(Out, "The cards have the IDs %v to %v.\n", *firstID, *firstID+imported-1)
+	}
+	if len(report.Skipped) != 0 {
+		fmt.Fprintf(Out, "\nSkipped %v:\n", CountCards(len(report.Skipped)))
+		for _, line := range report.Skipped {
+			fmt.Fprintf(Out, "    %v\n", line)
+		}
+	}
+	if len(report.Suspicious) != 0 {
+		fmt.Fprintf(Out, "\nThese cards were imported, but you may want to check them:\n")
+		for _, line := range report.Suspicious {
+			fmt.Fprintf(Out, "    %v\n", line)
+		}
+	}
+	return nil
+}
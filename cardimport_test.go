@@ -0,0 +1,163 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/thedadams/cahbot/game"
+)
+
+func TestReadJSONAgainstHumanity(t *testing.T) {
+	for _, c := range []struct {
+		name  string
+		data  string
+		want  []ImportedCard
+		error bool
+	}{
+		{
+			name: "a pack",
+			data: `{"name": "House", "black": [{"text": "Why is there _ and _?", "pick": 2}], "white": ["A cat.", {"text": "A \"quoted\" dog."}]}`,
+			want: []ImportedCard{
+				{Where: "black card 1", Question: true, Text: "Why is there _ and _?", Pick: 2, Pack: "House"},
+				{Where: "white card 1", Text: "A cat.", Pack: "House"},
+				{Where: "white card 2", Text: `A "quoted" dog.`, Pack: "House"},
+			},
+		},
+		{
+			name: "a list of packs",
+			data: "\n[{\"name\": \"One\", \"white\": [\"A cat.\"]},\n\n {\"name\": \"Two\", \"black\": [{\"text\": \"Who ate ___?\"}], \"white\": [\"A dog.\"]}]\n",
+			want: []ImportedCard{
+				{Where: "white card 1", Text: "A cat.", Pack: "One"},
+				{Where: "black card 1", Question: true, Text: "Who ate ___?", Pack: "Two"},
+				{Where: "white card 2", Text: "A dog.", Pack: "Two"},
+			},
+		},
+		{name: "no cards", data: `{"name": "Empty"}`},
+		{name: "nothing", data: "", error: true},
+		{name: "not JSON", data: "black,white", error: true},
+		{name: "a pack that is cut off", data: `{"name": "House", "white": ["A cat.",`, error: true},
+		{name: "a white card that is a number", data: `{"white": [5]}`, error: true},
+		{name: "black cards that are not a list", data: `[{"black": "Who ate _?"}]`, error: true},
+	} {
+		cards, err := ReadJSONAgainstHumanity([]byte(c.data))
+		if (err != nil) != c.error {
+			t.Errorf("%v: got the error %v, want an error: %v", c.name, err, c.error)
+			continue
+		}
+		if !reflect.DeepEqual(cards, c.want) {
+			t.Errorf("%v: got %+v, want %+v", c.name, cards, c.want)
+		}
+	}
+}
+
+func TestReadDelimited(t *testing.T) {
+	for _, c := range []struct {
+		name  string
+		data  string
+		comma rune
+		want  []ImportedCard
+		error bool
+	}{
+		{
+			name:  "quoted fields",
+			data:  "Type,Text,Pick,Pack\nblack,\"Why is there _, and _?\",2,House\nwhite,\"A \"\"quoted\"\" dog.\",,House\nwhite,\"Two\nlines.\"\nw,A cat.\n",
+			comma: ',',
+			want: []ImportedCard{
+				{Where: "line 2", Question: true, Text: "Why is there _, and _?", Pick: 2, Pack: "House"},
+				{Where: "line 3", Text: `A "quoted" dog.`, Pack: "House"},
+				{Where: "line 4", Text: "Two\nlines."},
+				{Where: "line 6", Text: "A cat."},
+			},
+		},
+		{
+			name:  "blank lines",
+			data:  "text\tcolor\n\nWho ate _?\tBlack\n\n\n  A cat.  \twhite\n",
+			comma: '\t',
+			want: []ImportedCard{
+				{Where: "line 3", Question: true, Text: "Who ate _?"},
+				{Where: "line 6", Text: "A cat."},
+			},
+		},
+		{
+			name:  "rows that are not cards",
+			data:  "type,text,pick\ngreen,A frog.\nblack,Who ate _?,one\nblack,Who ate _?,-1\nwhite\n",
+			comma: ',',
+			want: []ImportedCard{
+				{Where: "line 2", Text: "A frog.", Problem: `"green" is not black or white`},
+				{Where: "line 3", Question: true, Text: "Who ate _?", Problem: `"one" is not a number of answers`},
+				{Where: "line 4", Question: true, Text: "Who ate _?", Pick: -1, Problem: `"-1" is not a number of answers`},
+				{Where: "line 5"},
+			},
+		},
+		{name: "nothing", data: "", comma: ','},
+		{name: "only the header", data: "type,text\n", comma: ','},
+		{name: "no type column", data: "text,pick\nWho ate _?,1\n", comma: ',', error: true},
+		{name: "the wrong separator", data: "type,text\nwhite,A cat.\n", comma: '\t', error: true},
+		{name: "no header", data: "black,Who ate _?\n", comma: ',', error: true},
+	} {
+		cards, err := ReadDelimited(strings.NewReader(c.data), c.comma)
+		if (err != nil) != c.error {
+			t.Errorf("%v: got the error %v, want an error: %v", c.name, err, c.error)
+			continue
+		}
+		if !reflect.DeepEqual(cards, c.want) {
+			t.Errorf("%v: got %+v, want %+v", c.name, cards, c.want)
+		}
+	}
+}
+
+func TestImportCards(t *testing.T) {
+	existing, err := game.NewDeck([]byte(`[{"id": 1, "text": "Who ate _?", "numAnswers": 1}]`), []byte(`[{"id": 2, "text": "A cat."}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cards := []ImportedCard{
+		{Where: "line 2", Question: true, Text: "Why is there ____ and ____?"},
+		{Where: "line 3", Question: true, Text: "What is the answer?"},
+		{Where: "line 4", Question: true, Text: "Pick three: _ _ _", Pick: 3},
+		{Where: "line 5", Question: true, Text: "Only _?", Pick: 2},
+		{Where: "line 6", Text: "A &quot;quoted&quot; <i>dog</i>."},
+		{Where: "line 7", Text: `A "Quoted" dog.`},
+		{Where: "line 8", Text: "a cat."},
+		{Where: "line 9", Question: true, Text: "who ate _?"},
+		{Where: "line 10", Text: "   "},
+		{Where: "line 11", Text: "A frog.", Problem: `"green" is not black or white`},
+		{Where: "line 12", Text: "A <blink>frog</blink>.", Pack: "Odd"},
+		{Where: "line 13", Text: "A frog named _."},
+	}
+	pack, report := ImportCards(cards, "House", 100, existing)
+	want := game.Pack{
+		Name: "House",
+		Questions: []game.QuestionCard{
+			{ID: 100, Text: "Why is there _ and _?", NumAnswers: 2},
+			{ID: 101, Text: "What is the answer?", NumAnswers: 1},
+			{ID: 102, Text: "Pick three: _ _ _", NumAnswers: 3},
+		},
+		Answers: []game.AnswerCard{
+			{ID: 103, Text: `A "quoted" dog.`},
+			{ID: 104, Text: "A <blink>frog</blink>.", Expansion: "Odd"},
+			{ID: 105, Text: "A frog named _."},
+		},
+	}
+	if !reflect.DeepEqual(pack, want) {
+		t.Errorf("got %+v, want %+v", pack, want)
+	}
+	wantReport := ImportReport{
+		Skipped: []string{
+			`line 5: it has 1 blanks but its pick is 2: "Only _?"`,
+			`line 7: it is the same as card 103: "A \"Quoted\" dog."`,
+			`line 8: it is the same as card 2: "a cat."`,
+			`line 9: it is the same as card 1: "who ate _?"`,
+			"line 10: it does not have any text",
+			`line 11: "green" is not black or white: "A frog."`,
+		},
+		Suspicious: []string{
+			`line 12 (card 104): it still has HTML in it: "A <blink>frog</blink>."`,
+			`line 13 (card 105): it is a white card with a blank: "A frog named _."`,
+		},
+	}
+	if !reflect.DeepEqual(report, wantReport) {
+		t.Errorf("got the report %+v, want %+v", report, wantReport)
+	}
+}
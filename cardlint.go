@@ -0,0 +1,205 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/thedadams/cahbot/game"
+)
+
+// MaxCallbackData is the most bytes Telegram allows in the data of a button.
+const MaxCallbackData = 64
+
+// BuiltInCards is where the built-in cards are said to be in the problems lint finds.
+const BuiltInCards = "cards.go"
+
+// LintCard is a card that is checked by lint, and where it came from.
+type LintCard struct {
+	File       string
+	Question   bool
+	ID         int
+	Text       string
+	NumAnswers int
+	Expansion  string
+	// Checked is whether problems with the card are reported.  The other cards are only compared with.
+	Checked bool
+}
+
+// String says where the card is, like "cards.go: question card 459".
+func (c LintCard) String() string {
+	kind := "answer"
+	if c.Question {
+		kind = "question"
+	}
+	return fmt.Sprintf("%v: %v card %v", c.File, kind, c.ID)
+}
+
+// LintCards returns the problems with the checked cards: questions whose blanks do not match the number of
+// answers, cards with the same ID or text as another, HTML entities or escaped quotes left in the text,
+// text longer than MaxText characters that will not fit on a button, and expansions that are missing or too
+// long for the data of a button.
+func LintCards(Cards []LintCard, MaxText int) []string {
+	var problems []string
+	report := func(c LintCard, Problem string, Args ...interface{}) {
+		problems = append(problems, c.String()+": "+fmt.Sprintf(Problem, Args...))
+	}
+	IDs := make(map[int]LintCard)
+	texts := make(map[string]LintCard)
+	for _, c := range Cards {
+		other, sameID := IDs[c.ID]
+		if !sameID {
+			IDs[c.ID] = c
+		}
+		key := fmt.Sprintf("%v:%v", c.Question, strings.ToLower(strings.TrimSpace(game.CleanText(c.Text))))
+		same, sameText := texts[key]
+		if !sameText {
+			texts[key] = c
+		}
+		if !c.Checked {
+			continue
+		}
+		if c.ID <= 0 {
+			report(c, "it does not have an ID")
+		} else if sameID {
+			report(c, "it has the same ID as %v", other)
+		}
+		if strings.TrimSpace(c.Text) == "" {
+			report(c, "it does not have any text")
+			continue
+		}
+		if sameText {
+			report(c, "it has the same text as %v: %q", same, c.Text)
+		}
+		if c.Question {
+			blanks := strings.Count(c.Text, "_")
+			if c.NumAnswers < 1 {
+				report(c, "its numAnswers is %v", c.NumAnswers)
+			} else if blanks != 0 && blanks != c.NumAnswers {
+				report(c, "it has %v blanks but its numAnswers is %v: %q", blanks, c.NumAnswers, c.Text)
+			}
+		} else if strings.Contains(c.Text, "_") {
+			report(c, "it is an answer with a blank: %q", c.Text)
+		}
+		if html := leftoverHTML.FindAllString(c.Text, -1); len(html) != 0 {
+			report(c, "it has HTML in it (%v): %q", strings.Join(html, " "), c.Text)
+		}
+		if strings.Contains(c.Text, `\"`) {
+			report(c, "it has escaped quotes in it: %q", c.Text)
+		}
+		if n := utf8.RuneCountInString(game.CleanText(c.Text)); n > MaxText {
+			report(c, "its text is %v characters long, which is too long for a button: %q", n, c.Text)
+		}
+		if strings.TrimSpace(c.Expansion) == "" {
+			report(c, "it does not have an expansion")
+		} else if len("Pack::"+c.Expansion) > MaxCallbackData {
+			report(c, "the name of its expansion is too long for the data of a button: %q", c.Expansion)
+		}
+	}
+	return problems
+}
+
+// lintPack reads the cards of a pack file for lint.  Cards without an expansion belong to the pack.
+func lintPack(File string, Checked bool) ([]LintCard, error) {
+	data, err := ioutil.ReadFile(File)
+	if err != nil {
+		return nil, err
+	}
+	var pack game.Pack
+	if err := json.Unmarshal(data, &pack); err != nil {
+		return nil, err
+	}
+	if pack.Name == "" {
+		pack.Name = strings.TrimSuffix(filepath.Base(File), ".json")
+	}
+	var cards []LintCard
+	for _, q := range pack.Questions {
+		if q.Expansion == "" {
+			q.Expansion = pack.Name
+		}
+		cards = append(cards, LintCard{File, true, q.ID, q.Text, q.NumAnswers, q.Expansion, Checked})
+	}
+	for _, a := range pack.Answers {
+		if a.Expansion == "" {
+			a.Expansion = pack.Name
+		}
+		cards = append(cards, LintCard{File, false, a.ID, a.Text, 0, a.Expansion, Checked})
+	}
+	return cards, nil
+}
+
+// RunLint runs the cards lint command, which reports the problems with card packs.
+// It checks the pack files it is given, or the built-in cards and every pack in CardsDir if it is given none.
+// The cards are compared with the built-in cards and the packs in CardsDir, to find the ones that are the same.
+// It returns an error if there are any problems, so that the command fails.
+func RunLint(Args []string, CardsDir string, Out io.Writer) error {
+	flags := flag.NewFlagSet("cards lint", flag.ExitOnError)
+	maxText := flags.Int("max-text", 150, "the most characters a card can have and still fit on a button")
+	flags.Parse(Args)
+	checkAll := flags.NArg() == 0
+
+	var questions []game.QuestionCard
+	var answers []game.AnswerCard
+	if err := json.Unmarshal(AllQuestions, &questions); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(AllAnswers, &answers); err != nil {
+		return err
+	}
+	var cards []LintCard
+	for _, q := range questions {
+		cards = append(cards, LintCard{BuiltInCards, true, q.ID, q.Text, q.NumAnswers, q.Expansion, checkAll})
+	}
+	for _, a := range answers {
+		cards = append(cards, LintCard{BuiltInCards, false, a.ID, a.Text, 0, a.Expansion, checkAll})
+	}
+
+	var problems []string
+	files := flags.Args()
+	checked := make(map[string]bool)
+	for _, file := range files {
+		abs, _ := filepath.Abs(file)
+		checked[abs] = true
+	}
+	if CardsDir != "" {
+		packs, err := filepath.Glob(filepath.Join(CardsDir, "*.json"))
+		if err != nil {
+			return err
+		}
+		for _, file := range packs {
+			if abs, _ := filepath.Abs(file); !checked[abs] {
+				files = append(files, file)
+			}
+		}
+	}
+	for _, file := range files {
+		abs, _ := filepath.Abs(file)
+		check := checkAll || checked[abs]
+		pack, err := lintPack(file, check)
+		if err != nil {
+			if check {
+				problems = append(problems, fmt.Sprintf("%v: %v", file, err))
+			}
+			continue
+		}
+		if check && len(pack) == 0 {
+			problems = append(problems, fmt.Sprintf("%v: the pack does not have any cards", file))
+		}
+		cards = append(cards, pack...)
+	}
+
+	problems = append(problems, LintCards(cards, *maxText)...)
+	for _, p := range problems {
+		fmt.Fprintln(Out, p)
+	}
+	if len(problems) != 0 {
+		return fmt.Errorf("problems with the cards found: %v", len(problems))
+	}
+	fmt.Fprintln(Out, "The cards have no problems.")
+	return nil
+}
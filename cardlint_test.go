@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thedadams/cahbot/game"
+)
+
+// writePack writes a pack to a file in Dir and returns the file.
+func writePack(t *testing.T, Dir string, Pack game.Pack) string {
+	t.Helper()
+	data, err := json.Marshal(Pack)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(Dir, Pack.Name+".json")
+	if err := ioutil.WriteFile(file, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestLintReportsEveryProblem(t *testing.T) {
+	long := strings.Repeat("a", 151)
+	file := writePack(t, t.TempDir(), game.Pack{
+		Name: "broken",
+		Questions: []game.QuestionCard{
+			{ID: 7000, Text: "Who ate _ and _?", NumAnswers: 1},
+			{ID: 7001, Text: "Who?"},
+			{ID: 7012, Text: "Who ate _?", NumAnswers: 1},
+		},
+		Answers: []game.AnswerCard{
+			{ID: 7002, Text: "A frog named _."},
+			{ID: 7003, Text: "Tom &amp; Jerry."},
+			{ID: 7004, Text: `A \"quoted\" frog.`},
+			{ID: 7004, Text: "A newt."},
+			{ID: 7006, Text: "A toad."},
+			{ID: 7007, Text: "a toad."},
+			{Text: "No ID."},
+			{ID: 7008},
+			{ID: 7009, Text: long},
+			{ID: 7010, Text: "A long pack.", Expansion: strings.Repeat("x", 59)},
+			{ID: 7011, Text: "No pack.", Expansion: " "},
+			{ID: 7013, Text: "Who ate _?"},
+		},
+	})
+	var out bytes.Buffer
+	err := RunLint([]string{file}, "", &out)
+	want := []string{
+		file + `: question card 7000: it has 2 blanks but its numAnswers is 1: "Who ate _ and _?"`,
+		file + ": question card 7001: its numAnswers is 0",
+		file + `: answer card 7002: it is an answer with a blank: "A frog named _."`,
+		file + `: answer card 7003: it has HTML in it (&amp;): "Tom &amp; Jerry."`,
+		file + `: answer card 7004: it has escaped quotes in it: "A \\\"quoted\\\" frog."`,
+		file + ": answer card 7004: it has the same ID as " + file + ": answer card 7004",
+		file + ": answer card 7007: it has the same text as " + file + `: answer card 7006: "a toad."`,
+		file + ": answer card 0: it does not have an ID",
+		file + ": answer card 7008: it does not have any text",
+		file + ": answer card 7009: its text is 151 characters long, which is too long for a button: \"" + long + "\"",
+		file + `: answer card 7010: the name of its expansion is too long for the data of a button: "` + strings.Repeat("x", 59) + `"`,
+		file + ": answer card 7011: it does not have an expansion",
+		file + `: answer card 7013: it is an answer with a blank: "Who ate _?"`,
+	}
+	if got := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n"); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got the problems:\n%v\nwant:\n%v", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if err == nil || err.Error() != "problems with the cards found: 13" {
+		t.Errorf("got the error %v, want the 13 problems counted", err)
+	}
+}
+
+func TestLintPassesACleanPack(t *testing.T) {
+	dir := t.TempDir()
+	file := writePack(t, dir, game.Pack{
+		Name: "clean",
+		Questions: []game.QuestionCard{
+			{ID: 7000, Text: "Who ate _?", NumAnswers: 1},
+			{ID: 7001, Text: "What goes best with _ and _?", NumAnswers: 2},
+			{ID: 7002, Text: "What is in the box?", NumAnswers: 1, Expansion: "Mystery"},
+		},
+		Answers: []game.AnswerCard{
+			{ID: 7003, Text: "The last slice."},
+			{ID: 7004, Text: "A \"quoted\" frog."},
+		},
+	})
+	var out bytes.Buffer
+	if err := RunLint([]string{file}, dir, &out); err != nil || out.String() != "The cards have no problems.\n" {
+		t.Errorf("got %q and the error %v, want no problems", out.String(), err)
+	}
+}
+
+func TestLintReportsPacksThatCannotBeRead(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "bad.json")
+	if err := ioutil.WriteFile(file, []byte(`{"name": "bad", "answers": [`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	empty := writePack(t, dir, game.Pack{Name: "empty"})
+	var out bytes.Buffer
+	if err := RunLint([]string{file, empty}, "", &out); err == nil {
+		t.Error("got no error for a pack that is not JSON")
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], file+": ") || lines[1] != empty+": the pack does not have any cards" {
+		t.Errorf("got %q, want the pack that is not JSON and the empty pack reported", lines)
+	}
+}
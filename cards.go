@@ -1,8 +1,5 @@
 package main
 
-// AllExpansions contains the data needed for adding or removing expansion packs.
-var AllExpansions = []byte(`[{"name": "Base", "cdata": "Expansion::Base", "options": [{"name": "Include Base", "cdata": "Include::Base"}, {"name": "Exclude Base", "cdata": "Exclude::Base"}]}, {"name": "Base", "cdata": "Expansion::Base", "options": [{"name": "Include Base", "cdata": "Include::Base"}, {"name": "Exclude Base", "cdata": "Exclude::Base"}]}]`)
-
 // AllQuestions contains the "black card" information for the game.
 var AllQuestions = []byte(`[{"id": 459, "expansion": "Base", "text": "_?  There's an app for that.", "numAnswers": 1}, {"id": 460, "expansion": "Base", "text": "Why can't I sleep at night?", "numAnswers": 1}, {"id": 461, "expansion": "Base", "text": "What's that smell?", "numAnswers": 1}, {"id": 462, "expansion": "Base", "text": "I got 99 problems but _ ain't one.", "numAnswers": 1}, {"id": 463, "expansion": "Base", "text": "Maybe she's born with it.  Maybe it's _.", "numAnswers": 1}, {"id": 464, "expansion": "Base", "text": "What's the next Happy Meal&reg; toy?", "numAnswers": 1}, {"id": 465, "expansion": "Base", "text": "Anthropologists have recently discovered a primitive tribe that worships _.", "numAnswers": 1}, {"id": 466, "expansion": "Base", "text": "It's a pity that kids these days are all getting involved with _.", "numAnswers": 1}, {"id": 467, "expansion": "Base", "text": "During Picasso's often-overlooked Brown Period, he produced hundreds of paintings of _.", "numAnswers": 1}, {"id": 468, "expansion": "Base", "text": "Alternative medicine is now embracing the curative powers of _.", "numAnswers": 1}, {"id": 469, "expansion": "Base", "text": "And the Academy Award for _ goes to _.", "numAnswers": 2}, {"id": 470, "expansion": "Base", "text": "What's that sound?", "numAnswers": 1}, {"id": 471, "expansion": "Base", "text": "What ended my last relationship?", "numAnswers": 1}, {"id": 472, "expansion": "Base", "text": "MTV's new reality show features eight washed-up celebrities living with _.", "numAnswers": 1}, {"id": 473, "expansion": "Base", "text": "I drink to forget _.", "numAnswers": 1}, {"id": 474, "expansion": "Base", "text": "I'm sorry professor, but I couldn't complete my homework because of _.", "numAnswers": 1}, {"id": 475, "expansion": "Base", "text": "What is Batman's guilty pleasure?", "numAnswers": 1}, {"id": 476, "expansion": "Base", "text": "This is the way the world ends <br> This is the way the world ends <br> Not with a bang but with _.", "numAnswers": 1}, {"id": 477, "expansion": "Base", "text": "What's a girl's best friend?", "numAnswers": 1}, {"id": 478, "expansion": "Base", "text": "TSA guidelines now prohibit _ on airplanes.", "numAnswers": 1}, {"id": 479, "expansion": "Base", "text": "_.  That's how I want to die.", "numAnswers": 1}, {"id": 480, "expansion": "Base", "text": "For my next trick, I will pull _ out of _.", "numAnswers": 2}, {"id": 481, "expansion": "Base", "text": "In the new Disney Channel Original Movie, Hannah Montana struggles with _ for the first time.", "numAnswers": 1}, {"id": 482, "expansion": "Base", "text": "_ is a slippery slope that leads to _.", "numAnswers": 2}, {"id": 483, "expansion": "Base", "text": "What does Dick Cheney prefer?", "numAnswers": 1}, {"id": 484, "expansion": "Base", "text": "Dear Abby, I'm having some trouble with _ and would like your advice.", "numAnswers": 1}, {"id": 485, "expansion": "Base", "text": "Instead of coal, Santa now gives the bad children _.", "numAnswers": 1}, {"id": 486, "expansion": "Base", "text": "What's the most emo?", "numAnswers": 1}, {"id": 487, "expansion": "Base", "text": "In 1,000 years when paper money is but a distant memory, _ will be our currency.", "numAnswers": 1}, {"id": 488, "expansion": "Base", "text": "What's the next superhero/sidekick duo?", "numAnswers": 2}, {"id": 489, "expansion": "Base", "text": "In M. Night Shyamalan's new movie, Bruce Willis discovers that _ had really been _ all along.", "numAnswers": 2}, {"id": 490, "expansion": "Base", "text": "A romantic, candlelit dinner would be incomplete without _.", "numAnswers": 1}, {"id": 491, "expansion": "Base", "text": "_.  Becha can't have just one!", "numAnswers": 1}, {"id": 492, "expansion": "Base", "text": "White people like _.", "numAnswers": 1}, {"id": 493, "expansion": "Base", "text": "_.  High five, bro.", "numAnswers": 1}, {"id": 494, "expansion": "Base", "text": "Next from J.K. Rowling: Harry Potter and the Chamber of _.", "numAnswers": 1}, {"id": 495, "expansion": "Base", "text": "BILLY MAYS HERE FOR _.", "numAnswers": 1}, {"id": 496, "expansion": "Base", "text": "In a world ravaged by _, our only solace is _.", "numAnswers": 2}, {"id": 497, "expansion": "Base", "text": "War!  What is it good for?", "numAnswers": 1}, {"id": 498, "expansion": "Base", "text": "During sex, I like to think about _.", "numAnswers": 1}, {"id": 499, "expansion": "Base", "text": "What are my parents hiding from me?", "numAnswers": 1}, {"id": 500, "expansion": "Base", "text": "What will always get you laid?", "numAnswers": 1}, {"id": 501, "expansion": "Base", "text": "In L.A. County Jail, word is you can trade 200 cigarettes for _.", "numAnswers": 1}, {"id": 502, "expansion": "Base", "text": "What did I bring back from Mexico?", "numAnswers": 1}, {"id": 503, "expansion": "Base", "text": "What don't you want to find in your Chinese food?", "numAnswers": 1}, {"id": 504, "expansion": "Base", "text": "What will I bring back in time to convince people that I am a powerful wizard?", "numAnswers": 1}, {"id": 505, "expansion": "Base", "text": "How am I maintaining my relationship status?", "numAnswers": 1}, {"id": 506, "expansion": "Base", "text": "_.  It's a trap!", "numAnswers": 1}, {"id": 507, "expansion": "Base", "text": "Coming to Broadway this season, _: The Musical.", "numAnswers": 1}, {"id": 508, "expansion": "Base", "text": "While the United States raced the Soviet Union to the moon, the Mexican government funneled millions of pesos into research on _.", "numAnswers": 1}, {"id": 509, "expansion": "Base", "text": "After the earthquake, Sean Penn brought _ to the people of Haiti.", "numAnswers": 1}, {"id": 510, "expansion": "Base", "text": "Next on ESPN2, the World Series of _.", "numAnswers": 1}, {"id": 511, "expansion": "Base", "text": "Step 1: _.  Step 2: _.  Step 3: Profit.", "numAnswers": 2}, {"id": 512, "expansion": "Base", "text": "Rumor has it that Vladimir Putin's favorite dish is _ stuffed with _.", "numAnswers": 2}, {"id": 513, "expansion": "Base", "text": "But before I kill you, Mr. Bond, I must show you _.", "numAnswers": 1}, {"id": 514, "expansion": "Base", "text": "What gives me uncontrollable gas?", "numAnswers": 1}, {"id": 515, "expansion": "Base", "text": "What do old people smell like?", "numAnswers": 1}, {"id": 516, "expansion": "Base", "text": "The class field trip was completely ruined by _.", "numAnswers": 1}, {"id": 517, "expansion": "Base", "text": "When Pharaoh remained unmoved, Moses called down a Plague of _.", "numAnswers": 1}, {"id": 518, "expansion": "Base", "text": "What's my secret power?", "numAnswers": 1}, {"id": 519, "expansion": "Base", "text": "What's there a ton of in heaven?", "numAnswers": 1}, {"id": 520, "expansion": "Base", "text": "What would grandma find disturbing, yet oddly charming?", "numAnswers": 1}, {"id": 521, "expansion": "Base", "text": "I never truly understood _ until I encountered _.", "numAnswers": 2}, {"id": 522, "expansion": "Base", "text": "What did the U.S. airdrop to the children of Afghanistan?", "numAnswers": 1}, {"id": 523, "expansion": "Base", "text": "What helps Obama unwind?", "numAnswers": 1}, {"id": 524, "expansion": "Base", "text": "What did Vin Diesel eat for dinner?", "numAnswers": 1}, {"id": 525, "expansion": "Base", "text": "_: good to the last drop.", "numAnswers": 1}, {"id": 526, "expansion": "Base", "text": "Why am I sticky?", "numAnswers": 1}, {"id": 527, "expansion": "Base", "text": "What gets better with age?", "numAnswers": 1}, {"id": 528, "expansion": "Base", "text": "_: kid-tested, mother-approved.", "numAnswers": 1}, {"id": 529, "expansion": "Base", "text": "What's the crustiest?", "numAnswers": 1}, {"id": 530, "expansion": "Base", "text": "What's Teach for America using to inspire inner city students to succeed?", "numAnswers": 1}, {"id": 531, "expansion": "Base", "text": "Studies show that lab rats navigate mazes 50% faster after being exposed to _.", "numAnswers": 1}, {"id": 532, "expansion": "Base", "text": "Life for American Indians was forever changed when the White Man introduced them to _.", "numAnswers": 1}, {"id": 533, "expansion": "Base", "text": "Make a haiku.", "numAnswers": 3}, {"id": 534, "expansion": "Base", "text": "I do not know with what weapons World War III will be fought, but World War IV will be fought with _.", "numAnswers": 1}, {"id": 535, "expansion": "Base", "text": "Why do I hurt all over?", "numAnswers": 1}, {"id": 536, "expansion": "Base", "text": "What am I giving up for Lent?", "numAnswers": 1}, {"id": 537, "expansion": "Base", "text": "In Michael Jackson's final moments, he thought about _.", "numAnswers": 1}, {"id": 538, "expansion": "Base", "text": "In an attempt to reach a wider audience, the Smithsonian Museum of Natural History has opened an interactive exhibit on _.", "numAnswers": 1}, {"id": 539, "expansion": "Base", "text": "When I am President of the United States, I will create the Department of _.", "numAnswers": 1}, {"id": 540, "expansion": "Base", "text": "Lifetime&reg; presents _, the story of _.", "numAnswers": 2}, {"id": 541, "expansion": "Base", "text": "When I am a billionaire, I shall erect a 50-foot statue to commemorate _.", "numAnswers": 1}, {"id": 542, "expansion": "Base", "text": "When I was tripping on acid, _ turned into _.", "numAnswers": 2}, {"id": 543, "expansion": "Base", "text": "That's right, I killed _.  How, you ask?  _.", "numAnswers": 2}, {"id": 544, "expansion": "Base", "text": "What's my anti-drug?", "numAnswers": 1}, {"id": 545, "expansion": "Base", "text": "_ + _ = _.", "numAnswers": 3}, {"id": 546, "expansion": "Base", "text": "What never fails to liven up the party?", "numAnswers": 1}, {"id": 547, "expansion": "Base", "text": "What's the new fad diet?", "numAnswers": 1}, {"id": 548, "expansion": "Base", "text": "Major League Baseball has banned _ for giving players an unfair advantage.", "numAnswers": 1}, {"id": 629, "expansion": "CAHe1", "text": "My plan for world domination begins with _.", "numAnswers": 1}, {"id": 630, "expansion": "CAHe1", "text": "The CIA now interrogates enemy agents by repeatedly subjecting them to _.", "numAnswers": 1}, {"id": 631, "expansion": "CAHe1", "text": "Dear Sir or Madam, We regret to inform you that the Office of _ has denied your request for _", "numAnswers": 2}, {"id": 632, "expansion": "CAHe1", "text": "In Rome, there are whisperings that the Vatican has a secret room devoted to _.", "numAnswers": 1}, {"id": 633, "expansion": "CAHe1", "text": "Science will never explain _.", "numAnswers": 1}, {"id": 634, "expansion": "CAHe1", "text": "When all else fails, I can always masturbate to _.", "numAnswers": 1}, {"id": 635, "expansion": "CAHe1", "text": "I learned the hard way that you can't cheer up a grieving friend with _.", "numAnswers": 1}, {"id": 636, "expansion": "CAHe1", "text": "In its new tourism campaign, Detroit proudly proclaims that it has finally eliminated _.", "numAnswers": 1}, {"id": 637, "expansion": "CAHe1", "text": "An international tribunal has found _ guilty of _.", "numAnswers": 2}, {"id": 638, "expansion": "CAHe1", "text": "The socialist governments of Scandinavia have declared that access to _ is a basic human right.", "numAnswers": 1}, {"id": 639, "expansion": "CAHe1", "text": "In his new self-produced album, Kanye West raps over the sounds of _.", "numAnswers": 1}, {"id": 640, "expansion": "CAHe1", "text": "What's the gift that keeps on giving?", "numAnswers": 1}, {"id": 641, "expansion": "CAHe1", "text": "Next season on Man vs. Wild, Bear Grylls must survive in the depths of the Amazon with only _ and his wits.", "numAnswers": 1}, {"id": 642, "expansion": "CAHe1", "text": "When I pooped, what came out of my butt?", "numAnswers": 1}, {"id": 643, "expansion": "CAHe1", "text": "In the distant future, historians will agree that _ marked the beginning of America's decline.", "numAnswers": 1}, {"id": 644, "expansion": "CAHe1", "text": "In a pinch, _ can be a suitable substitute for _.", "numAnswers": 2}, {"id": 645, "expansion": "CAHe1", "text": "What has been making life difficult at the nudist colony?", "numAnswers": 1}, {"id": 646, "expansion": "CAHe1", "text": "Michael Bay's new three-hour action epic pits _ against _.", "numAnswers": 2}, {"id": 647, "expansion": "CAHe1", "text": "And I would have gotten away with it, too, if it hadn't been for _.", "numAnswers": 1}, {"id": 648, "expansion": "CAHe1", "text": "What brought the orgy to a grinding halt?", "numAnswers": 1}, {"id": 724, "expansion": "CAHe2", "text": "During his midlife crisis, my dad got really into _.", "numAnswers": 1}, {"id": 725, "expansion": "CAHe2", "text": "_ would be woefully incomplete without _.", "numAnswers": 2}, {"id": 726, "expansion": "CAHe2", "text": "My new favorite porn star is Joey &#34;_&#34; McGee.", "numAnswers": 1}, {"id": 727, "expansion": "CAHe2", "text": "Before I run for president, I must destroy all evidence of my involvement with _.", "numAnswers": 1}, {"id": 728, "expansion": "CAHe2", "text": "This is your captain speaking. Fasten your seatbelts and prepare for _.", "numAnswers": 1}, {"id": 729, "expansion": "CAHe2", "text": "In his newest and most difficult stunt, David Blaine must escape from _.", "numAnswers": 1}, {"id": 730, "expansion": "CAHe2", "text": "The Five Stages of Grief: denial, anger, bargaining, _, and acceptance.", "numAnswers": 1}, {"id": 731, "expansion": "CAHe2", "text": "My mom freaked out when she looked at my browser history and found _.com/_.", "numAnswers": 2}, {"id": 732, "expansion": "CAHe2", "text": "I went from _ to _, all thanks to _.", "numAnswers": 3}, {"id": 733, "expansion": "CAHe2", "text": "Members of New York's social elite are paying thousands of dollars just to experience _.", "numAnswers": 1}, {"id": 734, "expansion": "CAHe2", "text": "This month's Cosmo: &#34;Spice up your sex life by bringing _ into the bedroom.&#34;", "numAnswers": 1}, {"id": 735, "expansion": "CAHe2", "text": "Little Miss Muffet Sat on a tuffet, Eating her curds and _.", "numAnswers": 1}, {"id": 736, "expansion": "CAHe2", "text": "If God didn't want us to enjoy _, he wouldn't have given us _.", "numAnswers": 2}, {"id": 737, "expansion": "CAHe2", "text": "My country, 'tis of thee, sweet land of _.", "numAnswers": 1}, {"id": 738, "expansion": "CAHe2", "text": "After months of debate, the Occupy Wall Street General Assembly could only agree on &#34;More _!&#34;", "numAnswers": 1}, {"id": 739, "expansion": "CAHe2", "text": "I spent my whole life working toward _, only to have it ruined by _.", "numAnswers": 2}, {"id": 740, "expansion": "CAHe2", "text": "Next time on Dr. Phil: How to talk to your child about _.", "numAnswers": 1}, {"id": 741, "expansion": "CAHe2", "text": "Only two things in life are certain: death and _.", "numAnswers": 1}, {"id": 742, "expansion": "CAHe2", "text": "Everyone down on the ground! We don't want to hurt anyone. We're just here for _.", "numAnswers": 1}, {"id": 743, "expansion": "CAHe2", "text": "The healing process began when I joined a support group for victims of _.", "numAnswers": 1}, {"id": 744, "expansion": "CAHe2", "text": "The votes are in, and the new high school mascot is _.", "numAnswers": 1}, {"id": 745, "expansion": "CAHe2", "text": "Charades was ruined for me forever when my mom had to act out _.", "numAnswers": 1}, {"id": 746, "expansion": "CAHe2", "text": "Before _, all we had was _.", "numAnswers": 2}, {"id": 747, "expansion": "CAHe2", "text": "Tonight on 20/20: What you don't know about _ could kill you.", "numAnswers": 1}, {"id": 748, "expansion": "CAHe2", "text": "You haven't truly lived until you've experienced _ and _ at the same time.", "numAnswers": 2}, {"id": 749, "expansion": "CAHgrognards", "text": "D&D 4.0 isn't real D&D because of the _.", "numAnswers": 1}, {"id": 750, "expansion": "CAHgrognards", "text": "It's a D&D retroclone with _ added.", "numAnswers": 1}, {"id": 751, "expansion": "CAHgrognards", "text": "Storygames aren't RPGs because of the _.", "numAnswers": 1}, {"id": 752, "expansion": "CAHgrognards", "text": "The Slayer's Guide to _.", "numAnswers": 1}, {"id": 753, "expansion": "CAHgrognards", "text": "Worst character concept ever: _, but with _.", "numAnswers": 2}, {"id": 754, "expansion": "CAHgrognards", "text": "Alightment: Chaotic _", "numAnswers": 1}, {"id": 755, "expansion": "CAHgrognards", "text": "It's a D&D retroclone with _ added.", "numAnswers": 1}, {"id": 756, "expansion": "CAHgrognards", "text": "What made the paladin fall? _", "numAnswers": 1}, {"id": 757, "expansion": "CAHgrognards", "text": "The portal leads to the quasi-elemental plane of _.", "numAnswers": 1}, {"id": 758, "expansion": "CAHgrognards", "text": "The Temple of Elemental _.", "numAnswers": 1}, {"id": 759, "expansion": "CAHgrognards", "text": "Pathfinder is basically D&D _ Edition.", "numAnswers": 1}, {"id": 760, "expansion": "CAHgrognards", "text": "_ : The Storytelling Game.", "numAnswers": 1}, {"id": 761, "expansion": "CAHgrognards", "text": "People are wondering why Steve Jackson published GURPS _.", "numAnswers": 1}, {"id": 762, "expansion": "CAHgrognards", "text": "Linear Fighter, Quadratic _.", "numAnswers": 1}, {"id": 763, "expansion": "CAHgrognards", "text": "You start with 1d4 _ points.", "numAnswers": 1}, {"id": 764, "expansion": "CAHgrognards", "text": "Back when I was 12 and I was just starting playing D&D, the game had _.", "numAnswers": 1}, {"id": 765, "expansion": "CAHgrognards", "text": "Big Eyes, Small _.", "numAnswers": 1}, {"id": 766, "expansion": "CAHgrognards", "text": "In the grim darkness of the future there is only _.", "numAnswers": 1}, {"id": 767, "expansion": "CAHgrognards", "text": "My innovative new RPG has a stat for _.", "numAnswers": 1}, {"id": 768, "expansion": "CAHgrognards", "text": "A true gamer has no problem with _.", "numAnswers": 1}, {"id": 769, "expansion": "CAHgrognards", "text": "Elminster cast a potent _ spell and then had sex with _.", "numAnswers": 2}, {"id": 770, "expansion": "CAHgrognards", "text": "The Deck of Many _.", "numAnswers": 1}, {"id": 771, "expansion": "CAHgrognards", "text": "You are all at a tavern when _ approach you.", "numAnswers": 1}, {"id": 858, "expansion": "CAHweeaboo", "text": "For the convention I cosplayed as Sailor Moon, except with _.", "numAnswers": 1}, {"id": 859, "expansion": "CAHweeaboo", "text": "The worst part of Grave of the Fireflies is all the _.", "numAnswers": 1}, {"id": 860, "expansion": "CAHweeaboo", "text": "In the Evangelion remake, Shinji has to deal with _.", "numAnswers": 1}, {"id": 861, "expansion": "CAHweeaboo", "text": "Worst anime convention purchase ever? _.", "numAnswers": 1}, {"id": 862, "expansion": "CAHweeaboo", "text": "While powering up Vegeta screamed, _!", "numAnswers": 1}, {"id": 863, "expansion": "CAHweeaboo", "text": "You evaded my _ attack. Most impressive.", "numAnswers": 1}, {"id": 864, "expansion": "CAHweeaboo", "text": "I downloaded a doujin where _ got into _.", "numAnswers": 2}, {"id": 865, "expansion": "CAHweeaboo", "text": "The magical girl found out that the Power of Love is useless against _.", "numAnswers": 1}, {"id": 866, "expansion": "CAHweeaboo", "text": "The Japanese government has spent billions of yen researching _.", "numAnswers": 1}, {"id": 867, "expansion": "CAHweeaboo", "text": "In the dubbed version they changed _ into _.", "numAnswers": 2}, {"id": 868, "expansion": "CAHweeaboo", "text": "_ is Best Pony.", "numAnswers": 1}, {"id": 869, "expansion": "CAHweeaboo", "text": "The _ of Haruhi Suzumiya.", "numAnswers": 1}, {"id": 870, "expansion": "CAHweeaboo", "text": "The new thing in Akihabara is fetish cafes where you can see girls dressed up as _.", "numAnswers": 1}, {"id": 871, "expansion": "CAHweeaboo", "text": "Your drill can pierce _!", "numAnswers": 1}, {"id": 872, "expansion": "CAHweeaboo", "text": "Avatar: The Last _ bender.", "numAnswers": 1}, {"id": 873, "expansion": "CAHweeaboo", "text": "In the name of _ Sailor Moon will punish you!", "numAnswers": 1}, {"id": 874, "expansion": "CAHweeaboo", "text": "No harem anime is complete without _.", "numAnswers": 1}, {"id": 875, "expansion": "CAHweeaboo", "text": "My boyfriend's a _ now.", "numAnswers": 1}, {"id": 876, "expansion": "CAHweeaboo", "text": "The _ of _ has left me in despair!", "numAnswers": 2}, {"id": 877, "expansion": "CAHweeaboo", "text": "_.tumblr.com", "numAnswers": 1}, {"id": 878, "expansion": "CAHweeaboo", "text": "Somehow they made a cute mascot girl out of _.", "numAnswers": 1}, {"id": 879, "expansion": "CAHweeaboo", "text": "Haruko hit Naoto in the head with her bass guitar and _ came out.", "numAnswers": 1}, {"id": 966, "expansion": "CAHxmas", "text": "After blacking out during New year's Eve, I was awoken by _.", "numAnswers": 1}, {"id": 967, "expansion": "CAHxmas", "text": "This holiday season, Tim Allen must overcome his fear of _ to save Christmas.", "numAnswers": 1}, {"id": 968, "expansion": "CAHxmas", "text": "Jesus is _.", "numAnswers": 1}, {"id": 969, "expansion": "CAHxmas", "text": "Every Christmas, my uncle gets drunk and tells the story about _.", "numAnswers": 1}, {"id": 970, "expansion": "CAHxmas", "text": "What keeps me warm during the cold, cold, winter?", "numAnswers": 1}, {"id": 971, "expansion": "CAHxmas", "text": "On the third day of Christmas, my true love gave to me: three French hens, two turtle doves, and _.", "numAnswers": 1}, {"id": 972, "expansion": "CAHxmas", "text": "Wake up, America. Christmas is under attack by secular liberals and their _.", "numAnswers": 1}, {"id": 998, "expansion": "NEIndy", "text": "We got the third rope, now where's the fourth?", "numAnswers": 1}, {"id": 1001, "expansion": "NEIndy", "text": "Tonights main event, _ vs. _.", "numAnswers": 2}, {"id": 1003, "expansion": "NEIndy", "text": "Tackle, Dropdown, _.", "numAnswers": 1}, {"id": 1005, "expansion": "NEIndy", "text": "Christopher Daniels is late on his _.", "numAnswers": 1}, {"id": 1011, "expansion": "NEIndy", "text": "Instead of booking _, they should have booked _.", "numAnswers": 2}, {"id": 1012, "expansion": "NEIndy", "text": "Genius is 10% inspiration, 90% _.", "numAnswers": 1}, {"id": 1013, "expansion": "NEIndy", "text": "They found _ in the dumpster behind _.", "numAnswers": 2}, {"id": 1015, "expansion": "NEIndy", "text": "The best thing I ever got for Christmas was _.", "numAnswers": 1}, {"id": 1017, "expansion": "NEIndy", "text": "There's no crying in _.", "numAnswers": 1}, {"id": 1018, "expansion": "NEIndy", "text": "Mastodon! Pterodactyl! Triceratops! Sabretooth Tiger! _!", "numAnswers": 1}, {"id": 1020, "expansion": "NEIndy", "text": "Don't eat the _.", "numAnswers": 1}, {"id": 1023, "expansion": "NEIndy", "text": "He did _ with the _!?!", "numAnswers": 2}, {"id": 1026, "expansion": "NEIndy", "text": "SOOOOO hot, want to touch the _.", "numAnswers": 1}, {"id": 1027, "expansion": "NEIndy", "text": "Stop looking at me _!", "numAnswers": 1}, {"id": 1031, "expansion": "NEIndy", "text": "I'm cuckoo for _ puffs.", "numAnswers": 1}, {"id": 1032, "expansion": "NEIndy", "text": "Silly rabbit, _ are for kids.", "numAnswers": 1}, {"id": 1141, "expansion": "NSFH", "text": "Between love and madness lies _.", "numAnswers": 1}, {"id": 1142, "expansion": "NSFH", "text": "Instead of chess, the Grim Reaper now gambles for your soul with a game of _.", "numAnswers": 1}, {"id": 1143, "expansion": "NSFH", "text": "My father gave his life fighting to protect _ from _.", "numAnswers": 2}, {"id": 1144, "expansion": "NSFH", "text": "Why is my throat sore?", "numAnswers": 1}, {"id": 1145, "expansion": "NSFH", "text": "_ sparked a city-wide riot that only ended with _.", "numAnswers": 2}, {"id": 1146, "expansion": "NSFH", "text": "I\u2019m very sorry Mrs. Smith, but Little Billy has tested positive for _.", "numAnswers": 1}, {"id": 1147, "expansion": "NSFH", "text": "Instead of beating them, Chris Brown now does _ to women.", "numAnswers": 1}, {"id": 1148, "expansion": "NSFH", "text": "Instead of cutting, trendy young emo girls now engage in _.", "numAnswers": 1}, {"id": 1149, "expansion": "NSFH", "text": "The definition of rock bottom is gambling away _.", "numAnswers": 1}, {"id": 1150, "expansion": "NSFH", "text": "The Mayan prophecies really heralded the coming of _ in 2012.", "numAnswers": 1}, {"id": 1151, "expansion": "NSFH", "text": "The next US election will be fought on the key issues of _ against _.", "numAnswers": 2}, {"id": 1152, "expansion": "NSFH", "text": "When I was 10 I wrote to Santa wishing for _.", "numAnswers": 1}, {"id": 1153, "expansion": "NSFH", "text": "Where or How I met my last signifigant other: _.", "numAnswers": 1}, {"id": 1154, "expansion": "NSFH", "text": "_, Never leave home without it.", "numAnswers": 1}, {"id": 1155, "expansion": "NSFH", "text": "_. This is my fetish.", "numAnswers": 1}, {"id": 1156, "expansion": "NSFH", "text": "David Icke's newest conspiracy theory states that _ caused _.", "numAnswers": 2}, {"id": 1157, "expansion": "NSFH", "text": "I did _ so you don't have to!", "numAnswers": 1}, {"id": 1158, "expansion": "NSFH", "text": "I need your clothes, your bike, and _.", "numAnswers": 1}, {"id": 1159, "expansion": "NSFH", "text": "In a new Cold War retro movie, the red menace tries to conquer the world through the cunning use of _.", "numAnswers": 1}, {"id": 1160, "expansion": "NSFH", "text": "In college, our lecturer made us write a report comparing _ to _.", "numAnswers": 2}, {"id": 1161, "expansion": "NSFH", "text": "In The Hangover part 3, those four guys have to deal with _, _, and _.", "numAnswers": 3}, {"id": 1162, "expansion": "NSFH", "text": "My zombie survival kit includes food, water, and _.", "numAnswers": 1}, {"id": 1163, "expansion": "NSFH", "text": "The way to a man's heart is through _.", "numAnswers": 1}, {"id": 1164, "expansion": "NSFH", "text": "What was the theme of my second wedding?", "numAnswers": 1}, {"id": 1165, "expansion": "NSFH", "text": "What's the newest Japanese craze to head West?", "numAnswers": 1}, {"id": 1166, "expansion": "NSFH", "text": "Everybody loves _.", "numAnswers": 1}, {"id": 1167, "expansion": "NSFH", "text": "I can only express myself through _.", "numAnswers": 1}, {"id": 1168, "expansion": "NSFH", "text": "My new porn DVD was completely ruined by the inclusion of _", "numAnswers": 1}, {"id": 1169, "expansion": "NSFH", "text": "My three wishes will be for _, _, and _.", "numAnswers": 3}, {"id": 1170, "expansion": "NSFH", "text": "The latest horrifying school shooting was inspired by _.", "numAnswers": 1}, {"id": 1171, "expansion": "NSFH", "text": "I got fired because of my not-so-secret obsession over _.", "numAnswers": 1}, {"id": 1172, "expansion": "NSFH", "text": "My new favourite sexual position is _", "numAnswers": 1}, {"id": 1248, "expansion": "CAHe3", "text": "A successful job interview begins with a firm handshake and ends with _.", "numAnswers": 1}, {"id": 1249, "expansion": "CAHe3", "text": "Lovin' you is easy 'cause you're _.", "numAnswers": 1}, {"id": 1250, "expansion": "CAHe3", "text": "My life is ruled by a vicious cycle of _ and _.", "numAnswers": 2}, {"id": 1251, "expansion": "CAHe3", "text": "The blind date was going horribly until we discovered our shared interest in _.", "numAnswers": 1}, {"id": 1252, "expansion": "CAHe3", "text": "_. Awesome in theory, kind of a mess in practice.", "numAnswers": 1}, {"id": 1253, "expansion": "CAHe3", "text": "I'm not like the rest of you. I'm too rich and busy for _.", "numAnswers": 1}, {"id": 1254, "expansion": "CAHe3", "text": "In the seventh circle of Hell, sinners must endure _ for all eternity.", "numAnswers": 1}, {"id": 1255, "expansion": "CAHe3", "text": "_: Hours of fun. Easy to use. Perfect for _!", "numAnswers": 2}, {"id": 1256, "expansion": "CAHe3", "text": "What left this stain on my couch?", "numAnswers": 1}, {"id": 1257, "expansion": "CAHe3", "text": "Call the law offices of Goldstein & Goldstein, because no one should have to tolerate _ in the workplace.", "numAnswers": 1}, {"id": 1258, "expansion": "CAHe3", "text": "When you get right down to it, _ is just _.", "numAnswers": 2}, {"id": 1259, "expansion": "CAHe3", "text": "Turns out that _-Man was neither the hero we needed nor wanted.", "numAnswers": 1}, {"id": 1260, "expansion": "CAHe3", "text": "As part of his daily regimen, Anderson Cooper sets aside 15 minutes for _.", "numAnswers": 1}, {"id": 1261, "expansion": "CAHe3", "text": "Money can't buy me love, but it can buy me _.", "numAnswers": 1}, {"id": 1262, "expansion": "CAHe3", "text": "With enough time and pressure, _ will turn into _.", "numAnswers": 2}, {"id": 1263, "expansion": "CAHe3", "text": "And what did you bring for show and tell?", "numAnswers": 1}, {"id": 1264, "expansion": "CAHe3", "text": "During high school, I never really fit in until I found _ club.", "numAnswers": 1}, {"id": 1265, "expansion": "CAHe3", "text": "Hey, baby, come back to my place and I'll show you _.", "numAnswers": 1}, {"id": 1266, "expansion": "CAHe3", "text": "After months of practice with _, I think I'm finally ready for _.", "numAnswers": 2}, {"id": 1267, "expansion": "CAHe3", "text": "To prepare for his upcoming role, Daniel Day-Lewis immersed himself in the world of _.", "numAnswers": 1}, {"id": 1268, "expansion": "CAHe3", "text": "Finally! A service that delivers _ right to your door.", "numAnswers": 1}, {"id": 1269, "expansion": "CAHe3", "text": "My gym teacher got fired for adding _ to the obstacle course.", "numAnswers": 1}, {"id": 1270, "expansion": "CAHe3", "text": "Having problems with _? Try _!", "numAnswers": 2}, {"id": 1271, "expansion": "CAHe3", "text": "As part of his contract, Prince won't perform without _ in his dressing room.", "numAnswers": 1}, {"id": 1272, "expansion": "CAHe3", "text": "Listen, son. If you want to get involved with _, I won't stop you. Just steer clear of _.", "numAnswers": 2}, {"id": 1301, "expansion": "Image1", "text": "I just met you and this is crazy, but here's _, so _ maybe", "numAnswers": 2}, {"id": 1302, "expansion": "Image1", "text": "It's only _ if you get caught!", "numAnswers": 1}, {"id": 1303, "expansion": "Image1", "text": "_: The Next Generation", "numAnswers": 1}, {"id": 1304, "expansion": "Image1", "text": "Terminator 4: _", "numAnswers": 1}, {"id": 1305, "expansion": "Image1", "text": "Disney presents _ on ice!", "numAnswers": 1}, {"id": 1306, "expansion": "Image1", "text": "_. The other white meat.", "numAnswers": 1}, {"id": 1307, "expansion": "Image1", "text": "A _ a day keeps the _ away.", "numAnswers": 2}, {"id": 1309, "expansion": "Image1", "text": "I'm sweating like a _ at a _.", "numAnswers": 2}, {"id": 1310, "expansion": "Image1", "text": "I love the smell of _ in the morning.", "numAnswers": 1}, {"id": 1311, "expansion": "Image1", "text": "You're not gonna believe this, but _.", "numAnswers": 1}, {"id": 1316, "expansion": "Image1", "text": "_. All the cool kids are doing it.", "numAnswers": 1}, {"id": 1318, "expansion": "Image1", "text": "So I was _ in my cubicle at work, and suddenly _!", "numAnswers": 2}, {"id": 1320, "expansion": "Image1", "text": "Baskin Robbins just added a 32nd flavor: _!", "numAnswers": 1}, {"id": 1321, "expansion": "Image1", "text": "I can drive and _ at the same time.", "numAnswers": 1}, {"id": 1322, "expansion": "Image1", "text": "_ ain't nothin' to fuck wit'!", "numAnswers": 1}, {"id": 1403, "expansion": "GOT", "text": "If Ned Stark had _, he never would have _.", "numAnswers": 2}, {"id": 1404, "expansion": "GOT", "text": "Brace yourselves, _ is coming.", "numAnswers": 1}, {"id": 1405, "expansion": "GOT", "text": "In exchange for his sister, Viserys was given _.", "numAnswers": 1}, {"id": 1406, "expansion": "GOT", "text": "Despite his best efforts, King Robert filled his reign with _.", "numAnswers": 1}, {"id": 1407, "expansion": "GOT", "text": "_ was proclaimed the true king of the Seven Kingdoms.", "numAnswers": 1}, {"id": 1408, "expansion": "GOT", "text": "In _, you win or you lose.", "numAnswers": 1}, {"id": 1409, "expansion": "GOT", "text": "Because of _, Danerys was called _ by everyone.", "numAnswers": 2}, {"id": 1410, "expansion": "GOT", "text": "I will take what is mine with _ and _.", "numAnswers": 2}, {"id": 1411, "expansion": "GOT", "text": "There is no word for _ in Dothraki.", "numAnswers": 1}, {"id": 1412, "expansion": "GOT", "text": "In the next Game of Thrones book, George R. R. Martin said _ will _.", "numAnswers": 2}, {"id": 1413, "expansion": "GOT", "text": "All hail _! King of _!", "numAnswers": 2}, {"id": 1414, "expansion": "GOT", "text": "A Lannister always pays _.", "numAnswers": 1}, {"id": 1415, "expansion": "GOT", "text": "First lesson, stick them with _.", "numAnswers": 1}, {"id": 1416, "expansion": "GOT", "text": "In the name of _, first of his _.", "numAnswers": 2}, {"id": 1417, "expansion": "GOT", "text": "The things I do for _.", "numAnswers": 1}, {"id": 1418, "expansion": "GOT", "text": "Hodor only ever says _.", "numAnswers": 1}, {"id": 1419, "expansion": "GOT", "text": "The next Game of Thrones book will be titled _ of _.", "numAnswers": 2}, {"id": 1420, "expansion": "GOT", "text": "A Dothraki wedding without _ is considered a dull affair.", "numAnswers": 1}, {"id": 1421, "expansion": "GOT", "text": "After I was caught _, I was forced to join the Night's Watch.", "numAnswers": 1}, {"id": 1422, "expansion": "GOT", "text": "A man without _ is a man without power.", "numAnswers": 1}, {"id": 1460, "expansion": "PAXP13", "text": "The most controversial game at PAX this year is an 8-bit indie platformer about _.", "numAnswers": 1}, {"id": 1461, "expansion": "PAXP13", "text": "What made Spock cry?", "numAnswers": 1}, {"id": 1462, "expansion": "PAXP13", "text": "_: Achievement unlocked.", "numAnswers": 1}, {"id": 1463, "expansion": "PAXP13", "text": "There was a riot at the Gearbox panel when they gave the attendees _.", "numAnswers": 1}, {"id": 1464, "expansion": "PAXP13", "text": "In the new DLC for Mass Effect, Shepard must save the galaxy from _.", "numAnswers": 1}, {"id": 1465, "expansion": "PAXP13", "text": "What's the latest bullshit that's troubling this quaint fantasy town?", "numAnswers": 1}, {"id": 1466, "expansion": "PAXP13", "text": "No Enforcer wants to manage the panel on _.", "numAnswers": 1}, {"id": 1491, "expansion": "PAXE13", "text": "I have an idea even better than Kickstarter, and it's called _starter", "numAnswers": 1}, {"id": 1492, "expansion": "PAXE13", "text": "You have been waylaid by _ and must defend yourself.", "numAnswers": 1}, {"id": 1493, "expansion": "PAXE13", "text": "In the final round of this year's Omegathon, Omeganauts must face off in a game of _.", "numAnswers": 1}, {"id": 1494, "expansion": "PAXE13", "text": "Action stations! Action stations! Set condition one throughout the fleet and brace for _!", "numAnswers": 1}, {"id": 1495, "expansion": "PAXE13", "text": "Press &darr;&darr;&larr;&rarr; to unleash _.", "numAnswers": 1}, {"id": 1496, "expansion": "PAXE13", "text": "I don't know exactly how I got the PAX plague, but I suspect it had something to do with _.", "numAnswers": 1}, {"id": 1776, "expansion": "HACK", "text": "/r/ _.", "numAnswers": 1}, {"id": 1777, "expansion": "HACK", "text": "The Ada Initiative is now attacking _.", "numAnswers": 1}, {"id": 1778, "expansion": "HACK", "text": "Not another _ in the hotel elevator!", "numAnswers": 1}, {"id": 1779, "expansion": "HACK", "text": "Closing Ceremonies drinking game: Every time _ is mentioned... DRINK!", "numAnswers": 1}, {"id": 1780, "expansion": "HACK", "text": "In a Congressional hearing, US CYBERCOM commander Gen. Alexander claimed the latest data breach was due to _.", "numAnswers": 1}, {"id": 1781, "expansion": "HACK", "text": "The Maker Faire was unexpectedly interrupted by _.", "numAnswers": 1}, {"id": 1782, "expansion": "HACK", "text": "Do you even _?", "numAnswers": 1}, {"id": 1783, "expansion": "HACK", "text": "Come to the dark side, we have _.", "numAnswers": 1}, {"id": 1784, "expansion": "HACK", "text": "Y U NO _!!!!!", "numAnswers": 1}, {"id": 1785, "expansion": "HACK", "text": "While alone in the server room I _.", "numAnswers": 1}, {"id": 1786, "expansion": "HACK", "text": "When I get drunk I am an expert on _", "numAnswers": 1}, {"id": 1787, "expansion": "HACK", "text": "Well, guess what? I\u2019ve got a fever, and the only prescription is more _.", "numAnswers": 1}, {"id": 1788, "expansion": "HACK", "text": "We should take _ and push it _.", "numAnswers": 2}, {"id": 1789, "expansion": "HACK", "text": "We decided to _ to raise money for the EFF.", "numAnswers": 1}, {"id": 1790, "expansion": "HACK", "text": "TSA wouldn't allow me through because of my _.", "numAnswers": 1}, {"id": 1791, "expansion": "HACK", "text": "Tonight's Final Hacker Jeopardy category will be _!", "numAnswers": 1}, {"id": 1792, "expansion": "HACK", "text": "Today's PaulDotCom podcast featured _.", "numAnswers": 1}, {"id": 1793, "expansion": "HACK", "text": "These are not the _ you are looking for.", "numAnswers": 1}, {"id": 1794, "expansion": "HACK", "text": "The snozberries taste like _.", "numAnswers": 1}, {"id": 1795, "expansion": "HACK", "text": "The only winning move is to _.", "numAnswers": 1}, {"id": 1796, "expansion": "HACK", "text": "The next cyber war will feature _.", "numAnswers": 1}, {"id": 1797, "expansion": "HACK", "text": "The best part of Alexis Park was all the _.", "numAnswers": 1}, {"id": 1798, "expansion": "HACK", "text": "So long and thanks for all the _.", "numAnswers": 1}, {"id": 1799, "expansion": "HACK", "text": "Security through obscurity is better than _.", "numAnswers": 1}, {"id": 1800, "expansion": "HACK", "text": "Rule 34 _.", "numAnswers": 1}, {"id": 1801, "expansion": "HACK", "text": "Rock, Paper, Scissors, Lizard, _.", "numAnswers": 1}, {"id": 1802, "expansion": "HACK", "text": "Our most powerful weapon for the Zombie Apocalypse will be _.", "numAnswers": 1}, {"id": 1803, "expansion": "HACK", "text": "Only half of programming is coding. The other 90% is _.", "numAnswers": 1}, {"id": 1804, "expansion": "HACK", "text": "One does not simply _.", "numAnswers": 1}, {"id": 1805, "expansion": "HACK", "text": "On the Internet, no one can tell you're _.", "numAnswers": 1}, {"id": 1806, "expansion": "HACK", "text": "Occupy _.", "numAnswers": 1}, {"id": 1807, "expansion": "HACK", "text": "Next year's scavenger hunt is rumored to include finding a _ with a _.", "numAnswers": 2}, {"id": 1808, "expansion": "HACK", "text": "Next time we meet we should _.", "numAnswers": 1}, {"id": 1809, "expansion": "HACK", "text": "My extremely large _ is what makes me better than you.", "numAnswers": 1}, {"id": 1810, "expansion": "HACK", "text": "My _ brings all the _ to the yard.", "numAnswers": 2}, {"id": 1811, "expansion": "HACK", "text": "Most hackers smell like _.", "numAnswers": 1}, {"id": 1812, "expansion": "HACK", "text": "Las Vegas is best known for _.", "numAnswers": 1}, {"id": 1813, "expansion": "HACK", "text": "Keep calm and _.", "numAnswers": 1}, {"id": 1814, "expansion": "HACK", "text": "It's dangerous to go alone. Take _.", "numAnswers": 1}, {"id": 1815, "expansion": "HACK", "text": "It smells like _ in this room.", "numAnswers": 1}, {"id": 1816, "expansion": "HACK", "text": "In a shocking move Archive.org decided to NOT back up _.", "numAnswers": 1}, {"id": 1817, "expansion": "HACK", "text": "I'mma let you finish but _ is the best _ of all time.", "numAnswers": 2}, {"id": 1818, "expansion": "HACK", "text": "I'm fucking tired of hearing about _.", "numAnswers": 1}, {"id": 1819, "expansion": "HACK", "text": "I would be doing more with my life, except for this _ in the way.", "numAnswers": 1}, {"id": 1820, "expansion": "HACK", "text": "I work 80 hours a week and still can't afford a _.", "numAnswers": 1}, {"id": 1821, "expansion": "HACK", "text": "I used to be a hacker like you, until I took a(n) _ to the knee.", "numAnswers": 1}, {"id": 1822, "expansion": "HACK", "text": "I use _ to secure all of my personal data.", "numAnswers": 1}, {"id": 1823, "expansion": "HACK", "text": "I spotted the fed and all I got was _.", "numAnswers": 1}, {"id": 1824, "expansion": "HACK", "text": "I look like a geeky hacker, but I don't know anything about _.", "numAnswers": 1}, {"id": 1825, "expansion": "HACK", "text": "I have the biggest _, ever!", "numAnswers": 1}, {"id": 1826, "expansion": "HACK", "text": "I find your lack of _ disturbing.", "numAnswers": 1}, {"id": 1827, "expansion": "HACK", "text": "I can't believe they rejected my talk on _.", "numAnswers": 1}, {"id": 1828, "expansion": "HACK", "text": "I can haz _.", "numAnswers": 1}, {"id": 1829, "expansion": "HACK", "text": "HOLY _ BATMAN!!", "numAnswers": 1}, {"id": 1830, "expansion": "HACK", "text": "High Tech start-up company combines _ with _.", "numAnswers": 2}, {"id": 1831, "expansion": "HACK", "text": "Go home _, you're drunk.", "numAnswers": 1}, {"id": 1832, "expansion": "HACK", "text": "Go Go Gadget _!", "numAnswers": 1}, {"id": 1833, "expansion": "HACK", "text": "Drink all the _. Hack all the _.", "numAnswers": 2}, {"id": 1834, "expansion": "HACK", "text": "Def Con Kids will now focus on teaching young hackers _.", "numAnswers": 1}, {"id": 1835, "expansion": "HACK", "text": "Confession Bear Says: _", "numAnswers": 1}, {"id": 1836, "expansion": "HACK", "text": "But does _ run NetBSD?", "numAnswers": 1}, {"id": 1837, "expansion": "HACK", "text": "Am I the only one around here who _.", "numAnswers": 1}, {"id": 1838, "expansion": "HACK", "text": "All I did was _ but someone gave me a red card.", "numAnswers": 1}, {"id": 1839, "expansion": "HACK", "text": "35% of all hackers have to deal with _.", "numAnswers": 1}, {"id": 1840, "expansion": "HACK", "text": "_. There's an app for that.", "numAnswers": 1}, {"id": 1841, "expansion": "HACK", "text": "_. This is why I can't have nice things!", "numAnswers": 1}, {"id": 1842, "expansion": "HACK", "text": "_: You keep using that term. I do not think it means what you think it means.", "numAnswers": 1}, {"id": 1843, "expansion": "HACK", "text": "_ is now outsourced to call centers in India.", "numAnswers": 1}, {"id": 1844, "expansion": "HACK", "text": "_ shot first.", "numAnswers": 1}, {"id": 1845, "expansion": "HACK", "text": "_ Killed the barrel roll", "numAnswers": 1}, {"id": 1846, "expansion": "HACK", "text": "_ A'int Nobody Got Time For Dat!!", "numAnswers": 1}, {"id": 1847, "expansion": "HACK", "text": "_ Put a bird on it!", "numAnswers": 1}, {"id": 1848, "expansion": "HACK", "text": "_ makes me puke rainbows.", "numAnswers": 1}, {"id": 1849, "expansion": "HACK", "text": "_ is also monitored by Prism.", "numAnswers": 1}, {"id": 1850, "expansion": "HACK", "text": "_ is what keeps us together.", "numAnswers": 1}, {"id": 1851, "expansion": "HACK", "text": "_ is a better replacement for crypto.", "numAnswers": 1}, {"id": 1852, "expansion": "HACK", "text": "_ riding a Segway", "numAnswers": 1}, {"id": 1853, "expansion": "HACK", "text": "One day, over my fireplace, I'm going to have a massive painting of _. You know, to remind me where I came from.", "numAnswers": 1}, {"id": 1924, "expansion": "CAHe4", "text": "2 AM in the city that never sleeps. The door swings open and she walks in, legs up to here. Something in her eyes tells me she's looking for _.", "numAnswers": 1}, {"id": 1925, "expansion": "CAHe4", "text": "Adventure. Romance. _. From Paramount Pictures, \"_.\"", "numAnswers": 2}, {"id": 1926, "expansion": "CAHe4", "text": "Alright, bros. Our frat house is condemned, and all the hot slampieces are over at Gamma Phi. The time has come to commence Operation _.", "numAnswers": 1}, {"id": 1927, "expansion": "CAHe4", "text": "As king, how will I keep the peasants in line?", "numAnswers": 1}, {"id": 1928, "expansion": "CAHe4", "text": "Dear Leader Kim Jong-un, our village praises your infinite wisdom with a humble offering of _.", "numAnswers": 1}, {"id": 1929, "expansion": "CAHe4", "text": "Do not fuck with me! I am literally _ right now.", "numAnswers": 1}, {"id": 1930, "expansion": "CAHe4", "text": "Every step towards _ gets me a little bit closer to _.", "numAnswers": 2}, {"id": 1931, "expansion": "CAHe4", "text": "Forget everything you know about _, because now we've supercharged it with _!", "numAnswers": 2}, {"id": 1932, "expansion": "CAHe4", "text": "Honey, I have a new role-play I want to try tonight! You can be _, and I'll be _.", "numAnswers": 2}, {"id": 1933, "expansion": "CAHe4", "text": "How am I compensating for my tiny penis?", "numAnswers": 1}, {"id": 1934, "expansion": "CAHe4", "text": "I am become _, destroyer of _!", "numAnswers": 2}, {"id": 1935, "expansion": "CAHe4", "text": "I'm pretty sure I'm high right now, because I'm absolutely mesmerized by _.", "numAnswers": 1}, {"id": 1936, "expansion": "CAHe4", "text": "I'm sorry sir, but we don't allow _ at the country club.", "numAnswers": 1}, {"id": 1937, "expansion": "CAHe4", "text": "If you can't handle _, you'd better stay away from _.", "numAnswers": 2}, {"id": 1938, "expansion": "CAHe4", "text": "In return for my soul, the Devil promised me _ but all I got was _.", "numAnswers": 2}, {"id": 1939, "expansion": "CAHe4", "text": "In the beginning there was _. And the Lord said, \"Let there be _.\"", "numAnswers": 2}, {"id": 1940, "expansion": "CAHe4", "text": "It lurks in the night. It hungers for flesh. This summer, no one is safe from _.", "numAnswers": 1}, {"id": 1941, "expansion": "CAHe4", "text": "Man, this is bullshit. Fuck _.", "numAnswers": 1}, {"id": 1942, "expansion": "CAHe4", "text": "Oprah's book of the month is \"_ For _: A Story of Hope\"", "numAnswers": 2}, {"id": 1943, "expansion": "CAHe4", "text": "She's up all night for good fun. I'm up all night for _.", "numAnswers": 1}, {"id": 1944, "expansion": "CAHe4", "text": "The Japanese have developed a smaller, more efficient version of _.", "numAnswers": 1}, {"id": 1945, "expansion": "CAHe4", "text": "This is the prime of my life. I'm young, hot, and full of _.", "numAnswers": 1}, {"id": 1946, "expansion": "CAHe4", "text": "This year's hottest album is \"_\" by _.", "numAnswers": 2}, {"id": 1947, "expansion": "CAHe4", "text": "We never did find _, but along the way we sure learned a lot about _.", "numAnswers": 2}, {"id": 1948, "expansion": "CAHe4", "text": "Wes Anderson's new film tells the story of a precocious child coming to terms with _.", "numAnswers": 1}, {"id": 1949, "expansion": "CAHe4", "text": "What's fun until it gets weird?", "numAnswers": 1}, {"id": 1950, "expansion": "CAHe4", "text": "You've seen the bearded lady! You've seen the ring of fire! Now, ladies and gentlemen, feast your eyes upon _!", "numAnswers": 1}, {"id": 1951, "expansion": "CAHe4", "text": "_ may pass, but _ will last forever.", "numAnswers": 2}, {"id": 1952, "expansion": "CAHe4", "text": "_ will never be the same after _.", "numAnswers": 2}, {"id": 1953, "expansion": "CAHe4", "text": "You guys, I saw this crazy movie last night. It opens on _, and then there's some stuff about _, and then it ends with _.", "numAnswers": 3}, {"id": 2047, "expansion": "Gallifrey", "text": "They found some more lost episodes! They were found in _.", "numAnswers": 1}, {"id": 2048, "expansion": "Gallifrey", "text": "The Doctor did it! He saved the world again! This time using a _.", "numAnswers": 1}, {"id": 2049, "expansion": "Gallifrey", "text": "_ was sent to save _.", "numAnswers": 2}, {"id": 2050, "expansion": "Gallifrey", "text": "I'd give up _ to travel with The Doctor.", "numAnswers": 1}, {"id": 2051, "expansion": "Gallifrey", "text": "The next Doctor Who spin-off is going to be called _.", "numAnswers": 1}, {"id": 2052, "expansion": "Gallifrey", "text": "Who should be the 13th doctor?", "numAnswers": 1}, {"id": 2053, "expansion": "Gallifrey", "text": "The Chameleon circuit is working again... somewhat. Instead of a phone booth, the TARDIS is now a _.", "numAnswers": 1}, {"id": 2054, "expansion": "Gallifrey", "text": "Originally, the 50th anniversary special was going to have _ appear, but the BBC decided against it in the end.", "numAnswers": 1}, {"id": 2055, "expansion": "Gallifrey", "text": "After we watch an episode, I've got some _-flavored Jelly Babies to hand out.", "numAnswers": 1}, {"id": 2056, "expansion": "Gallifrey", "text": "Wibbly-wobbly timey-wimey _.", "numAnswers": 1}, {"id": 2057, "expansion": "Gallifrey", "text": "What's going to be The Doctor's new catch phrase.", "numAnswers": 1}, {"id": 2058, "expansion": "Gallifrey", "text": "Bowties are _.", "numAnswers": 1}, {"id": 2059, "expansion": "Gallifrey", "text": "The voice chip of one of the Cybermen has malfunctioned. Instead of saying \"DELETE!\", it now says \"_\".", "numAnswers": 1}, {"id": 2060, "expansion": "Gallifrey", "text": "Old and busted: \"EXTERMINATE!\" New hotness: _.", "numAnswers": 1}, {"id": 2061, "expansion": "Gallifrey", "text": "There's a new dance on Gallifrey, it's called the _.", "numAnswers": 1}, {"id": 2062, "expansion": "Gallifrey", "text": "They announced a LEGO Doctor Who game! Rumor has it that _ is an unlockable character.", "numAnswers": 1}, {"id": 2063, "expansion": "Gallifrey", "text": "FUN FACT: The Daleks were originally shaped to look like _.", "numAnswers": 1}, {"id": 2064, "expansion": "Gallifrey", "text": "At this new Doctor Who themed restaurant, you can get a free _ if you can eat a plate of bangers and mash in under 3 minutes.", "numAnswers": 1}, {"id": 2065, "expansion": "Gallifrey", "text": "According to the Daleks, _ is better at _.", "numAnswers": 2}, {"id": 2066, "expansion": "Gallifrey", "text": "Who is going to be The Doctor's next companion?", "numAnswers": 1}, {"id": 2067, "expansion": "Gallifrey", "text": "I think the BBC is losing it. They just released a Doctor Who-themed _.", "numAnswers": 1}, {"id": 2068, "expansion": "Gallifrey", "text": "It's a little-known fact that if you send a _ to the BBC, they will send you a picture of The Doctor.", "numAnswers": 1}, {"id": 2069, "expansion": "Gallifrey", "text": "I was okay with all the BAD WOLF graffiti, until someone wrote it on _.", "numAnswers": 1}, {"id": 2070, "expansion": "Gallifrey", "text": "Jack Harkness, I can't leave you alone for a minute! I turn around and you're trying to seduce _.", "numAnswers": 1}, {"id": 2071, "expansion": "Gallifrey", "text": "In all of time and space, you decide that _ is a good choice?!", "numAnswers": 1}, {"id": 2072, "expansion": "Gallifrey", "text": "Adipose were thought to be made of fat, but are really made of _.", "numAnswers": 1}, {"id": 2073, "expansion": "Gallifrey", "text": "I hear the next thing that will cause The Doctor to regenerate is _.", "numAnswers": 1}, {"id": 2445, "expansion": "Alternia", "text": "_ makes the Homestuck fandom uncomfortable.", "numAnswers": 1}, {"id": 2446, "expansion": "Alternia", "text": "_ stays awake at night, crying over _.", "numAnswers": 2}, {"id": 2447, "expansion": "Alternia", "text": "_ totally makes me question my sexuality.", "numAnswers": 1}, {"id": 2448, "expansion": "Alternia", "text": "_. On the roof. Now.", "numAnswers": 1}, {"id": 2449, "expansion": "Alternia", "text": "_. It keeps happening!", "numAnswers": 1}, {"id": 2450, "expansion": "Alternia", "text": "\"Sacred leggings\" was a mistranslation. The Sufferer actually died in Sacred _.", "numAnswers": 1}, {"id": 2451, "expansion": "Alternia", "text": "After throwing _ at Karkat\u2019s head, Dave made the intriguing discover that troll horns are very sensitive.", "numAnswers": 1}, {"id": 2452, "expansion": "Alternia", "text": "AG: Who needs luck when you have _?", "numAnswers": 1}, {"id": 2453, "expansion": "Alternia", "text": "All _. All of it!", "numAnswers": 1}, {"id": 2454, "expansion": "Alternia", "text": "Alternia\u2019s political system was based upon _.", "numAnswers": 1}, {"id": 2455, "expansion": "Alternia", "text": "Believe it or not, Kankri\u2019s biggest trigger is _.", "numAnswers": 1}, {"id": 2456, "expansion": "Alternia", "text": "Calliborn wants you to draw pornography of _.", "numAnswers": 1}, {"id": 2457, "expansion": "Alternia", "text": "Dave Strider likes _, but only ironically.", "numAnswers": 1}, {"id": 2458, "expansion": "Alternia", "text": "Equius beats up Eridan for _.", "numAnswers": 1}, {"id": 2459, "expansion": "Alternia", "text": "Everybody out of the god damn way. You\u2019ve got a heart full of _, a soul full of _, and a body full of _. (Draw two, play three)", "numAnswers": 3}, {"id": 2460, "expansion": "Alternia", "text": "Feferi secretly hates _.", "numAnswers": 1}, {"id": 2461, "expansion": "Alternia", "text": "For Betty Crocker\u2019s latest ad campaign/brainwashing scheme, she is using _ as inspiration.", "numAnswers": 1}, {"id": 2462, "expansion": "Alternia", "text": "For his birthday, Dave gave John _.", "numAnswers": 1}, {"id": 2463, "expansion": "Alternia", "text": "Fuckin\u2019 _. How do they work?", "numAnswers": 1}, {"id": 2464, "expansion": "Alternia", "text": "Gamzee not only likes using his clubs for juggling and strifing, he also uses them for_.", "numAnswers": 1}, {"id": 2465, "expansion": "Alternia", "text": "Getting a friend to read Homestuck is like _.", "numAnswers": 1}, {"id": 2466, "expansion": "Alternia", "text": "How do I live without _?", "numAnswers": 1}, {"id": 2467, "expansion": "Alternia", "text": "Hussie died on his quest bed and rose as the fully realized _ of _.", "numAnswers": 2}, {"id": 2468, "expansion": "Alternia", "text": "Hussie unintentionally revealed that Homestuck will end with _ and _ consummating their relationship at last.", "numAnswers": 2}, {"id": 2469, "expansion": "Alternia", "text": "I am _. It\u2019s me.", "numAnswers": 1}, {"id": 2470, "expansion": "Alternia", "text": "I finally became Tumblr famous when I released a gifset of _.", "numAnswers": 1}, {"id": 2471, "expansion": "Alternia", "text": "I just found _ in my closet it is like fucking christmas up in here.", "numAnswers": 1}, {"id": 2472, "expansion": "Alternia", "text": "I warned you about _, bro! I told you, dog!", "numAnswers": 1}, {"id": 2473, "expansion": "Alternia", "text": "In the final battle, John distracts Lord English by showing him _.", "numAnswers": 1}, {"id": 2474, "expansion": "Alternia", "text": "It\u2019s hard, being _. It\u2019s hard and no one understands.", "numAnswers": 1}, {"id": 2475, "expansion": "Alternia", "text": "John is a good boy. And he loves _.", "numAnswers": 1}, {"id": 2476, "expansion": "Alternia", "text": "John may not be a homosexual, but he has a serious thing for _.", "numAnswers": 1}, {"id": 2477, "expansion": "Alternia", "text": "Kanaya reached into her dead lusus\u2019s stomach and retrieved _.", "numAnswers": 1}, {"id": 2478, "expansion": "Alternia", "text": "Kanaya tells Karkat about _ to cheer him up.", "numAnswers": 1}, {"id": 2479, "expansion": "Alternia", "text": "Karkat gave our universe _.", "numAnswers": 1}, {"id": 2480, "expansion": "Alternia", "text": "Latula and Porrin have decided to teach Kankri about the wonders of _.", "numAnswers": 1}, {"id": 2481, "expansion": "Alternia", "text": "Little did they know, the key to defeating Lord English was actually _.", "numAnswers": 1}, {"id": 2482, "expansion": "Alternia", "text": "Little known fact: Kurloz\u2019s stitching is actually made out of _.", "numAnswers": 1}, {"id": 2483, "expansion": "Alternia", "text": "Nanna baked a cake for John to commemorate _.", "numAnswers": 1}, {"id": 2484, "expansion": "Alternia", "text": "Nepeta only likes Karkat for his _.", "numAnswers": 1}, {"id": 2485, "expansion": "Alternia", "text": "Nepeta\u2019s secret OTP is _ with _.", "numAnswers": 2}, {"id": 2486, "expansion": "Alternia", "text": "Nobody was surprised to find _ under Jade\u2019s skirt. The surprise was she used it for/on _.", "numAnswers": 2}, {"id": 2487, "expansion": "Alternia", "text": "Porrim made Kankri a sweater to cover his _.", "numAnswers": 1}, {"id": 2488, "expansion": "Alternia", "text": "Problem Sleuth had a hard time investigating _.", "numAnswers": 1}, {"id": 2489, "expansion": "Alternia", "text": "Rose was rather disgusted when she started reading about _.", "numAnswers": 1}, {"id": 2490, "expansion": "Alternia", "text": "Terezi can top anyone except _.", "numAnswers": 1}, {"id": 2491, "expansion": "Alternia", "text": "The hole in Kanaya\u2019s stomach is so large, she can fit _ in it.", "numAnswers": 1}, {"id": 2492, "expansion": "Alternia", "text": "The next thing Hussie will turn into a sex joke will be _.", "numAnswers": 1}, {"id": 2493, "expansion": "Alternia", "text": "The only way to beat Vriska in an eating contest is to put _ on the table.", "numAnswers": 1}, {"id": 2494, "expansion": "Alternia", "text": "The real reason Terezi stabbed Vriska was to punish her for _.", "numAnswers": 1}, {"id": 2495, "expansion": "Alternia", "text": "The secret way to achieve God Tier is to die on top of _.", "numAnswers": 1}, {"id": 2496, "expansion": "Alternia", "text": "The thing that made Kankri break his vow of celibacy was _.", "numAnswers": 1}, {"id": 2497, "expansion": "Alternia", "text": "Turns out, pre-entry prototyping with _ was not the best idea.", "numAnswers": 1}, {"id": 2498, "expansion": "Alternia", "text": "Vriska killed Spidermom with _.", "numAnswers": 1}, {"id": 2499, "expansion": "Alternia", "text": "Vriska roleplays _ with Terezi as _.", "numAnswers": 2}, {"id": 2500, "expansion": "Alternia", "text": "Vriska\u2019s greatest regret is _.", "numAnswers": 1}, {"id": 2501, "expansion": "Alternia", "text": "Wear  _. Be _.", "numAnswers": 2}, {"id": 2502, "expansion": "Alternia", "text": "What did Jake get Dirk for his birthday?", "numAnswers": 1}, {"id": 2503, "expansion": "Alternia", "text": "What is the worst thing that Terezi ever licked?", "numAnswers": 1}, {"id": 2504, "expansion": "Alternia", "text": "What is your OT3? (Draw 2, play 3.)", "numAnswers": 1}, {"id": 2505, "expansion": "Alternia", "text": "What makes your kokoro go \"doki doki\"?", "numAnswers": 1}, {"id": 2506, "expansion": "Alternia", "text": "What's in the box, Jack?", "numAnswers": 1}, {"id": 2507, "expansion": "Alternia", "text": "When a bucket is unavailable, trolls with use _.", "numAnswers": 1}, {"id": 2508, "expansion": "Alternia", "text": "When Dave received _ from his Bro for his 9th birthday, be felt a little warm inside.", "numAnswers": 1}, {"id": 2509, "expansion": "Alternia", "text": "Whenever I see _ on MSPARP, I disconnect immediately.", "numAnswers": 1}, {"id": 2510, "expansion": "Alternia", "text": "where doing it man. where MAKING _ HAPEN!", "numAnswers": 1}, {"id": 2511, "expansion": "Alternia", "text": "Your name is JOHN EGBERT and boy do you love _!", "numAnswers": 1}, {"id": 2514, "expansion": "Ladies Against Humanity", "text": "Hey, Susie. I know your job is _ but can you just grab me _? Thanks.", "numAnswers": 2}, {"id": 2527, "expansion": "Ladies Against Humanity", "text": "This month in Cosmo: how to give your man _ at the expense of _.", "numAnswers": 2}, {"id": 2543, "expansion": "Ladies Against Humanity", "text": "Are you there, God? It's me, _", "numAnswers": 1}, {"id": 2545, "expansion": "Ladies Against Humanity", "text": "50 Shades of _.", "numAnswers": 1}, {"id": 2548, "expansion": "Ladies Against Humanity", "text": "It's not length, it's _.", "numAnswers": 1}, {"id": 2552, "expansion": "Ladies Against Humanity", "text": "Whatever, Peeta. You'll never understand my struggle with _.", "numAnswers": 1}, {"id": 2556, "expansion": "Ladies Against Humanity", "text": "Men are from _, women are from _.", "numAnswers": 2}, {"id": 2559, "expansion": "Ladies Against Humanity", "text": "Why does the Komen Foundation hate Planned Parenthood?", "numAnswers": 1}, {"id": 2562, "expansion": "Ladies Against Humanity", "text": "Math is hard. Let's go _!", "numAnswers": 1}, {"id": 2564, "expansion": "Ladies Against Humanity", "text": "The latest proposal in the Texas legislature is to take away _ from women.", "numAnswers": 1}, {"id": 2567, "expansion": "Ladies Against Humanity", "text": "If you don't mind my asking, how *do* lesbians have sex?", "numAnswers": 1}, {"id": 2571, "expansion": "Ladies Against Humanity", "text": "In her next romcom, Katherine Heigl plays a woman who falls in love with her boss's _.", "numAnswers": 1}, {"id": 2575, "expansion": "Ladies Against Humanity", "text": "The Pantone color of the year is inspired by _.", "numAnswers": 1}, {"id": 2577, "expansion": "Ladies Against Humanity", "text": "What is Olivia Pope's secret to removing red wine stains from white clothes?", "numAnswers": 1}, {"id": 2581, "expansion": "Ladies Against Humanity", "text": "Why exactly was Alanis so mad at Uncle Joey?", "numAnswers": 1}, {"id": 2584, "expansion": "Ladies Against Humanity", "text": "Why do men on the Internet send me pictures of _?", "numAnswers": 1}, {"id": 2589, "expansion": "Ladies Against Humanity", "text": "What's my weapon of choice in the \"War on Women\"?", "numAnswers": 1}, {"id": 2596, "expansion": "Ladies Against Humanity", "text": "What's Seth MacFarlane's problem?", "numAnswers": 1}, {"id": 2603, "expansion": "Ladies Against Humanity", "text": "I couldn't help but wonder: was it Mr. Big, or was it _?", "numAnswers": 1}, {"id": 2606, "expansion": "Ladies Against Humanity", "text": "What fell into my bra?", "numAnswers": 1}, {"id": 2610, "expansion": "Ladies Against Humanity", "text": "What's my preferred method of contraception?", "numAnswers": 1}, {"id": 2613, "expansion": "Ladies Against Humanity", "text": "Sofia Coppola's new film focuses on a wealthy young white woman feeling alienated by _.", "numAnswers": 1}, {"id": 2616, "expansion": "Ladies Against Humanity", "text": "_: the Tori Amos song that changed my life", "numAnswers": 1}, {"id": 2618, "expansion": "Ladies Against Humanity", "text": "Something old, something new, something borrowed, and _.", "numAnswers": 1}, {"id": 2622, "expansion": "Ladies Against Humanity", "text": "Why can't we have nice things?", "numAnswers": 1}, {"id": 2626, "expansion": "Canadian Conversion Kit", "text": "In an attempt to reach a wider audience, the Royal Ontario Museum has opened an interactive exhibit on _.", "numAnswers": 1}, {"id": 2627, "expansion": "Canadian Conversion Kit", "text": "What's the Canadian government using to inspire rural students to suceed?", "numAnswers": 1}, {"id": 2628, "expansion": "Canadian Conversion Kit", "text": "in the next Bob and Doug McKenzie adventure, they have to find _ to uncover a sinister plot involving _ and _.", "numAnswers": 3}, {"id": 2629, "expansion": "Canadian Conversion Kit", "text": "Air canada guidelines now prohibit _ on airplanes.", "numAnswers": 1}, {"id": 2630, "expansion": "Canadian Conversion Kit", "text": "CTV presents _, the store of _.", "numAnswers": 2}, {"id": 2631, "expansion": "Canadian Conversion Kit", "text": "In Vancouver it is now legal to _.", "numAnswers": 1}, {"id": 2632, "expansion": "Canadian Conversion Kit", "text": "O Canada, we stand on guard for _.", "numAnswers": 1}, {"id": 2633, "expansion": "Canadian Conversion Kit", "text": "If _ came in two-fours, Canada would be more _.", "numAnswers": 2}, {"id": 2634, "expansion": "Canadian Conversion Kit", "text": "After unifying the GST and PST, the Government can now afford to provide _ for _.", "numAnswers": 2}, {"id": 2662, "expansion": "Nobilis Reed", "text": "When Verity snuck out for her nightly exhibitionistic jaunt, she didn't expect to come face to face with _.", "numAnswers": 1}, {"id": 2663, "expansion": "Nobilis Reed", "text": "Programmable clothes that can turn into any imaginable garment are great, but didn't the designers consider _?", "numAnswers": 1}, {"id": 2664, "expansion": "Nobilis Reed", "text": "Procurator Marcus Amandus set out to explore Lake Ontarius and discovered _.", "numAnswers": 1}, {"id": 2665, "expansion": "Nobilis Reed", "text": "You can satiate any sexual proclivity in Metamor City, if you look hard enough. Even _.", "numAnswers": 1}, {"id": 2666, "expansion": "Nobilis Reed", "text": "The new performers in the Artbodies strip club have raised a few eyebrows. Who'd have thought to combine _ with _?", "numAnswers": 2}, {"id": 2667, "expansion": "Nobilis Reed", "text": "In the next episode of Monster Whisperer, Dale Clearwater helps a _ whose tentacle monster is plagued with _.", "numAnswers": 2}, {"id": 2668, "expansion": "Nobilis Reed", "text": "The title of the new erotica anthology this month is: 'Like _.'", "numAnswers": 1}, {"id": 2669, "expansion": "Nobilis Reed", "text": "Because of the 'accident' yesterday, the Scout Academy now forbids cadets from having any contact whatsoever with _.", "numAnswers": 1}, {"id": 2670, "expansion": "Nobilis Reed", "text": "When confronted by an excited tentacle monster, it's best to just relax and think of _.", "numAnswers": 1}, {"id": 2671, "expansion": "Nobilis Reed", "text": "A Man, A Woman, and a _.", "numAnswers": 1}, {"id": 2717, "expansion": "christmas2013", "text": "But wait, there's more! If you order _ in the next 15 minutes, we'll throw in _ absolutely free!", "numAnswers": 2}, {"id": 2718, "expansion": "christmas2013", "text": "Because they are forbidden from masturbating, Mormons channel their repressed sexual energy into _.", "numAnswers": 1}, {"id": 2719, "expansion": "christmas2013", "text": "Blessed are you, Lord our God, creator of the universe, who has granted us _.", "numAnswers": 1}, {"id": 2720, "expansion": "christmas2013", "text": "I really hope my grandma doesn't ask me to explain _ again.", "numAnswers": 1}, {"id": 2721, "expansion": "christmas2013", "text": "What's the one thing that makes an elf instantly ejaculate?", "numAnswers": 1}, {"id": 2722, "expansion": "christmas2013", "text": "Here's what you can expect for the new year. Out:_. In: _.", "numAnswers": 2}, {"id": 2723, "expansion": "christmas2013", "text": "Revealed: Why He Really Resigned! Pope Benedict's Secret Struggle with _!", "numAnswers": 1}, {"id": 2724, "expansion": "christmas2013", "text": "Kids these days with their iPods and their Internet. In my day, all we needed to pass the time was _.", "numAnswers": 1}, {"id": 2725, "expansion": "christmas2013", "text": "GREETINGS HUMANS I AM _ BOT EXECUTING PROGRAM", "numAnswers": 1}, {"id": 2749, "expansion": "90s", "text": "Siskel and Ebert have panned _ as \u201dpoorly conceived\u201d and \u201dsloppily executed.\u201d", "numAnswers": 1}, {"id": 2750, "expansion": "90s", "text": "Up next on Nickelodeon: \u201dClarissa Explains _.\u201d", "numAnswers": 1}, {"id": 2751, "expansion": "90s", "text": "I'm a bitch, I'm a lover, I'm a child, I'm _.", "numAnswers": 1}, {"id": 2752, "expansion": "90s", "text": "How did Stella get her groove back?", "numAnswers": 1}, {"id": 2753, "expansion": "90s", "text": "Believe it or not, Jim Carrey can do a dead-on impression of _.", "numAnswers": 1}, {"id": 2754, "expansion": "90s", "text": "It's Morphin' Time! Mastodon! Pterodactyl! Triceratops! Sabertooth Tiger! _!", "numAnswers": 1}, {"id": 2755, "expansion": "90s", "text": "Tonight on SNICK: \u201dAre You Afraid of _?\u201d", "numAnswers": 1}, {"id": 2830, "expansion": "CAHe5", "text": "And today's soup is Cream of _.", "numAnswers": 1}, {"id": 2831, "expansion": "CAHe5", "text": "Now in bookstores: \u201dThe Audacity of _,\u201d by Barack Obama.", "numAnswers": 1}, {"id": 2832, "expansion": "CAHe5", "text": "WHOOO! God damn I love _!", "numAnswers": 1}, {"id": 2833, "expansion": "CAHe5", "text": "Do you lack energy? Does it sometimes feel like the whole world is _? Zoloft.&reg;", "numAnswers": 1}, {"id": 2834, "expansion": "CAHe5", "text": "Hi, this is Jim from accounting. We noticed a $1,200 charge labeled \u201d_.\u201d Can you explain?", "numAnswers": 1}, {"id": 2835, "expansion": "CAHe5", "text": "Well if _ is good enough for _, it's good enough for me.", "numAnswers": 2}, {"id": 2836, "expansion": "CAHe5", "text": "Yo' mama so fat she _!", "numAnswers": 1}, {"id": 2837, "expansion": "CAHe5", "text": "What killed my boner?", "numAnswers": 1}, {"id": 2838, "expansion": "CAHe5", "text": "Don't forget! Beginning this week, Casual Friday will officially become \u201d_ Friday.\u201d", "numAnswers": 1}, {"id": 2839, "expansion": "CAHe5", "text": "In his farewell address, George Washington famously warned Americans about the dangers of _.", "numAnswers": 1}, {"id": 2840, "expansion": "CAHe5", "text": "Having the worst day EVER. #_", "numAnswers": 1}, {"id": 2841, "expansion": "CAHe5", "text": "Get ready for the movie of the summer! One cop plays by the book. The other's only interested in one thing: _.", "numAnswers": 1}, {"id": 2842, "expansion": "CAHe5", "text": "What's making things awkward in the sauna?", "numAnswers": 1}, {"id": 2843, "expansion": "CAHe5", "text": "Life's pretty tough in the fast lane. That's why I never leave the house without _.", "numAnswers": 1}, {"id": 2844, "expansion": "CAHe5", "text": "Patient presents with _. Likely a result of _.", "numAnswers": 2}, {"id": 2845, "expansion": "CAHe5", "text": "Hi MTV! My name is Kendra, I live in Malibu, I'm into _, and I love to have a good time.", "numAnswers": 1}, {"id": 2846, "expansion": "CAHe5", "text": "Help me doctor, I've got _ in my butt!", "numAnswers": 1}, {"id": 2847, "expansion": "CAHe5", "text": "Why am I broke?", "numAnswers": 1}, {"id": 2848, "expansion": "CAHe5", "text": "I don't mean to brag, but they call me the Michael Jordan of _.", "numAnswers": 1}, {"id": 2849, "expansion": "CAHe5", "text": "Heed my voice, mortals! I am the god of _, and I will not tolerate _!", "numAnswers": 2}, {"id": 2850, "expansion": "CAHe5", "text": "Here at the Academy for Gifted Children, we allow students to explore _ at their own pace.", "numAnswers": 1}, {"id": 2851, "expansion": "CAHe5", "text": "Well what do you have to say for yourself, Casey? This is the third time you've been sent to the principal's office for _.", "numAnswers": 1}, {"id": 2852, "expansion": "CAHe5", "text": "In his new action comedy, Jackie Chan must fend off ninjas while also dealing with _.", "numAnswers": 1}, {"id": 2853, "expansion": "CAHe5", "text": "Armani suit: $1,000. Dinner for two at that swanky restaurant: $300. The look on her face when you surprise her with _: priceless.", "numAnswers": 1}, {"id": 2854, "expansion": "CAHe5", "text": "Do the Dew &reg; with our most extreme flavor yet! Get ready for Mountain Dew _!", "numAnswers": 1}, {"id": 2930, "expansion": "CAHe6", "text": "I work my ass off all day for this family, and this is what I come home to? _!?", "numAnswers": 1}, {"id": 2931, "expansion": "CAHe6", "text": "I have a strict policy. First date, dinner. Second date, kiss. Third date, _.", "numAnswers": 1}, {"id": 2932, "expansion": "CAHe6", "text": "When I was a kid, we used to play Cowboys and _.", "numAnswers": 1}, {"id": 2933, "expansion": "CAHe6", "text": "This is America. If you don't work hard, you don't succeed. I don't care if you're black, white, purple, or _.", "numAnswers": 1}, {"id": 2934, "expansion": "CAHe6", "text": "You Won't Believe These 15 Hilarious _ Bloopers!", "numAnswers": 1}, {"id": 2935, "expansion": "CAHe6", "text": "James is a lonely boy. But when he discovers a secret door in his attic, he meets a magical new friend: _.", "numAnswers": 1}, {"id": 2936, "expansion": "CAHe6", "text": "Don't worry kid. It gets better. I've been living with _ for 20 years.", "numAnswers": 1}, {"id": 2937, "expansion": "CAHe6", "text": "My grandfather worked his way up from nothing. When he came to this country, all he had was the shoes on his feet and _.", "numAnswers": 1}, {"id": 2938, "expansion": "CAHe6", "text": "Behind every powerful man is _.", "numAnswers": 1}, {"id": 2939, "expansion": "CAHe6", "text": "You are not alone. Millions of Americans struggle with _ every day.", "numAnswers": 1}, {"id": 2940, "expansion": "CAHe6", "text": "Come to Dubai, where you can relax in our world famous spas, experience the nightlife, or simply enjoy _ by the poolside.", "numAnswers": 1}, {"id": 2941, "expansion": "CAHe6", "text": "\"This is madness.\" \"No, THIS IS _!\"", "numAnswers": 1}, {"id": 2942, "expansion": "CAHe6", "text": "Listen Gary, I like you. But if you want that corner office, you're going to have to show me _.", "numAnswers": 1}, {"id": 2943, "expansion": "CAHe6", "text": "I went to the desert and ate of the peyote cactus. Turns out my spirit animal is _.", "numAnswers": 1}, {"id": 2944, "expansion": "CAHe6", "text": "And would you like those buffalo wings mild, hot, or _?", "numAnswers": 1}, {"id": 2945, "expansion": "CAHe6", "text": "The six things I could never do without: oxygen, Facebook, chocolate, Netflix, friends, and _ LOL!", "numAnswers": 1}, {"id": 2946, "expansion": "CAHe6", "text": "Why won't you make love to me anymore? Is it _?", "numAnswers": 1}, {"id": 2947, "expansion": "CAHe6", "text": "Puberty is a time of change. You might notice hair growing in new places. You might develop an interest in _. This is normal.", "numAnswers": 1}, {"id": 2948, "expansion": "CAHe6", "text": "I'm sorry, Mrs. Chen, but there was nothing we could do. At 4:15 this morning, your son succumbed to _.", "numAnswers": 1}, {"id": 2949, "expansion": "CAHe6", "text": "I'm Miss Tennessee, and if I could make the world better by changing one thing, I would get rid of _.", "numAnswers": 1}, {"id": 2950, "expansion": "CAHe6", "text": "Tonight we will have sex. And afterwards, If you'd like, a little bit of _.", "numAnswers": 1}, {"id": 2951, "expansion": "CAHe6", "text": "Everybody join hands and close your eyes. Do you sense that? That's the presence of _ in this room.", "numAnswers": 1}, {"id": 2952, "expansion": "CAHe6", "text": "To become a true Yanomamo warrior, you must prove that you can withstand _ without crying out.", "numAnswers": 1}, {"id": 2953, "expansion": "CAHe6", "text": "Y'all ready to get this thing started? I'm Nick Cannon, and this is America's Got _.", "numAnswers": 1}, {"id": 2954, "expansion": "CAHe6", "text": "If you had to describe the Card Czar, using only one of the cards in your hand, which one would it be?", "numAnswers": 1}]`)
 
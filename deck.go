@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/thedadams/cahbot/game"
+)
+
+// LoadDeck builds the deck from the built-in cards and every *.json pack in Dir, in the order of their names.
+// Dir can be "" for only the built-in cards.
+func LoadDeck(Dir string) (*game.Deck, error) {
+	Deck, err := game.NewDeck(AllQuestions, AllAnswers)
+	if err != nil {
+		return nil, err
+	}
+	if Dir == "" {
+		return Deck, nil
+	}
+	files, err := filepath.Glob(filepath.Join(Dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var pack game.Pack
+		if err := json.Unmarshal(data, &pack); err != nil {
+			return nil, fmt.Errorf("%v: %v", file, err)
+		}
+		if pack.Name == "" {
+			pack.Name = strings.TrimSuffix(filepath.Base(file), ".json")
+		}
+		if err := Deck.Add(pack); err != nil {
+			return nil, fmt.Errorf("%v: %v", file, err)
+		}
+		log.Printf("Loaded %v question cards and %v answer cards from %v.", len(pack.Questions), len(pack.Answers), file)
+	}
+	return Deck, nil
+}
+
+// RunCards runs the cards command, which works with the card packs in --cards-dir.
+func RunCards(Args []string, CardsDir string, Out io.Writer) error {
+	if len(Args) > 0 && Args[0] == "import" {
+		Deck, err := LoadDeck(CardsDir)
+		if err != nil {
+			return err
+		}
+		return RunImport(Args[1:], Deck, CardsDir, Out)
+	}
+	if len(Args) > 0 && Args[0] == "lint" {
+		return RunLint(Args[1:], CardsDir, Out)
+	}
+	return fmt.Errorf("usage: %v cards import|lint", os.Args[0])
+}
+
+// CurrentDeck returns the deck that new games are dealt from.
+func (bot *CAHBot) CurrentDeck() *game.Deck {
+	bot.deckMu.RLock()
+	defer bot.deckMu.RUnlock()
+	return bot.Deck
+}
+
+// ReloadCards loads the cards from CardsDir again and swaps them in.
+// Games in progress keep the cards they hold, and cards that were removed are not dealt again.
+func (bot *CAHBot) ReloadCards() (*game.Deck, error) {
+	fresh, err := LoadDeck(bot.CardsDir)
+	if err != nil {
+		return nil, err
+	}
+	bot.deckMu.Lock()
+	defer bot.deckMu.Unlock()
+	bot.Deck = bot.Deck.Reload(fresh)
+	bot.Store.SetDeck(bot.Deck)
+	return bot.Deck, nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"expvar"
+	"log"
+	"runtime/debug"
+	"sync"
+)
+
+// QueueSize is how many updates can wait for one game before more are turned away.
+const QueueSize = 100
+
+// QueuedUpdates is the number of updates waiting to be handled, across all games.
+var QueuedUpdates = expvar.NewInt("queued_updates")
+
+// ActiveQueues is the number of games that have updates waiting or being handled.
+var ActiveQueues = expvar.NewInt("active_queues")
+
+// QueueDepth is the number of updates waiting to be handled for each game that has a queue.
+// A game is taken out of it when its queue is emptied.
+var QueueDepth = expvar.NewMap("queue_depth")
+
+// ErrQueueFull is returned when an update cannot be queued because its game already has QueueSize updates waiting.
+var ErrQueueFull = errors.New("the queue for the game is full")
+
+// Dispatcher runs work in order for each key, and the work for different keys in parallel.
+// A worker is started for a key when work arrives and stops when its queue is empty.
+type Dispatcher struct {
+	QueueSize int
+
+	mu      sync.Mutex
+	queues  map[string]chan func()
+	running sync.WaitGroup
+}
+
+// NewDispatcher creates a Dispatcher that lets QueueSize pieces of work wait for each key.
+func NewDispatcher(QueueSize int) *Dispatcher {
+	return &Dispatcher{QueueSize: QueueSize, queues: make(map[string]chan func())}
+}
+
+// Dispatch queues Work to run after the work already queued for Key.
+// It returns false if the queue for Key is full and Work was not queued.
+func (d *Dispatcher) Dispatch(Key string, Work func()) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	q, ok := d.queues[Key]
+	if !ok {
+		q = make(chan func(), d.QueueSize)
+		d.queues[Key] = q
+		ActiveQueues.Add(1)
+		d.running.Add(1)
+		go d.work(Key, q)
+	}
+	select {
+	case q <- Work:
+		QueuedUpdates.Add(1)
+		QueueDepth.Add(Key, 1)
+		return true
+	default:
+		return false
+	}
+}
+
+// Wait waits until all the queued work is done.
+func (d *Dispatcher) Wait() {
+	d.running.Wait()
+}
+
+// work runs the work for a key until there is none left.
+func (d *Dispatcher) work(Key string, q chan func()) {
+	defer d.running.Done()
+	for {
+		d.mu.Lock()
+		select {
+		case w := <-q:
+			QueueDepth.Add(Key, -1)
+			d.mu.Unlock()
+			QueuedUpdates.Add(-1)
+			run(Key, w)
+		default:
+			delete(d.queues, Key)
+			QueueDepth.Delete(Key)
+			ActiveQueues.Add(-1)
+			d.mu.Unlock()
+			return
+		}
+	}
+}
+
+// run runs a piece of work, so that a panic only loses that piece of work.
+func run(Key string, Work func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("ERROR: handling an update for %v panicked: %v\n%s", Key, r, debug.Stack())
+		}
+	}()
+	Work()
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDispatchRunsWorkForAKeyInOrder(t *testing.T) {
+	d := NewDispatcher(QueueSize)
+	var mu sync.Mutex
+	var ran []int
+	for i := 0; i < 50; i++ {
+		i := i
+		if !d.Dispatch("abcde", func() {
+			mu.Lock()
+			ran = append(ran, i)
+			mu.Unlock()
+		}) {
+			t.Fatalf("Work %v was not queued.", i)
+		}
+	}
+	d.Wait()
+	want := make([]int, 50)
+	for i := range want {
+		want[i] = i
+	}
+	if !reflect.DeepEqual(ran, want) {
+		t.Errorf("The work ran in the order %v, not %v.", ran, want)
+	}
+}
+
+func TestDispatchRunsKeysConcurrently(t *testing.T) {
+	d := NewDispatcher(QueueSize)
+	blocked, release := make(chan struct{}), make(chan struct{})
+	var ranA []string
+	d.Dispatch("abcde", func() {
+		close(blocked)
+		<-release
+		ranA = append(ranA, "first")
+	})
+	d.Dispatch("abcde", func() { ranA = append(ranA, "second") })
+	<-blocked
+
+	// The other game is not held up by the first one.
+	done := make(chan struct{})
+	d.Dispatch("fghij", func() { close(done) })
+	select {
+	case <-done:
+	case <-time.After(waitTime):
+		t.Fatal("The work for another game waited for the first game.")
+	}
+
+	waited := make(chan struct{})
+	go func() {
+		d.Wait()
+		close(waited)
+	}()
+	select {
+	case <-waited:
+		t.Fatal("Wait returned while work was still running.")
+	case <-time.After(10 * time.Millisecond):
+	}
+	close(release)
+	select {
+	case <-waited:
+	case <-time.After(waitTime):
+		t.Fatal("Wait did not return when the work was done.")
+	}
+	if want := []string{"first", "second"}; !reflect.DeepEqual(ranA, want) {
+		t.Errorf("The first game ran %v, not %v.", ranA, want)
+	}
+}
+
+func TestDispatchTurnsAwayWorkWhenTheQueueIsFull(t *testing.T) {
+	d := NewDispatcher(2)
+	blocked, release := make(chan struct{}), make(chan struct{})
+	d.Dispatch("abcde", func() {
+		close(blocked)
+		<-release
+	})
+	<-blocked
+	for i := 0; i < 2; i++ {
+		if !d.Dispatch("abcde", func() {}) {
+			t.Fatalf("Work %v was not queued behind the running work.", i)
+		}
+	}
+	if d.Dispatch("abcde", func() {}) {
+		t.Error("Work was queued when the queue was full.")
+	}
+	if !d.Dispatch("fghij", func() {}) {
+		t.Error("A full queue turned away work for another game.")
+	}
+	close(release)
+	d.Wait()
+	if !d.Dispatch("abcde", func() {}) {
+		t.Error("Work was not queued once the queue was empty.")
+	}
+	d.Wait()
+}
+
+func TestDispatchKeepsGoingAfterAPanic(t *testing.T) {
+	d := NewDispatcher(QueueSize)
+	ran := false
+	d.Dispatch("abcde", func() { panic("oops") })
+	d.Dispatch("abcde", func() { ran = true })
+	d.Wait()
+	if !ran {
+		t.Error("The work after a panic did not run.")
+	}
+}
+
+func TestQueueDepthIsPublishedForEachGame(t *testing.T) {
+	d := NewDispatcher(QueueSize)
+	blocked, release := make(chan struct{}), make(chan struct{})
+	d.Dispatch("depth", func() {
+		close(blocked)
+		<-release
+	})
+	<-blocked
+	for i := 0; i < 3; i++ {
+		d.Dispatch("depth", func() {})
+	}
+	if depth := QueueDepth.Get("depth"); depth == nil || depth.String() != "3" {
+		t.Errorf("got a queue depth of %v, want 3 waiting behind the running work", depth)
+	}
+	close(release)
+	d.Wait()
+	if depth := QueueDepth.Get("depth"); depth != nil {
+		t.Errorf("got a queue depth of %v after the queue was emptied, want the game taken out", depth)
+	}
+}
@@ -1,124 +1,262 @@
 package main
 
 import (
-	"crypto/sha512"
-	"encoding/base64"
-	"html"
 	"log"
-	"os"
 	"strconv"
 	"strings"
 
+	"github.com/thedadams/cahbot/game"
 	"github.com/thedadams/telegram-bot-api"
 )
 
+// DispatchUpdate queues an update behind the other updates for the same game, and returns whether it was queued.
+// Done, if it is not nil, is called when a queued update is finished with.  Updates the bot does not handle, like
+// edited messages, are not queued.  If the queue for the game is full, the update is not queued and ErrQueueFull
+// is returned, so that the caller can have Telegram send the update again later.
+//
+// An update that has already been handled is skipped.  It is marked as handled after the game is saved, so an
+// update can be handled again if the bot stops in between.  That does no harm: a choice carries its round and
+// does nothing the second time, and a command that already took effect, like /join, is refused.
+func (bot *CAHBot) DispatchUpdate(update tgbotapi.Update, Done func()) (bool, error) {
+	messageType := bot.DetectKindMessageReceived(update)
+	var User *tgbotapi.User
+	var Message *tgbotapi.Message
+	if messageType == "callback" {
+		User, Message = update.CallbackQuery.From, update.CallbackQuery.Message
+	} else if update.Message != nil {
+		User, Message = update.Message.From, update.Message
+	}
+	if User == nil || Message == nil {
+		return false, nil
+	}
+	key := bot.UpdateKey(User, Message, messageType)
+	ok := bot.Work.Dispatch(key, func() {
+		if Done != nil {
+			defer Done()
+		}
+		if handled, err := bot.Store.UpdateHandled(update.UpdateID); err != nil {
+			log.Printf("ERROR: %v", err)
+		} else if handled {
+			log.Printf("Update %v has already been handled.", update.UpdateID)
+			return
+		}
+		bot.HandleUpdate(User, Message, update.CallbackQuery, messageType)
+		if err := bot.Store.MarkUpdateHandled(update.UpdateID); err != nil {
+			log.Printf("ERROR: %v", err)
+		}
+	})
+	if !ok {
+		log.Printf("The queue for %v is full.  Update %v from %v will be handled later.", key, update.UpdateID, User.ID)
+		return false, ErrQueueFull
+	}
+	return true, nil
+}
+
+// UpdateKey returns the key that orders an update: the game the user is playing, the game they are joining, or the user.
+func (bot *CAHBot) UpdateKey(User *tgbotapi.User, Message *tgbotapi.Message, messageType string) string {
+	GameID, err := bot.Store.GameIDForUser(User.ID)
+	if err != nil {
+		log.Printf("ERROR: %v", err)
+	}
+	if GameID != "" {
+		return GameID
+	}
+	if fields := strings.Fields(Message.Text); messageType == "command" && len(fields) > 1 && fields[0] == "/join" {
+		return fields[1]
+	}
+	return "user:" + strconv.Itoa(User.ID)
+}
+
 // HandleUpdate is the starting point for handling an update from chat.
 func (bot *CAHBot) HandleUpdate(User *tgbotapi.User, Message *tgbotapi.Message, Callback *tgbotapi.CallbackQuery, messageType string) {
 	bot.AddUserToDatabase(User, int64(User.ID))
-	GameID, err := GetGameID(User.ID, int64(User.ID), bot.DBConn)
+	GameID, err := bot.Store.GameIDForUser(User.ID)
+	if err != nil {
+		log.Printf("ERROR: %v", err)
+	}
 	log.Printf("Message from %s of type %s with game ID %s", User.String(), messageType, GameID)
 	if messageType == "command" {
 		bot.ProccessCommand(Message, GameID)
 	} else if messageType == "callback" {
 		log.Printf("We received a callback from %v.", User.ID)
+		if GameID == "" {
+			bot.SendNoGameMessage(Message.Chat.ID)
+			return
+		}
 		callbackType := strings.Split(Callback.Data, "::")
 		switch callbackType[0] {
 		case "ChangeSetting":
-			// Handle the change of a setting here.
-			HandlePlayerResponse(bot, GameID, Message, SettingIsValid(bot, Message.Text), Callback.Data, bot.ChangeGameSettings)
+			// Handle the picking of a setting to change here.
+			bot.ShowSettingOptions(User.ID, Message.Chat.ID, Message.MessageID, GameID, Callback.Data)
 		case "Answer":
 			// Handle the receipt of an answer here.
-			answer := AnswerIsValid(bot, int64(User.ID), Message.Text)
-			HandlePlayerResponse(bot, GameID, Message, answer, strconv.Itoa(answer), bot.ReceivedAnswerFromPlayer)
+			round, card, err := ParseRoundChoice(callbackType)
+			if err != nil {
+				log.Printf("The answer we received was not a valid card: %v", Callback.Data)
+				bot.SendActionFailedMessage(Message.Chat.ID)
+				return
+			}
+			bot.ReceivedAnswerFromPlayer(User.ID, Message.Chat.ID, GameID, round, card)
 		case "TradeInCard":
 			// Handle the trading in of a card here.
-			answer := AnswerIsValid(bot, int64(User.ID), Message.Text)
-			HandlePlayerResponse(bot, GameID, Message, answer, strconv.Itoa(answer), bot.TradeInCard)
+			round, pick, err := ParseTradePick(callbackType)
+			if err != nil {
+				log.Printf("GameID: %v - The card to trade in was not valid: %v", GameID, Callback.Data)
+				bot.SendActionFailedMessage(Message.Chat.ID)
+				return
+			}
+			bot.TradeInCard(User.ID, Message.Chat.ID, Message.MessageID, GameID, round, pick)
 		case "CzarBest":
 			// Handle the receipt of a czar picking best answer here.
-			HandleCzarResponse(bot, GameID, Message, callbackType[0], CzarChoiceIsValid(bot, GameID, Message.Text))
+			round, choice, err := ParseRoundChoice(callbackType)
+			if err != nil {
+				log.Printf("GameID: %v - The Card Czar's choice was not valid: %v", GameID, Callback.Data)
+				bot.SendActionFailedMessage(Message.Chat.ID)
+				return
+			}
+			bot.CzarChoseAnswer(User.ID, Message.Chat.ID, Message.MessageID, GameID, round, choice)
 		case "CzarWorst":
-			// Handle the receipt of a czar picking the worst answer here.
-			HandleCzarResponse(bot, GameID, Message, callbackType[0], CzarChoiceIsValid(bot, GameID, Message.Text))
+			// Handle the receipt of a czar picking worst answer here.
+			round, choice, err := ParseRoundChoice(callbackType)
+			if err != nil {
+				log.Printf("GameID: %v - The Card Czar's choice was not valid: %v", GameID, Callback.Data)
+				bot.SendActionFailedMessage(Message.Chat.ID)
+				return
+			}
+			bot.CzarChoseWorst(User.ID, Message.Chat.ID, GameID, round, choice)
+		case "Vote":
+			// Handle a player voting for the best answer here.
+			round, choice, err := ParseRoundChoice(callbackType)
+			if err != nil {
+				log.Printf("GameID: %v - The vote was not valid: %v", GameID, Callback.Data)
+				bot.SendActionFailedMessage(Message.Chat.ID)
+				return
+			}
+			bot.ReceivedVote(User.ID, Message.Chat.ID, GameID, round, choice)
+		case "HouseRule":
+			// Handle the turning on or off of a house rule here.
+			bot.ToggleHouseRule(User.ID, Message.Chat.ID, Message.MessageID, GameID, strings.TrimPrefix(Callback.Data, "HouseRule::"))
+		case "Eliminate":
+			// Handle a player eliminating an answer on their turn here.
+			round, choice, err := ParseRoundChoice(callbackType)
+			if err != nil {
+				log.Printf("GameID: %v - The answer to eliminate was not valid: %v", GameID, Callback.Data)
+				bot.SendActionFailedMessage(Message.Chat.ID)
+				return
+			}
+			bot.EliminateAnswer(User.ID, Message.Chat.ID, GameID, round, choice)
+		case "Pack":
+			// Handle the including or excluding of an expansion pack here.
+			bot.TogglePack(User.ID, Message.Chat.ID, Message.MessageID, GameID, strings.TrimPrefix(Callback.Data, "Pack::"))
+		case "Packs":
+			// The player is done picking the expansion packs.
+			if g, ok := bot.ViewGame(GameID, Message.Chat.ID); ok {
+				bot.Messenger.EditChoices(Message.Chat.ID, Message.MessageID, "The game is played with these expansion packs:\n"+PacksText(g), nil)
+			}
+		default:
+			// The options of a setting look like <setting>::<option>.
+			bot.ChangeGameSettings(User.ID, Message.Chat.ID, Message.MessageID, GameID, Callback.Data)
 		}
 	} else if messageType == "message" || messageType == "photo" || messageType == "video" || messageType == "audio" || messageType == "contact" || messageType == "document" || messageType == "location" || messageType == "sticker" {
-		if err != nil {
-			bot.Send(tgbotapi.NewMessage(int64(User.ID), "It seems that you are not involved in any game so your message fell on deaf ears."))
+		if GameID == "" {
+			bot.Messenger.SendText(int64(User.ID), "It seems that you are not involved in any game so your message fell on deaf ears.")
 		} else {
-			bot.ForwardMessageToGame(Message, GameID)
+			bot.ReceivedMessageFromPlayer(User.ID, Message, GameID, messageType)
 		}
 	}
 }
 
-// SendToGame sends a message from a player to the rest of the group.
-func (bot *CAHBot) SendToGame(GameID, message string) {
-	tx, err := bot.DBConn.Begin()
-	defer tx.Rollback()
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		return
+// UpdateGame loads a game, applies an action to it, saves it and tells the players what happened.
+// If the action is not allowed, the player that tried it is told why.  It returns the game and whether the action was done.
+func (bot *CAHBot) UpdateGame(GameID string, ChatID int64, Action func(*game.Game) ([]game.Event, error)) (*game.Game, bool) {
+	var g *game.Game
+	var events []game.Event
+	var actionErr error
+	err := bot.Store.UpdateGame(GameID, func(loaded *game.Game) error {
+		g = loaded
+		events, actionErr = Action(g)
+		return actionErr
+	})
+	if err == ErrNoGame {
+		bot.Messenger.SendText(ChatID, "There is no game with id "+GameID+".  Please try again with a new id or use /create to create a game.")
+	} else if actionErr != nil {
+		log.Printf("GameID: %v - %v", GameID, actionErr)
+		bot.Messenger.SendText(ChatID, GameErrorMessage(g, actionErr))
+	} else if err != nil {
+		log.Printf("GameID: %v - ERROR: %v", GameID, err)
+		bot.SendActionFailedMessage(ChatID)
+	} else {
+		bot.Announce(g, events)
+		return g, true
 	}
-	rows, err := bot.DBConn.Query("SELECT get_user_ids_for_game($1)", GameID)
-	defer rows.Close()
-	if err != nil {
-		log.Printf("ERROR: %v", err)
+	return g, false
+}
+
+// TickGame reminds the players of a game that the round is waiting for them, or moves the round on without them
+// if its deadline has passed.
+func (bot *CAHBot) TickGame(GameID string) {
+	var g *game.Game
+	var events []game.Event
+	err := bot.Store.UpdateGame(GameID, func(loaded *game.Game) error {
+		g = loaded
+		events = g.Tick()
+		return nil
+	})
+	if err == ErrNoGame {
+		return
+	} else if err != nil {
+		log.Printf("GameID: %v - ERROR: %v", GameID, err)
 		return
 	}
-	var ID int64
-	for rows.Next() {
-		if err := rows.Scan(&ID); err != nil {
-			log.Printf("ERROR: %v", err)
-		} else {
-			bot.Send(tgbotapi.NewMessage(ID, message))
-		}
+	bot.Announce(g, events)
+}
+
+// ViewGame loads a game without changing it.
+func (bot *CAHBot) ViewGame(GameID string, ChatID int64) (*game.Game, bool) {
+	g, err := bot.Store.LoadGame(GameID)
+	if err != nil {
+		log.Printf("GameID: %v - ERROR: %v", GameID, err)
+		bot.SendActionFailedMessage(ChatID)
+		return nil, false
 	}
-	if err := rows.Err(); err != nil {
-		log.Printf("ERROR: %v", err)
+	return g, true
+}
+
+// SendToGame sends a message to everyone in the game.
+func (bot *CAHBot) SendToGame(g *game.Game, message string) {
+	for _, p := range g.Players {
+		bot.Messenger.SendText(p.ChatID, message)
 	}
 }
 
 // ForwardMessageToGame forwards a message from a player to the rest of the group.
 func (bot *CAHBot) ForwardMessageToGame(m *tgbotapi.Message, GameID string) {
-	tx, err := bot.DBConn.Begin()
-	defer tx.Rollback()
+	IDs, err := bot.Store.ChatIDs(GameID)
 	if err != nil {
 		log.Printf("ERROR: %v", err)
 		bot.SendActionFailedMessage(m.Chat.ID)
 		return
 	}
-	rows, err := bot.DBConn.Query("SELECT get_chat_ids_for_game($1)", GameID)
-	defer rows.Close()
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		bot.SendActionFailedMessage(m.Chat.ID)
-		return
-	}
-	var ID int64
-	for rows.Next() {
-		if err := rows.Scan(&ID); err != nil {
-			log.Printf("ERROR: %v", err)
-		} else if ID != m.Chat.ID {
-			bot.Send(tgbotapi.NewForward(ID, m.Chat.ID, m.MessageID))
+	for _, ID := range IDs {
+		if ID != m.Chat.ID {
+			bot.Messenger.Relay(ID, m.Chat.ID, m.MessageID, m.Text)
 		}
 	}
-	if err := rows.Err(); err != nil {
-		log.Printf("ERROR: %v", err)
-	}
 }
 
 // SendNoGameMessage sends a 'There is no game' message.
 func (bot *CAHBot) SendNoGameMessage(ChatID int64) {
-	bot.Send(tgbotapi.NewMessage(ChatID, "You are currently not in a game.  Use command /create to create a new one or /join <id> to join a game with an id."))
+	bot.Messenger.SendText(ChatID, "You are currently not in a game.  Use command /create to create a new one or /join <id> to join a game with an id.")
 }
 
 // WrongCommand sends a "wrong command" message.
 func (bot *CAHBot) WrongCommand(ChatID int64) {
-	bot.Send(tgbotapi.NewMessage(ChatID, "Sorry, I don't know that command."))
+	bot.Messenger.SendText(ChatID, "Sorry, I don't know that command.")
 }
 
 // SendActionFailedMessage sends a generic sorry message.
 func (bot *CAHBot) SendActionFailedMessage(ChatID int64) {
-	bot.Send(tgbotapi.NewMessage(ChatID, "I'm sorry, but it seems I have have difficulties right now.  You can try again later or contact my developer @thedadams."))
+	bot.Messenger.SendText(ChatID, "I'm sorry, but it seems I have have difficulties right now.  You can try again later or contact my developer @thedadams.")
 }
 
 // DetectKindMessageReceived detects the kind of message we received from the user.
@@ -127,49 +265,53 @@ func (bot *CAHBot) DetectKindMessageReceived(u tgbotapi.Update) string {
 	if u.CallbackQuery != nil {
 		return "callback"
 	}
-	if u.Message.Text != "" {
-		if u.Message.IsCommand() {
+	m := u.Message
+	if m == nil {
+		return "undetermined"
+	}
+	if m.Text != "" {
+		if m.IsCommand() {
 			return "command"
 		}
 		return "message"
 	}
-	if len(*u.Message.Photo) != 0 {
+	if m.Photo != nil && len(*m.Photo) != 0 {
 		return "photo"
 	}
-	if u.Message.Audio.FileID != "" {
+	if m.Audio != nil && m.Audio.FileID != "" {
 		return "audio"
 	}
-	if u.Message.Video.FileID != "" {
+	if m.Video != nil && m.Video.FileID != "" {
 		return "video"
 	}
-	if u.Message.Document.FileID != "" {
+	if m.Document != nil && m.Document.FileID != "" {
 		return "document"
 	}
-	if u.Message.Sticker.FileID != "" {
+	if m.Sticker != nil && m.Sticker.FileID != "" {
 		return "sticker"
 	}
-	if len(*u.Message.NewChatMembers) != 0 {
+	if m.NewChatMembers != nil && len(*m.NewChatMembers) != 0 {
 		return "newParticipant"
 	}
-	if u.Message.LeftChatMember.ID != 0 {
+	if m.LeftChatMember != nil && m.LeftChatMember.ID != 0 {
 		return "byeParticipant"
 	}
-	if u.Message.NewChatTitle != "" {
+	if m.NewChatTitle != "" {
 		return "newChatTitle"
 	}
-	if len(*u.Message.NewChatPhoto) != 0 {
+	if m.NewChatPhoto != nil && len(*m.NewChatPhoto) != 0 {
 		return "newChatPhoto"
 	}
-	if u.Message.DeleteChatPhoto {
+	if m.DeleteChatPhoto {
 		return "deleteChatPhoto"
 	}
-	if u.Message.GroupChatCreated {
+	if m.GroupChatCreated {
 		return "newGroupChat"
 	}
-	if u.Message.Contact.UserID != 0 || u.Message.Contact.FirstName != "" || u.Message.Contact.LastName != "" {
+	if m.Contact != nil && (m.Contact.UserID != 0 || m.Contact.FirstName != "" || m.Contact.LastName != "") {
 		return "contact"
 	}
-	if u.Message.Location.Longitude != 0 && u.Message.Location.Latitude != 0 {
+	if m.Location != nil && m.Location.Longitude != 0 && m.Location.Latitude != 0 {
 		return "location"
 	}
 	return "undetermined"
@@ -182,93 +324,55 @@ func (bot *CAHBot) ProccessCommand(m *tgbotapi.Message, GameID string) {
 	// Get the command.
 	switch strings.Replace(strings.Fields(m.Text)[0], "/", "", 1) {
 	case "start":
-		bot.Send(tgbotapi.NewMessage(m.Chat.ID, "Welcome to Cards Against Humanity for Telegram.  To create a new game, use the command /create.  If you create a game, you will be given a 5 character id you can share with friends so they can join you.  You can also join a game using the /join <id> command where the <id> is replaced with a game id created by someone else.  To see all available commands, use /help."))
-		bot.Send(tgbotapi.NewMessage(m.Chat.ID, "While you are in a game, any (non-command) message you send to me will be automatically forwarded to everyone else in the game so you're all in the loop."))
+		bot.Messenger.SendText(m.Chat.ID, "Welcome to Cards Against Humanity for Telegram.  To create a new game, use the command /create.  If you create a game, you will be given a 5 character id you can share with friends so they can join you.  You can also join a game using the /join <id> command where the <id> is replaced with a game id created by someone else.  To see all available commands, use /help.")
+		bot.Messenger.SendText(m.Chat.ID, "While you are in a game, any (non-command) message you send to me will be automatically forwarded to everyone else in the game so you're all in the loop.")
 	case "help":
 		// TODO: use helpers to build a help message.
-		bot.Send(tgbotapi.NewMessage(m.Chat.ID, "A help message should go here."))
+		bot.Messenger.SendText(m.Chat.ID, "A help message should go here.")
 	case "create":
 		if GameID != "" {
-			bot.Send(tgbotapi.NewMessage(m.Chat.ID, "You are already part of a game with id "+GameID+" and cannot create another game.  You can leave your current game with the command /leave."))
+			bot.Messenger.SendText(m.Chat.ID, "You are already part of a game with id "+GameID+" and cannot create another game.  You can leave your current game with the command /leave.")
 		} else {
-			ID := bot.CreateNewGame(m.Chat.ID, m.From)
-			if ID != "" {
-				bot.Send(tgbotapi.NewMessage(m.Chat.ID, "The game was created successfully.  Tell your friends to use the command '/join "+ID+"' to join your game.  Remember that your game will be deleted after 2 days of inactivity."))
-				bot.AddPlayerToGame(ID, m.From, m.Chat.ID)
-			} else {
-				bot.Send(tgbotapi.NewMessage(m.Chat.ID, "An error occurred while trying to create the game.  The game was not created."))
-			}
+			bot.CreateNewGame(m.Chat.ID, m.From)
 		}
 	case "remove":
-		tx, err := bot.DBConn.Begin()
-		defer tx.Rollback()
-		if err != nil {
-			log.Printf("ERROR: %v", err)
-			bot.SendActionFailedMessage(m.Chat.ID)
-			return
-		}
 		// If the user is in a game, we remove them.
 		if GameID != "" {
 			bot.RemovePlayerFromGame(GameID, m.From, m.Chat.ID)
 		}
 		log.Printf("Removing user from the database.")
-		_, err = tx.Exec("SELECT remove_user($1)", m.From.ID)
-		if err != nil {
+		if err := bot.Store.RemoveUser(m.From.ID); err != nil {
 			log.Printf("ERROR: %v", err)
 			bot.SendActionFailedMessage(m.Chat.ID)
 			return
 		}
-		tx.Commit()
-		bot.Send(tgbotapi.NewMessage(m.Chat.ID, "You have been removed from our records. If you ever want to come back, send the command /start.  Thank you for playing."))
+		bot.Messenger.SendText(m.Chat.ID, "You have been removed from our records. If you ever want to come back, send the command /start.  Thank you for playing.")
 
 	case "begin":
 		if GameID != "" {
-			bot.BeginGame(GameID)
+			bot.BeginGame(GameID, m.Chat.ID)
 		} else {
 			bot.SendNoGameMessage(m.Chat.ID)
 		}
 	case "end":
 		if GameID != "" {
-			bot.EndGame(GameID, m.From.String(), false)
+			bot.EndGame(GameID, m.Chat.ID, m.From.String())
 		} else {
 			bot.SendNoGameMessage(m.Chat.ID)
 		}
 	case "join":
 		if len(strings.Fields(m.Text)) > 1 {
 			if GameID != "" {
-				bot.Send(tgbotapi.NewMessage(m.Chat.ID, "You are already part of a game with id "+GameID+" and cannot join another game.  You can leave your current game with the command /leave."))
+				bot.Messenger.SendText(m.Chat.ID, "You are already part of a game with id "+GameID+" and cannot join another game.  You can leave your current game with the command /leave.")
 			} else {
-				// If the user is not part of another game, we check to see if they id they
-				// game is valid.
-				tx, err := bot.DBConn.Begin()
-				defer tx.Rollback()
-				if err != nil {
-					log.Printf("ERROR: %v", err)
-					bot.SendActionFailedMessage(m.Chat.ID)
-					return
-				}
-				var exists bool
-				row := tx.QueryRow("SELECT check_game_exists($1)", strings.Fields(m.Text)[1])
-				if _ = row.Scan(&exists); exists {
-					// If the game is in the middle of a round, we don't add them yet.
-					var InRound bool
-					err = tx.QueryRow("SELECT is_game_in_round($1)", GameID).Scan(&InRound)
-					if err != nil || InRound {
-						bot.Send(tgbotapi.NewMessage(m.Chat.ID, "The game you are trying to join is in the middle of a round.  Please wait until they are finished to join."))
-					} else {
-						// The id is valid and we add them and the game is not in-round.
-						bot.AddPlayerToGame(strings.Fields(m.Text)[1], m.From, m.Chat.ID)
-					}
-				} else {
-					bot.Send(tgbotapi.NewMessage(m.Chat.ID, "There is no game with id "+strings.Fields(m.Text)[1]+".  Please try again with a new id or use /create to create a game."))
-				}
+				bot.AddPlayerToGame(strings.Fields(m.Text)[1], m.From, m.Chat.ID)
 			}
 		} else {
-			bot.Send(tgbotapi.NewMessage(m.Chat.ID, "You did not enter a game id.  Try again with the format /join <id>."))
+			bot.Messenger.SendText(m.Chat.ID, "You did not enter a game id.  Try again with the format /join <id>.")
 		}
 	case "gameid":
 		if GameID != "" {
-			bot.Send(tgbotapi.NewMessage(m.Chat.ID, "The game you are currently playing has id "+GameID+".  Others can join your game by using the command '/join "+GameID+"'."))
+			bot.Messenger.SendText(m.Chat.ID, "The game you are currently playing has id "+GameID+".  Others can join your game by using the command '/join "+GameID+"'.")
 		} else {
 			bot.SendNoGameMessage(m.Chat.ID)
 		}
@@ -280,79 +384,91 @@ func (bot *CAHBot) ProccessCommand(m *tgbotapi.Message, GameID string) {
 		}
 	case "next":
 		if GameID != "" {
-			bot.StartRound(GameID)
+			bot.StartRound(GameID, m.Chat.ID)
 		} else {
 			bot.SendNoGameMessage(m.Chat.ID)
 		}
 	case "cards":
 		if GameID != "" {
-			bot.ListCardsForUserWithMessage(GameID, m.Chat.ID, "Your cards are listed in the keyboard area.")
+			if g, ok := bot.ViewGame(GameID, m.Chat.ID); ok {
+				bot.ListCardsForUserWithMessage(g, g.Player(m.From.ID), "Your cards are listed in the keyboard area.")
+			}
 		} else {
 			bot.SendNoGameMessage(m.Chat.ID)
 		}
 	case "scores":
 		if GameID != "" {
-			bot.Send(tgbotapi.NewMessage(m.Chat.ID, "Here are the current scores:\n"+GameScores(GameID, bot.DBConn)))
+			if g, ok := bot.ViewGame(GameID, m.Chat.ID); ok {
+				bot.Messenger.SendText(m.Chat.ID, "Here are the current scores:\n"+BuildScoreList(g.Scores())+ScoreHistoryText(g))
+			}
 		} else {
 			bot.SendNoGameMessage(m.Chat.ID)
 		}
 	case "settings":
 		if GameID != "" {
-			bot.SendGameSettings(GameID, m.Chat.ID)
+			if g, ok := bot.ViewGame(GameID, m.Chat.ID); ok {
+				bot.SendGameSettings(g, m.Chat.ID)
+			}
 		} else {
 			bot.SendNoGameMessage(m.Chat.ID)
 		}
 	case "changesettings":
 		if GameID != "" {
-			tx, err := bot.DBConn.Begin()
-			defer tx.Rollback()
-			if err != nil {
-				log.Printf("ERROR: %v", err)
-				bot.SendActionFailedMessage(m.Chat.ID)
-				return
-			}
-			var InRound bool
-			err = tx.QueryRow("SELECT is_game_in_round($1)", GameID).Scan(&InRound)
-			if err != nil || InRound {
-				log.Printf("User attempting to change the settings for game with id %v in the middle of a round.", GameID)
-				bot.Send(tgbotapi.NewMessage(m.Chat.ID, "You cannot change settings while the game is in the middle of a round.  Please wait until the round is finished and try again."))
-				return
+			log.Printf("GameID: %v - User with ID %v wants to change the settings.", GameID, m.From.ID)
+			bot.UpdateGame(GameID, m.Chat.ID, func(g *game.Game) ([]game.Event, error) {
+				return g.EditSettings(m.From.ID)
+			})
+		} else {
+			bot.SendNoGameMessage(m.Chat.ID)
+		}
+	case "packs":
+		if GameID != "" {
+			if g, ok := bot.ViewGame(GameID, m.Chat.ID); ok {
+				bot.Messenger.SendChoices(m.Chat.ID, PacksMenuText(g), PackChoices(g))
 			}
-			bot.SendGameSettings(GameID, m.Chat.ID)
-			tx.Commit()
-			message := tgbotapi.NewMessage(m.Chat.ID, "Which setting would you like to change?")
-			message.ReplyMarkup = SetupInlineKeyboard(bot.Settings, 1)
-			bot.Send(message)
+		} else {
+			bot.SendNoGameMessage(m.Chat.ID)
+		}
+	case "reboot":
+		if GameID != "" {
+			log.Printf("GameID: %v - User with ID %v wants to reboot the universe.", GameID, m.From.ID)
+			bot.UpdateGame(GameID, m.Chat.ID, func(g *game.Game) ([]game.Event, error) {
+				return g.RebootUniverse(m.From.ID)
+			})
 		} else {
 			bot.SendNoGameMessage(m.Chat.ID)
 		}
 	case "czar":
 		if GameID != "" {
-			var czar string
-			tx, err := bot.DBConn.Begin()
-			defer tx.Rollback()
-			if err != nil {
-				log.Printf("ERROR: %v", err)
-				bot.SendActionFailedMessage(m.Chat.ID)
-				return
+			if g, ok := bot.ViewGame(GameID, m.Chat.ID); ok && g.CzarPlayer() != nil {
+				bot.Messenger.SendText(m.Chat.ID, "The current Card czar is "+g.CzarPlayer().Name+".")
+			} else if ok && g.Settings.Czarless {
+				bot.Messenger.SendText(m.Chat.ID, "This game has no Card Czar.  Everyone votes for the best answer.")
 			}
-			err = tx.QueryRow("SELECT who_is_czar($1)", GameID).Scan(&czar)
-			if err != nil {
-				log.Printf("ERROR: %v", err)
-				bot.SendActionFailedMessage(m.Chat.ID)
-				return
-			}
-			bot.Send(tgbotapi.NewMessage(m.Chat.ID, "The current Card czar is "+czar+"."))
 		} else {
 			bot.SendNoGameMessage(m.Chat.ID)
 		}
 	case "logging":
 		if len(strings.Fields(m.Text)) > 1 {
-			hasher := sha512.New()
-			if strings.EqualFold(base64.URLEncoding.EncodeToString(hasher.Sum([]byte(strings.Fields(m.Text)[1]))), os.Getenv("APPPASS")) {
-				bot.Debug = !bot.Debug
-				log.Printf("Debugging/verbose logging has been turned to %v.", bot.Debug)
+			if IsAdmin(strings.Fields(m.Text)[1]) {
+				if t, ok := bot.Messenger.(*TelegramMessenger); ok {
+					t.Debug = !t.Debug
+					log.Printf("Debugging/verbose logging has been turned to %v.", t.Debug)
+				}
+			}
+		} else {
+			bot.WrongCommand(m.Chat.ID)
+		}
+	case "reloadcards":
+		if len(strings.Fields(m.Text)) > 1 && IsAdmin(strings.Fields(m.Text)[1]) {
+			Deck, err := bot.ReloadCards()
+			if err != nil {
+				log.Printf("ERROR: %v", err)
+				bot.Messenger.SendText(m.Chat.ID, "The cards were not reloaded: "+err.Error())
+				return
 			}
+			log.Printf("Reloaded the cards from %q.", bot.CardsDir)
+			bot.Messenger.SendText(m.Chat.ID, "Reloaded the cards.  New games are dealt from "+strconv.Itoa(len(Deck.QuestionIDs()))+" question cards and "+strconv.Itoa(len(Deck.AnswerIDs()))+" answer cards.")
 		} else {
 			bot.WrongCommand(m.Chat.ID)
 		}
@@ -363,516 +479,312 @@ func (bot *CAHBot) ProccessCommand(m *tgbotapi.Message, GameID string) {
 
 // AddPlayerToGame adds a player to a game if the player is not playing.
 func (bot *CAHBot) AddPlayerToGame(GameID string, User *tgbotapi.User, ChatID int64) {
-	// This is supposed to check that there are not more than 10 players in a game.
-	tx, err := bot.DBConn.Begin()
-	defer tx.Rollback()
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		bot.SendActionFailedMessage(ChatID)
-		return
-	}
-	var numPlayersInGame int
-	err = tx.QueryRow("SELECT num_players_in_game($1)", GameID).Scan(&numPlayersInGame)
-	if numPlayersInGame > 10 {
-		bot.Send(tgbotapi.NewMessage(ChatID, "Player limit of 10 reached, we can not add any more players."))
-	} else {
-		var tmp bool
-		row := tx.QueryRow("SELECT is_player_in_game($2,$1)", GameID, ChatID)
-		if _ = row.Scan(&tmp); tmp {
-			bot.Send(tgbotapi.NewMessage(ChatID, "You are already playing in this game.  Use command /leave to remove yourself."))
-		} else {
-			log.Printf("Adding %v to the game %v...", User, GameID)
-			_, err = tx.Exec("SELECT add_player_to_game($1, $2)", GameID, ChatID)
-			if err != nil {
-				log.Printf("ERROR: %v", err)
-				bot.SendActionFailedMessage(ChatID)
-				return
-			}
-			if tx.Commit() != nil {
-				log.Printf("ERROR %T: %v", err, err)
-				bot.SendActionFailedMessage(ChatID)
-				return
-			}
-			bot.SendToGame(GameID, User.String()+" has joined the game!")
-			bot.Send(tgbotapi.NewMessage(ChatID, "Welcome to the game!  Here are the currect game settings for your review."))
-			bot.SendGameSettings(GameID, ChatID)
-		}
-	}
+	log.Printf("Adding %v to the game %v...", User, GameID)
+	bot.UpdateGame(GameID, ChatID, func(g *game.Game) ([]game.Event, error) {
+		return g.AddPlayer(User.ID, ChatID, User.String())
+	})
 }
 
 // AddUserToDatabase adds a user to the database. It does not link them to a game.
 func (bot *CAHBot) AddUserToDatabase(User *tgbotapi.User, ChatID int64) bool {
-	// Check to see if the user is already in the database.
-	var exists bool
-	tx, err := bot.DBConn.Begin()
-	defer tx.Rollback()
-	if err != nil {
-		log.Printf("Cannot connect to the database.")
+	if err := bot.Store.AddUser(User.ID, ChatID, User.FirstName, User.LastName, User.UserName, User.String()); err != nil {
+		log.Printf("ERROR: %v", err)
+		bot.SendActionFailedMessage(ChatID)
 		return false
 	}
-	_ = tx.QueryRow("SELECT does_user_exist($1::int)", User.ID).Scan(&exists)
-	if !exists {
-		log.Printf("Adding user with ID %v to the database.", User.ID)
-		_, err = tx.Exec("SELECT add_user($1, $2, $3, $4, $5, $6)", User.ID, ChatID, User.FirstName, User.LastName, User.UserName, User.String())
-		if err != nil {
-			log.Printf("ERROR: %v", err)
-			bot.SendActionFailedMessage(ChatID)
-			return false
-		}
-		tx.Commit()
-		return true
-	}
-	log.Printf("User with id %v is already in the database.", User.ID)
 	return true
 }
 
 // BeginGame begins an already created game.
-func (bot *CAHBot) BeginGame(GameID string) {
-	tx, err := bot.DBConn.Begin()
-	defer tx.Rollback()
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		bot.SendToGame(GameID, "We could not start the game because of an internal error.")
-		return
-	}
-	// Check to see if there are more than 2 players.
-	var tmp int
-	err = tx.QueryRow("SELECT num_players_in_game($1)", GameID).Scan(&tmp)
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		bot.SendToGame(GameID, "We could not start the game because of an internal error.")
-		return
-	}
-	if tmp < 2 {
-		log.Printf("There aren't enough players in game with id " + GameID + " to start it.")
-		tx.Rollback()
-		bot.SendToGame(GameID, "You really need at least 3 players to make it interesting.  Right now, you have "+strconv.Itoa(tmp)+".  Tell others to use the command '/join "+GameID+"' to join your game.")
-		return
-	}
+func (bot *CAHBot) BeginGame(GameID string, ChatID int64) {
 	log.Printf("Trying to start game with id %v.", GameID)
-	tx.Commit()
-	bot.SendToGame(GameID, "Get ready, we are starting the game!")
-	bot.StartRound(GameID)
+	bot.UpdateGame(GameID, ChatID, func(g *game.Game) ([]game.Event, error) {
+		return g.Begin()
+	})
 }
 
-// ChangeGameSettings changes a setting for the given game.
-// TODO: implement.
-func (bot *CAHBot) ChangeGameSettings(ChatID int64, GameID string, Setting string) {
-	tx, err := bot.DBConn.Begin()
-	defer tx.Rollback()
-	if err != nil {
-		log.Printf("GameID: %v - ERROR: %v", GameID, err)
-		bot.SendToGame(GameID, "We could not start the game because of an internal error.")
-		return
+// ChangeGameSettings applies an option picked from the settings menu and shows the menu again with the new settings.
+func (bot *CAHBot) ChangeGameSettings(UserID int, ChatID int64, MessageID int, GameID string, Option string) {
+	log.Printf("GameID: %v - User with ID %v picked the setting %v.", GameID, UserID, Option)
+	g, ok := bot.UpdateGame(GameID, ChatID, func(g *game.Game) ([]game.Event, error) {
+		settings, ok := ApplySetting(g.Settings, Option)
+		if !ok {
+			return nil, ErrUnknownOption
+		}
+		return g.ChangeSettings(UserID, settings)
+	})
+	if ok {
+		bot.Messenger.EditChoices(ChatID, MessageID, SettingsMenuText(g), SettingChoices(bot.Settings))
 	}
 }
 
-// CreateNewGame creates a new game.
-func (bot *CAHBot) CreateNewGame(ChatID int64, User *tgbotapi.User) string {
-	tx, err := bot.DBConn.Begin()
-	defer tx.Rollback()
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		bot.SendActionFailedMessage(ChatID)
-		return ""
-	}
+// CreateNewGame creates a new game and adds the user that created it.
+func (bot *CAHBot) CreateNewGame(ChatID int64, User *tgbotapi.User) {
 	var GameID string
 	for {
 		GameID = GetRandomID()
-		var tmp bool
-		_ = tx.QueryRow("SELECT check_game_exists($1)", GameID).Scan(&tmp)
-		if !tmp {
+		exists, err := bot.Store.GameExists(GameID)
+		if err != nil {
+			log.Printf("ERROR: %v", err)
+			bot.SendActionFailedMessage(ChatID)
+			return
+		}
+		if !exists {
 			break
 		}
 	}
 	log.Printf("Creating a new game with ID %v.", GameID)
-	// Get the keys for the All Cards map.SE
-	ShuffledQuestionCards := make([]int, len(bot.AllQuestionCards))
-	for i := 0; i < len(ShuffledQuestionCards); i++ {
-		ShuffledQuestionCards[i] = i
-	}
-	ShuffledAnswerCards := make([]int, len(bot.AllAnswerCards))
-	for i := 0; i < len(ShuffledAnswerCards); i++ {
-		ShuffledAnswerCards[i] = i
-	}
-	if err != nil {
-		log.Printf("Error creating game: %v", err)
-		bot.SendActionFailedMessage(ChatID)
-		return ""
+	g := game.New(GameID, bot.CurrentDeck(), game.DefaultSettings, nil)
+	events, err := g.AddPlayer(User.ID, ChatID, User.String())
+	if err == nil {
+		err = bot.Store.CreateGame(g)
 	}
-	tx.Exec("SELECT add_game($1,$2,$3,$4)", GameID, ArrayTransformForPostgres(ShuffledQuestionCards), ArrayTransformForPostgres(ShuffledAnswerCards), User.ID)
-	err = tx.Commit()
 	if err != nil {
 		log.Printf("Game could not be created. ERROR: %v", err)
-		bot.SendActionFailedMessage(ChatID)
-		return ""
+		bot.Messenger.SendText(ChatID, "An error occurred while trying to create the game.  The game was not created.")
+		return
 	}
 	log.Printf("Game with id %v created successfully!", GameID)
-	return GameID
+	bot.Messenger.SendText(ChatID, "The game was created successfully.  Tell your friends to use the command '/join "+GameID+"' to join your game.  Remember that your game will be deleted after 2 days of inactivity.")
+	bot.Announce(g, events)
 }
 
-// CzarChoseAnswer handles the czar choosing an answer.
-func (bot *CAHBot) CzarChoseAnswer(ChatID int64, GameID string, Answer string, BestAnswer bool) {
-	log.Printf("The Card Czar for game with id %v chose a valid answer.", GameID)
-	tx, err := bot.DBConn.Begin()
-	defer tx.Rollback()
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		bot.SendActionFailedMessage(ChatID)
-		return
-	}
-	var response string
-	err = tx.QueryRow("SELECT czar_chose_answer($1,$2)", GameID, Answer).Scan(&response)
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		bot.SendActionFailedMessage(ChatID)
-		return
-	}
-	response = response[1 : len(response)-1]
-	winner := strings.Split(response, ",")[0]
-	gameOver := strings.ToUpper(strings.Split(response, ",")[1]) == "TRUE"
-	if BestAnswer {
-		bot.SendToGame(GameID, "The czar chose the best answer: "+Answer+"\n\nThis was "+winner+"'s answer.  You get one Awesome Point!")
-	} else {
-		bot.SendToGame(GameID, "The czar chose the worst answer: "+Answer+"\n\nThis was "+winner+"'s answer.  You lose one Awesome Point.")
-	}
-	if gameOver {
-		tx.Commit()
-		bot.EndGame(GameID, "", true)
+// CzarChoseAnswer handles the czar choosing an answer.  In Serious Business, the czar's choices are shown again
+// without the answers they ranked, until every place is taken.
+func (bot *CAHBot) CzarChoseAnswer(UserID int, ChatID int64, MessageID int, GameID string, Round, Choice int) {
+	log.Printf("The Card Czar for game with id %v chose answer %v.", GameID, Choice)
+	g, ok := bot.UpdateGame(GameID, ChatID, func(g *game.Game) ([]game.Event, error) {
+		return g.ChooseAnswer(UserID, Round, Choice)
+	})
+	if !ok || g.Settings.Judging != game.RankedJudging || g.Round != Round {
+		return
+	}
+	if g.Phase == game.Judging {
+		bot.Messenger.EditChoices(ChatID, MessageID, CzarPrompt(g, g.Answers()), CzarChoices(g, g.Answers()))
 	} else {
-		tx.Exec("SELECT end_round($1)", GameID)
-		err = tx.QueryRow("SELECT who_is_czar($1)", GameID).Scan(&winner)
-		if err != nil {
-			log.Printf("ERROR: %v", err)
-			bot.SendActionFailedMessage(ChatID)
-			return
-		}
-		var czarChatID int64
-		err = tx.QueryRow("SELECT czar_chat_id($1, $2)", GameID, "").Scan(&czarChatID)
-		if err != nil {
-			log.Printf("ERROR: %v", err)
-			bot.SendActionFailedMessage(ChatID)
-			return
-		}
-		tx.Commit()
-		bot.SendToGame(GameID, "The new Card Czar is "+winner+".  They will start the new round soon.")
-		bot.Send(tgbotapi.NewMessage(czarChatID, "You are the Card Czar for the next round.  Use the command /next to start the next round."))
+		bot.Messenger.EditChoices(ChatID, MessageID, "Thanks, czar!  Your ranking is in.", nil)
 	}
 }
 
-// DisplayQuestionCard sends a message show the players the question card.
-func (bot *CAHBot) DisplayQuestionCard(GameID string, AddCardsToPlayersHands bool) {
-	log.Printf("Getting question card index for game with id %v", GameID)
-	tx, err := bot.DBConn.Begin()
-	defer tx.Rollback()
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		return
+// CzarChoseWorst handles the czar picking the worst answer.
+func (bot *CAHBot) CzarChoseWorst(UserID int, ChatID int64, GameID string, Round, Choice int) {
+	log.Printf("GameID: %v - The czar picked the worst answer %v.", GameID, Choice)
+	bot.UpdateGame(GameID, ChatID, func(g *game.Game) ([]game.Event, error) {
+		return g.ChooseWorst(UserID, Round, Choice)
+	})
+}
+
+// EndGame stops and ends an already created game.
+func (bot *CAHBot) EndGame(GameID string, ChatID int64, UserThatStoppedGame string) {
+	log.Printf("Deleting a game with id %v...", GameID)
+	bot.UpdateGame(GameID, ChatID, func(g *game.Game) ([]game.Event, error) {
+		return g.End(UserThatStoppedGame), nil
+	})
+}
+
+// ListAnswers lists the answers for everyone and allows the czar to choose one.
+func (bot *CAHBot) ListAnswers(g *game.Game, Czar *game.Player, Answers []game.Answer) {
+	text := "Here are the submitted answers:\n\n"
+	for _, val := range Answers {
+		text += val.Text + "\n"
 	}
-	var index int
-	err = tx.QueryRow("SELECT get_question_card($1)", GameID).Scan(&index)
+	log.Printf("Showing everyone the answers submitted for game %v.", g.ID)
+	bot.SendToGame(g, text)
+	log.Printf("Asking the czar, %v, to pick an answer for game with id %v.", Czar.ID, g.ID)
+	bot.Messenger.SendChoices(Czar.ChatID, CzarPrompt(g, Answers), CzarChoices(g, Answers))
+}
+
+// ShowEliminationBoards shows every player the answers being eliminated, with Status under them, and gives the
+// player whose turn it is the choices to eliminate one of the answers that are Left.  If Fresh is true, or a player
+// has no board yet, they are sent a new board; otherwise the one they have is edited.
+func (bot *CAHBot) ShowEliminationBoards(g *game.Game, Answers []game.Answer, Left []int, Status string, Fresh bool) {
+	boards, err := bot.Store.Boards(g.ID)
 	if err != nil {
 		log.Printf("ERROR: %v", err)
-		return
 	}
-	log.Printf("The current question cards for game with id %v has index %v.", GameID, index)
-	if AddCardsToPlayersHands && bot.AllQuestionCards[index].NumAnswers > 1 {
-		_, err = tx.Exec("SELECT add_cards_to_all_in_game($1, $2)", GameID, bot.AllQuestionCards[index].NumAnswers-1)
+	text := EliminationText(Answers, Left, Status)
+	for _, p := range g.Players {
+		if ID, ok := boards[p.ID]; ok && !Fresh {
+			bot.Messenger.EditChoices(p.ChatID, ID, text, EliminationChoices(g, p, Answers, Left))
+			continue
+		}
+		ID, err := bot.Messenger.SendChoices(p.ChatID, text, EliminationChoices(g, p, Answers, Left))
 		if err != nil {
 			log.Printf("ERROR: %v", err)
-			return
+			continue
+		}
+		if err := bot.Store.SaveBoard(g.ID, p.ID, ID); err != nil {
+			log.Printf("ERROR: %v", err)
 		}
 	}
-	tx.Commit()
-	log.Printf("Sending question card to game with ID %v...", GameID)
-	message := "Here is the question card:\n\n"
-	message += strings.Replace(html.UnescapeString(bot.AllQuestionCards[index].Text), "\\\"", "", -1)
-	bot.SendToGame(GameID, html.UnescapeString(message))
 }
 
-// EndGame stops and ends an already created game.
-func (bot *CAHBot) EndGame(GameID string, UserThatStoppedGame string, SomeoneWon bool) {
-	tx, err := bot.DBConn.Begin()
-	defer tx.Rollback()
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		bot.SendToGame(GameID, "There was an error when I tried to end the game.  You can try again or contact my developer @thedadams.")
-		return
-	}
-	log.Printf("Deleting a game with id %v...", GameID)
-	rows, err := tx.Query("SELECT end_game($1)", GameID)
-	defer rows.Close()
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		bot.SendToGame(GameID, "There was an error when I tried to end the game.  You can try again or contact my developer @thedadams.")
+// EliminateAnswer handles a player eliminating an answer on their turn.
+func (bot *CAHBot) EliminateAnswer(UserID int, ChatID int64, GameID string, Round, Choice int) {
+	bot.UpdateGame(GameID, ChatID, func(g *game.Game) ([]game.Event, error) {
+		return g.Eliminate(UserID, Round, Choice)
+	})
+}
+
+// ListWorstAnswers asks the czar to pick the worst of the answers that are left.
+func (bot *CAHBot) ListWorstAnswers(g *game.Game, Czar *game.Player, Answers []game.Answer) {
+	log.Printf("Asking the czar, %v, to pick the worst answer for game with id %v.", Czar.ID, g.ID)
+	bot.Messenger.SendChoices(Czar.ChatID, "Czar, now choose the worst answer.  That player loses an Awesome Point.", AnswerChoices(g, "CzarWorst", Answers))
+}
+
+// ListCardsForUserWithMessage lists a user's cards as choices.  If we need them to respond to a question, this is handled.
+func (bot *CAHBot) ListCardsForUserWithMessage(g *game.Game, Player *game.Player, text string) {
+	if Player == nil {
 		return
 	}
-	if !SomeoneWon {
-		// Someone ended the game.
-		bot.SendToGame(GameID, "The game has been stopped by "+UserThatStoppedGame+".\nHere are the scores:\n"+BuildScoreList(rows)+"Thanks for playing!")
-	} else {
-		// The game ended because someone won.
-		bot.SendToGame(GameID, "The game has ended.  Here are the scores:\n"+BuildScoreList(rows)+"Thanks for playing!")
+	log.Printf("Showing the user %v their cards.", Player.ChatID)
+	cards := make([]Choice, len(Player.Hand))
+	for i, card := range g.Hand(Player) {
+		cards[i] = Choice{card.String(), "Answer::" + strconv.Itoa(g.Round) + "::" + strconv.Itoa(Player.Hand[i])}
 	}
-	tx.Commit()
+	bot.Messenger.SendChoices(Player.ChatID, text, cards)
 }
 
-// ListAnswers lists the answers for everyone and allows the czar to choose one.
-func (bot *CAHBot) ListAnswers(GameID string) {
-	tx, err := bot.DBConn.Begin()
-	defer tx.Rollback()
-	if err != nil {
-		log.Printf("ERROR: %v", err)
+// ReceivedAnswerFromPlayer handles the receipt of an answer from a player.
+func (bot *CAHBot) ReceivedAnswerFromPlayer(UserID int, ChatID int64, GameID string, Round, Card int) {
+	bot.UpdateGame(GameID, ChatID, func(g *game.Game) ([]game.Event, error) {
+		return g.PlayCard(UserID, Round, Card)
+	})
+}
+
+// ReceivedMessageFromPlayer writes a message on the blank card the player is playing, or forwards it to the game.
+// While a player is writing on a blank card, their messages are not forwarded.
+func (bot *CAHBot) ReceivedMessageFromPlayer(UserID int, Message *tgbotapi.Message, GameID string, messageType string) {
+	g, ok := bot.ViewGame(GameID, Message.Chat.ID)
+	if !ok {
 		return
 	}
-	var cards string
-	err = tx.QueryRow("SELECT get_answers($1)", GameID).Scan(&cards)
-	if err != nil {
-		log.Printf("ERROR: %v", err)
+	if p := g.Player(UserID); p == nil || p.WritingIn == 0 || g.Phase != game.CollectingAnswers {
+		bot.ForwardMessageToGame(Message, GameID)
 		return
 	}
-	text := "Here are the submitted answers:\n\n"
-	cardsKeyboard := make([][]tgbotapi.KeyboardButton, 1)
-	for i, val := range ShuffleAnswers(strings.Split(cards[1:len(cards)-1], "+=+\",")) {
-		text += strings.Replace(html.UnescapeString(strings.Replace(val[1:len(val)-1], "+=+", "", -1)), "\\\"", "", -1) + "\n"
-		cardsKeyboard[i] = make([]tgbotapi.KeyboardButton, 1)
-		cardsKeyboard[i][0] = tgbotapi.KeyboardButton{Text: strings.Replace(html.UnescapeString(strings.Replace(val[1:len(val)-1], "+=+", "", -1)), "\\\"", "", -1), RequestContact: false, RequestLocation: false}
-	}
-	log.Printf("Showing everyone the answers submitted for game %v.", GameID)
-	bot.SendToGame(GameID, text)
-	var czarChatID int64
-	err = tx.QueryRow("SELECT czar_chat_id($1, $2)", GameID, "czarbest").Scan(&czarChatID)
-	if err != nil {
-		log.Printf("ERROR: %v", err)
+	if messageType != "message" {
+		bot.Messenger.SendText(Message.Chat.ID, "Please type your answer for the blank card.")
 		return
 	}
-	tx.Commit()
-	log.Printf("Asking the czar, %v, to pick an answer for game with id %v.", czarChatID, GameID)
-	message := tgbotapi.NewMessage(czarChatID, "Czar, please choose the best answer.")
-	message.ReplyMarkup = tgbotapi.ReplyKeyboardMarkup{Keyboard: cardsKeyboard, ResizeKeyboard: true, OneTimeKeyboard: true, Selective: false}
-	bot.Send(message)
+	bot.UpdateGame(GameID, Message.Chat.ID, func(g *game.Game) ([]game.Event, error) {
+		return g.WriteIn(UserID, Message.Text)
+	})
 }
 
-// ListCardsForUserWithMessage lists a user's cards using a custom keyboard in the Telegram API.  If we need them to respond to a question, this is handled.
-func (bot *CAHBot) ListCardsForUserWithMessage(GameID string, ChatID int64, text string) {
-	log.Printf("Showing the user %v their cards.", ChatID)
-	tx, err := bot.DBConn.Begin()
-	defer tx.Rollback()
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		bot.SendActionFailedMessage(ChatID)
-		return
-	}
-	var response string
-	err = tx.QueryRow("SELECT get_user_cards($1)", ChatID).Scan(&response)
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		bot.SendActionFailedMessage(ChatID)
-		return
-	}
-	response = response[1 : len(response)-1]
-	message := tgbotapi.NewMessage(ChatID, text)
-	cards := make([][]tgbotapi.InlineKeyboardButton, len(strings.Split(response, ",")))
-	for i := range cards {
-		cards[i] = make([]tgbotapi.InlineKeyboardButton, 1)
-	}
-	for i := 0; i < len(strings.Split(response, ",")); i++ {
-		tmp, _ := strconv.Atoi(strings.Split(response, ",")[i])
-		answerText := html.UnescapeString(bot.AllAnswerCards[tmp].Text)
-		callbackData := "answer::" + answerText
-		cards[i][0] = tgbotapi.InlineKeyboardButton{Text: answerText, CallbackData: &callbackData}
-	}
-	message.ReplyMarkup = tgbotapi.InlineKeyboardMarkup{InlineKeyboard: cards}
-	bot.Send(message)
+// ReceivedVote handles a player voting for the best answer.
+func (bot *CAHBot) ReceivedVote(UserID int, ChatID int64, GameID string, Round, Choice int) {
+	log.Printf("GameID: %v - User with ID %v voted for answer %v.", GameID, UserID, Choice)
+	bot.UpdateGame(GameID, ChatID, func(g *game.Game) ([]game.Event, error) {
+		return g.Vote(UserID, Round, Choice)
+	})
 }
 
-// ReceivedAnswerFromPlayer handles the receipt of an answer from a player.
-func (bot *CAHBot) ReceivedAnswerFromPlayer(ChatID int64, GameID string, Answer string) {
-	tx, err := bot.DBConn.Begin()
-	defer tx.Rollback()
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		bot.SendActionFailedMessage(ChatID)
-		return
-	}
-	AnswerIndex, _ := strconv.Atoi(Answer)
-	var QuestionIndex int
-	var DisplayName string
-	var CurrentAnswer string
-	err = tx.QueryRow("SELECT get_question_card($1)", GameID).Scan(&QuestionIndex)
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		bot.SendActionFailedMessage(ChatID)
+// RemovePlayerFromGame removes a player from a game if the player is playing.
+func (bot *CAHBot) RemovePlayerFromGame(GameID string, User *tgbotapi.User, ChatID int64) {
+	log.Printf("Removing %v from the game %v...", User, GameID)
+	bot.UpdateGame(GameID, ChatID, func(g *game.Game) ([]game.Event, error) {
+		return g.RemovePlayer(User.ID)
+	})
+}
+
+// ShowSettingOptions moves the settings menu of the player changing the settings to what they picked:
+// the options of a setting, back to the settings, or done.
+func (bot *CAHBot) ShowSettingOptions(UserID int, ChatID int64, MessageID int, GameID string, CData string) {
+	if CData == "ChangeSetting::Done" {
+		if _, ok := bot.UpdateGame(GameID, ChatID, func(g *game.Game) ([]game.Event, error) {
+			return g.DoneEditingSettings(UserID)
+		}); ok {
+			bot.Messenger.EditChoices(ChatID, MessageID, "The settings have been saved.", nil)
+		}
 		return
 	}
-	err = tx.QueryRow("SELECT get_current_answer($1)", ChatID).Scan(&CurrentAnswer)
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		bot.SendActionFailedMessage(ChatID)
+	g, ok := bot.ViewGame(GameID, ChatID)
+	if !ok {
 		return
 	}
-	if CurrentAnswer == "" {
-		CurrentAnswer = bot.AllQuestionCards[QuestionIndex].Text
-		// If the question is really a question without any blanks, we just list the answer.
-		if !strings.Contains(CurrentAnswer, "_") {
-			CurrentAnswer = bot.AllAnswerCards[AnswerIndex].Text
+	if g.SettingsEditor != UserID {
+		err := game.ErrNotEditing
+		if g.SettingsEditor != 0 {
+			err = game.ErrSettingsLocked
 		}
-	}
-	CurrentAnswer = strings.Replace(CurrentAnswer, "_", TrimPunctuation(bot.AllAnswerCards[AnswerIndex].Text), 1)
-	_, err = tx.Exec("SELECT received_answer_from_user($1, $2, $3, $4)", ChatID, AnswerIndex, CurrentAnswer, !strings.Contains(CurrentAnswer, "_"))
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		bot.SendActionFailedMessage(ChatID)
+		bot.Messenger.SendText(ChatID, GameErrorMessage(g, err))
 		return
 	}
-	err = tx.QueryRow("SELECT get_display_name($1)", ChatID).Scan(&DisplayName)
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		bot.SendActionFailedMessage(ChatID)
+	if CData == "ChangeSetting::Back" {
+		bot.Messenger.EditChoices(ChatID, MessageID, SettingsMenuText(g), SettingChoices(bot.Settings))
 		return
 	}
-	if strings.Contains(CurrentAnswer, "_") {
-		log.Printf("We received a valid answer from user with id %v, but we need another answer.", ChatID)
-		tx.Commit()
-		bot.ListCardsForUserWithMessage(GameID, ChatID, "We received your answer, but this is a multi-answer questions.  Please choose another answer.")
-	} else {
-		log.Printf("We received a valid, complete answer from user with id %v.", ChatID)
-		bot.SendToGame(GameID, "We received "+DisplayName+"'s answer.")
-		err = tx.QueryRow("SELECT do_we_have_all_answers($1)", GameID).Scan(&QuestionIndex)
-		if err != nil {
-			log.Printf("ERROR: %v", err)
-			bot.SendActionFailedMessage(ChatID)
-			return
-		}
-		tx.Commit()
-		if QuestionIndex == 1 {
-			go bot.ListAnswers(GameID)
-		}
-	}
-}
-
-// RemovePlayerFromGame removes a player from a game if the player is playing.
-func (bot *CAHBot) RemovePlayerFromGame(GameID string, User *tgbotapi.User, ChatID int64) {
-	tx, err := bot.DBConn.Begin()
-	defer tx.Rollback()
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		bot.SendActionFailedMessage(ChatID)
+	if CData == "ChangeSetting::HouseRules" {
+		bot.Messenger.EditChoices(ChatID, MessageID, HouseRulesMenuText(), HouseRuleChoices(g))
 		return
 	}
-	log.Printf("Removing %v from the game %v...", User, GameID)
-	str := ""
-	err = tx.QueryRow("SELECT remove_player_from_game($1)", ChatID).Scan(&str)
-	if err != nil {
-		log.Printf("ERROR: %v", err)
+	setting, ok := FindSetting(bot.Settings, CData)
+	if !ok {
+		log.Printf("GameID: %v - There is no setting %v.", GameID, CData)
 		bot.SendActionFailedMessage(ChatID)
 		return
 	}
-	bot.Send(tgbotapi.NewMessage(ChatID, "Thanks for playing, "+User.String()+"!  You collected "+strings.Split(str[1:len(str)-1], ",")[1]+" Awesome Points."))
-	// Now check to see if there is anyone still in the game.
-	var numPlayersInGame int
-	err = tx.QueryRow("SELECT num_players_in_game($1)", GameID).Scan(&numPlayersInGame)
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		tx.Commit()
-		return
+	bot.Messenger.EditChoices(ChatID, MessageID, setting.Name+":", OptionChoices(setting))
+}
+
+// TogglePack includes or excludes an expansion pack and shows the packs menu again.
+func (bot *CAHBot) TogglePack(UserID int, ChatID int64, MessageID int, GameID string, Pack string) {
+	log.Printf("GameID: %v - User with ID %v is toggling the pack %v.", GameID, UserID, Pack)
+	if g, ok := bot.UpdateGame(GameID, ChatID, func(g *game.Game) ([]game.Event, error) {
+		return g.TogglePack(UserID, Pack)
+	}); ok {
+		bot.Messenger.EditChoices(ChatID, MessageID, PacksMenuText(g), PackChoices(g))
 	}
-	tx.Commit()
-	if numPlayersInGame == 0 {
-		log.Printf("There are no more players in game with id %v.  We shall end it.", GameID)
-		bot.EndGame(GameID, User.String(), false)
-	} else {
-		bot.SendToGame(GameID, User.String()+" has left the game with a score of "+strings.Split(str[1:len(str)-1], ",")[1]+".")
+}
+
+// ToggleHouseRule turns a house rule on or off and shows the house rules menu again.
+func (bot *CAHBot) ToggleHouseRule(UserID int, ChatID int64, MessageID int, GameID string, Rule string) {
+	log.Printf("GameID: %v - User with ID %v is toggling the house rule %v.", GameID, UserID, Rule)
+	if g, ok := bot.UpdateGame(GameID, ChatID, func(g *game.Game) ([]game.Event, error) {
+		return g.ToggleHouseRule(UserID, Rule)
+	}); ok {
+		bot.Messenger.EditChoices(ChatID, MessageID, HouseRulesMenuText(), HouseRuleChoices(g))
 	}
 }
 
 // SendGameSettings sends the game settings to the person that requested them.
-func (bot *CAHBot) SendGameSettings(GameID string, ChatID int64) {
-	tx, err := bot.DBConn.Begin()
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		bot.SendActionFailedMessage(ChatID)
-		tx.Rollback()
-		return
-	}
-	var settings string
-	err = tx.QueryRow("SELECT game_settings($1)", GameID).Scan(&settings)
-	tx.Commit()
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		return
-	}
-	text := "Game settings:\n"
-	settings = strings.Replace(settings, "false", "No", -1)
-	settings = strings.Replace(settings, "true", "Yes", -1)
-	for _, val := range strings.Split(settings[1:len(settings)-1], ",") {
-		text += val[1:len(val)-1] + "\n"
-	}
-	log.Printf("Sending game settings for %v.", GameID)
-	bot.Send(tgbotapi.NewMessage(ChatID, text))
+func (bot *CAHBot) SendGameSettings(g *game.Game, ChatID int64) {
+	log.Printf("Sending game settings for %v.", g.ID)
+	bot.Messenger.SendText(ChatID, "Game settings:\n"+SettingsText(g.Settings)+HouseRulesText(g))
 }
 
 // StartRound handles the starting/resuming of a round.
-func (bot *CAHBot) StartRound(GameID string) {
+func (bot *CAHBot) StartRound(GameID string, ChatID int64) {
 	log.Printf("Attempting to start the next round for game with id %v.", GameID)
-	ids := make([]int64, 0)
-	tx, err := bot.DBConn.Begin()
-	defer tx.Rollback()
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		bot.SendToGame(GameID, "We ran into an error and cannot start the next round.  You can report the error to my developer, @thedadams, or try again later.")
+	bot.UpdateGame(GameID, ChatID, func(g *game.Game) ([]game.Event, error) {
+		return g.StartRound()
+	})
+}
+
+// TradeInCard handles a player picking the cards they trade in at the end of the round.
+// Pick is a card, All to pick as many cards as they can, or Done to trade them in.
+func (bot *CAHBot) TradeInCard(UserID int, ChatID int64, MessageID int, GameID string, Round int, Pick string) {
+	g, ok := bot.UpdateGame(GameID, ChatID, func(g *game.Game) ([]game.Event, error) {
+		switch Pick {
+		case "All":
+			return g.PickAllTrades(UserID, Round)
+		case "Done":
+			return g.TradeIn(UserID, Round)
+		}
+		card, err := strconv.Atoi(Pick)
+		if err != nil {
+			return nil, game.ErrCardNotInHand
+		}
+		return g.PickTrade(UserID, Round, card)
+	})
+	if !ok {
 		return
 	}
-	// Check to see if the game is running and if we are waiting for answers.
-	var waiting bool
-	err = tx.QueryRow("SELECT waiting_for_answers($1)", GameID).Scan(&waiting)
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		bot.SendToGame(GameID, "We ran into an error and cannot start the next round.  You can report the error to my developer, @thedadams, or try again later.")
+	if Pick == "Done" {
+		bot.Messenger.EditChoices(ChatID, MessageID, "You are done trading in cards for this round.", nil)
 		return
 	}
-	if waiting {
-		bot.SendToGame(GameID, "We are waiting for players to give answers.")
-	} else {
-		rows, err := tx.Query("SELECT start_round($1)", GameID)
-		defer rows.Close()
-		if err != nil {
-			log.Printf("ERROR: %v", err)
-			bot.SendToGame(GameID, "We ran into an error and cannot start the next round.  You can report the error to my developer, @thedadams, or try again later.")
-			return
-		}
-		for rows.Next() {
-			var tmp int64
-			err = rows.Scan(&tmp)
-			if err != nil {
-				log.Printf("ERROR: %v", err)
-			}
-			ids = append(ids, tmp)
-		}
-		tx.Commit()
-		if ids[0] == -1 {
-			log.Printf("We cannot start the next round for game with id %v because someone is changing the settings.", GameID)
-			var tmp string
-			tx, err = bot.DBConn.Begin()
-			err = tx.QueryRow("SELECT get_display_name($1)", ids[1]).Scan(&tmp)
-			tx.Rollback()
-			if err != nil {
-				log.Printf("ERROR: %v", err)
-				bot.SendToGame(GameID, "We cannot begin the next round because someone is changing the settings of the game.")
-				return
-			}
-			bot.SendToGame(GameID, "We cannot start the next round because "+tmp+" is changing the settings of the game.")
-			return
-		}
-		bot.DisplayQuestionCard(GameID, true)
-		for i := range ids {
-			log.Printf("Asking %v for an answer card.", ids[i])
-			bot.ListCardsForUserWithMessage(GameID, ids[i], "Please pick an answer for the question.")
-		}
-	}
-}
-
-// TradeInCard handles the trading in of a card at the end of the round.
-func (bot *CAHBot) TradeInCard(ChatID int64, GameID string, Answer string) {
-
+	text, choices := TradeInMenu(g, g.Player(UserID))
+	bot.Messenger.EditChoices(ChatID, MessageID, text, choices)
 }
@@ -0,0 +1,44 @@
+package game
+
+// Award is the Awesome Points a player won, or lost, in a round.  Games keep them in History.
+type Award struct {
+	Round    int
+	PlayerID int
+	Points   int
+}
+
+// score changes a player's Awesome Points and records the change in the game's history.
+func (g *Game) score(p *Player, Points int) {
+	p.Points += Points
+	g.History = append(g.History, Award{g.Round, p.ID, Points})
+}
+
+// award gives each of Winners the Points at the same position, all at once, unless it is the final round, which is
+// played for fun.  It returns the winner that won the game by it: the one with the most Awesome Points of those that
+// reached PointsToWin, or nil.
+func (g *Game) award(Winners []*Player, Points []int) *Player {
+	if g.InFinalRound() {
+		return nil
+	}
+	for i, w := range Winners {
+		g.score(w, Points[i])
+	}
+	var champion *Player
+	for _, w := range Winners {
+		if w.Points >= g.Settings.PointsToWin && (champion == nil || w.Points > champion.Points) {
+			champion = w
+		}
+	}
+	return champion
+}
+
+// forget takes a player that left out of the history, since their points go with them.
+func (g *Game) forget(PlayerID int) {
+	var history []Award
+	for _, a := range g.History {
+		if a.PlayerID != PlayerID {
+			history = append(history, a)
+		}
+	}
+	g.History = history
+}
@@ -0,0 +1,74 @@
+package game
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Blank cards are answer cards that players write their own answer on.  Settings.BlankCards of them are
+// shuffled into the answer pile, with the IDs -1, -2 and so on, so that they never clash with the cards in the deck.
+const (
+	// BlankCardText is what a blank card says in a player's hand.
+	BlankCardText = "✏️ Blank card: write your own answer"
+	// WriteInMarker is put in front of an answer written on a blank card, so that the czar can tell.
+	WriteInMarker = "✏️ "
+	// MaxWriteIn is the most characters a player can write on a blank card.
+	MaxWriteIn = 100
+)
+
+// IsBlankCard reports whether the answer card with the given ID is a blank card.
+func IsBlankCard(ID int) bool {
+	return ID < 0
+}
+
+// blankIDs returns the IDs of the blank cards in the game.
+func (g *Game) blankIDs() []int {
+	IDs := make([]int, g.Settings.BlankCards)
+	for i := range IDs {
+		IDs[i] = -(i + 1)
+	}
+	return IDs
+}
+
+// changeBlankCards shuffles the blank cards that were added into the answer pile, and takes the ones that were
+// removed out of the pile and the discards.  The blank cards that players hold stay in their hands.
+func (g *Game) changeBlankCards(Old, New int) {
+	for ID := -(Old + 1); ID >= -New; ID-- {
+		g.AnswerPile = append(g.AnswerPile, ID)
+	}
+	if New > Old {
+		g.AnswerPile = g.shuffle(g.AnswerPile)
+		return
+	}
+	removed := func(IDs []int) []int {
+		kept := IDs[:0]
+		for _, ID := range IDs {
+			if ID >= -New {
+				kept = append(kept, ID)
+			}
+		}
+		return kept
+	}
+	g.AnswerPile, g.Discards = removed(g.AnswerPile), removed(g.Discards)
+}
+
+// WriteIn plays the blank card a player picked, with Text written on it as their answer.
+func (g *Game) WriteIn(PlayerID int, Text string) ([]Event, error) {
+	p := g.Player(PlayerID)
+	if p == nil {
+		return nil, ErrNotInGame
+	}
+	if p.WritingIn == 0 || g.Phase != CollectingAnswers {
+		return nil, ErrNotWritingIn
+	}
+	Text = strings.Join(strings.Fields(strings.Replace(Text, "_", " ", -1)), " ")
+	if Text == "" {
+		return nil, ErrEmptyWriteIn
+	}
+	if utf8.RuneCountInString(Text) > MaxWriteIn {
+		return nil, ErrWriteInTooLong
+	}
+	card := p.WritingIn
+	p.WritingIn = 0
+	return g.play(p, card, Text), nil
+}
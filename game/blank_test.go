@@ -0,0 +1,73 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteIn(t *testing.T) {
+	g := newGame(t, DefaultSettings, 3)
+	begin(t, g)
+	p := g.Player(2)
+	if _, err := g.WriteIn(p.ID, "too soon"); err != ErrNotWritingIn {
+		t.Errorf("writing without a blank card: got %v, want %v", err, ErrNotWritingIn)
+	}
+	p.Hand[0] = -1
+	events, err := g.PlayCard(p.ID, g.Round, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eventOf(events, WriteInRequested{}) == nil || p.WritingIn != -1 || len(p.Played) != 0 {
+		t.Fatalf("got %v, want the player asked to write on the blank card before it is played", events)
+	}
+	if _, err := g.WriteIn(p.ID, "  _ "); err != ErrEmptyWriteIn {
+		t.Errorf("writing nothing: got %v, want %v", err, ErrEmptyWriteIn)
+	}
+	if _, err := g.WriteIn(p.ID, strings.Repeat("a", MaxWriteIn+1)); err != ErrWriteInTooLong {
+		t.Errorf("writing too much: got %v, want %v", err, ErrWriteInTooLong)
+	}
+	if _, err := g.WriteIn(p.ID, "my   own_answer"); err != nil {
+		t.Fatal(err)
+	}
+	if want := ": " + WriteInMarker + "my own answer."; !strings.HasSuffix(p.Answer, want) {
+		t.Errorf("got answer %q, want it to end with %q", p.Answer, want)
+	}
+	if p.WritingIn != 0 || indexOf(p.Hand, -1) != -1 {
+		t.Errorf("the blank card was not played")
+	}
+}
+
+func TestChangingBlankCards(t *testing.T) {
+	g := newGame(t, DefaultSettings, 3)
+	count := func() int {
+		n := 0
+		for _, card := range append(append([]int(nil), g.AnswerPile...), g.Discards...) {
+			if IsBlankCard(card) {
+				n++
+			}
+		}
+		return n
+	}
+	change := func(Blanks int) {
+		t.Helper()
+		s := g.Settings
+		s.BlankCards = Blanks
+		if _, err := g.EditSettings(1); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := g.ChangeSettings(1, s); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := g.DoneEditingSettings(1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	change(3)
+	if n := count(); n != 3 {
+		t.Errorf("got %v blank cards in the pile, want 3", n)
+	}
+	change(1)
+	if n := count(); n != 1 || indexOf(g.AnswerPile, -1) == -1 {
+		t.Errorf("got %v blank cards in the pile, want only card -1", n)
+	}
+}
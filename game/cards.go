@@ -0,0 +1,218 @@
+package game
+
+import (
+	"encoding/json"
+	"html"
+	"strings"
+)
+
+// QuestionCard represents a black card in CAH.
+type QuestionCard struct {
+	ID         int    `json:"id"`
+	Text       string `json:"text"`
+	NumAnswers int    `json:"numAnswers"`
+	Expansion  string `json:"expansion"`
+	// Retired cards were removed from the card data.  Games that hold them keep them, but they are not dealt again.
+	Retired bool `json:"-"`
+}
+
+// String returns the text of the card as it should be shown to players.
+func (c QuestionCard) String() string {
+	return CleanText(c.Text)
+}
+
+// Blanks returns the number of blanks in the question.
+func (c QuestionCard) Blanks() int {
+	return strings.Count(c.Text, "_")
+}
+
+// AnswerCard represents a white card in CAH.
+type AnswerCard struct {
+	ID        int    `json:"id"`
+	Text      string `json:"text"`
+	Expansion string `json:"expansion"`
+	// Retired cards were removed from the card data.  Games that hold them keep them, but they are not dealt again.
+	Retired bool `json:"-"`
+}
+
+// String returns the text of the card as it should be shown to players.
+func (c AnswerCard) String() string {
+	return CleanText(c.Text)
+}
+
+// Deck holds every card that a game can draw from.
+// Games refer to cards by their IDs, so that hands and draw piles can be stored as integers and stay the same
+// when cards are added to the card data or moved around in it.
+type Deck struct {
+	Questions []QuestionCard
+	Answers   []AnswerCard
+
+	// questions and answers map the ID of each card to its position in Questions or Answers.
+	questions map[int]int
+	answers   map[int]int
+}
+
+// NewDeck builds a Deck from the JSON card data.  It returns an error if two cards have the same ID.
+func NewDeck(Questions, Answers []byte) (*Deck, error) {
+	var p Pack
+	if err := json.Unmarshal(Questions, &p.Questions); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(Answers, &p.Answers); err != nil {
+		return nil, err
+	}
+	d := new(Deck)
+	if err := d.Add(p); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Question returns the question card with the given ID.  A card that is not in the deck has no text.
+func (d *Deck) Question(ID int) QuestionCard {
+	if ID == HaikuID {
+		return HaikuCard
+	}
+	if i, ok := d.questions[ID]; ok {
+		return d.Questions[i]
+	}
+	return QuestionCard{ID: ID, Retired: true}
+}
+
+// Answer returns the answer card with the given ID.  A card that is not in the deck has no text.
+func (d *Deck) Answer(ID int) AnswerCard {
+	if IsBlankCard(ID) {
+		return AnswerCard{ID: ID, Text: BlankCardText}
+	}
+	if i, ok := d.answers[ID]; ok {
+		return d.Answers[i]
+	}
+	return AnswerCard{ID: ID, Retired: true}
+}
+
+// HasQuestion reports whether the question card with the given ID is in the deck.
+func (d *Deck) HasQuestion(ID int) bool {
+	_, ok := d.questions[ID]
+	return ok
+}
+
+// HasAnswer reports whether the answer card with the given ID is in the deck.  Blank cards always are.
+func (d *Deck) HasAnswer(ID int) bool {
+	if IsBlankCard(ID) {
+		return true
+	}
+	_, ok := d.answers[ID]
+	return ok
+}
+
+// QuestionIDs returns the IDs of all the question cards in the deck that can be dealt.
+func (d *Deck) QuestionIDs() []int {
+	var IDs []int
+	for _, q := range d.Questions {
+		if !q.Retired {
+			IDs = append(IDs, q.ID)
+		}
+	}
+	return IDs
+}
+
+// AnswerIDs returns the IDs of all the answer cards in the deck that can be dealt.
+func (d *Deck) AnswerIDs() []int {
+	var IDs []int
+	for _, a := range d.Answers {
+		if !a.Retired {
+			IDs = append(IDs, a.ID)
+		}
+	}
+	return IDs
+}
+
+// index maps the ID of every card to its position.
+func (d *Deck) index() {
+	d.questions = make(map[int]int, len(d.Questions))
+	for i, q := range d.Questions {
+		d.questions[q.ID] = i
+	}
+	d.answers = make(map[int]int, len(d.Answers))
+	for i, a := range d.Answers {
+		d.answers[a.ID] = i
+	}
+}
+
+// Expansion is an expansion pack in the deck and how many cards it has.
+type Expansion struct {
+	Name      string
+	Questions int
+	Answers   int
+}
+
+// Expansions returns the expansions in the deck that have cards to deal, in the order they first appear.
+func (d *Deck) Expansions() []Expansion {
+	var expansions []Expansion
+	index := make(map[string]int)
+	find := func(Name string) *Expansion {
+		i, ok := index[Name]
+		if !ok {
+			i = len(expansions)
+			index[Name] = i
+			expansions = append(expansions, Expansion{Name: Name})
+		}
+		return &expansions[i]
+	}
+	for _, q := range d.Questions {
+		if !q.Retired {
+			find(q.Expansion).Questions++
+		}
+	}
+	for _, a := range d.Answers {
+		if !a.Retired {
+			find(a.Expansion).Answers++
+		}
+	}
+	return expansions
+}
+
+// CleanText removes the HTML entities and escaped quotes from card text.
+func CleanText(Text string) string {
+	return strings.Replace(html.UnescapeString(Text), "\\\"", "", -1)
+}
+
+// FillBlanks builds the answer to a question from the answer cards played, in order.
+// If the question has no blanks, the answers are listed instead.
+func FillBlanks(Question QuestionCard, Answers []AnswerCard) string {
+	if Question.Blanks() == 0 {
+		texts := make([]string, len(Answers))
+		for i, a := range Answers {
+			texts[i] = a.String()
+		}
+		return strings.Join(texts, " / ")
+	}
+	// The answers are put between the parts of the question, so that an answer with a _ in it is not filled too.
+	parts := strings.SplitAfter(Question.String(), "_")
+	for i, a := range Answers {
+		if i < len(parts)-1 {
+			parts[i] = strings.TrimSuffix(parts[i], "_") + TrimPunctuation(a.String())
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// LastCharactorIsPunctuation checks to see if the last character of a string is punctuation.
+func LastCharactorIsPunctuation(TheString string) bool {
+	length := len(TheString) - 1
+	if length < 0 {
+		return false
+	}
+	if string(TheString[length]) == "." || string(TheString[length]) == "!" || string(TheString[length]) == "?" {
+		return true
+	}
+	return false
+}
+
+// TrimPunctuation trims the punctuation on an answer to help the grammar.
+func TrimPunctuation(TheString string) string {
+	if !LastCharactorIsPunctuation(TheString) {
+		return TheString
+	}
+	return TrimPunctuation(strings.TrimSuffix(strings.TrimSuffix(strings.TrimSuffix(TheString, "!"), "?"), "."))
+}
@@ -0,0 +1,187 @@
+package game
+
+import (
+	"math/rand"
+	"time"
+)
+
+// CzarTimeoutRule is what happens when the czar does not judge before the deadline.
+type CzarTimeoutRule int
+
+// The rules for a czar that does not judge in time.
+const (
+	// ReplaceCzar makes the next player the czar for the rest of the round.  Their answer goes back in their hand.
+	ReplaceCzar CzarTimeoutRule = iota
+	// RandomPick picks one of the answers at random.
+	RandomPick
+	// VotePick lets the players that answered vote for the best answer.
+	VotePick
+)
+
+func (r CzarTimeoutRule) String() string {
+	switch r {
+	case ReplaceCzar:
+		return "replace the czar"
+	case RandomPick:
+		return "pick at random"
+	case VotePick:
+		return "vote"
+	}
+	return "unknown"
+}
+
+// Rounds have deadlines when Settings.AnswerMinutes or Settings.CzarMinutes are set.  Halfway to a deadline the
+// players that are holding up the round are reminded, and at the deadline Tick moves the round on without them.
+
+// Due reports whether it is time to remind the players or move the round on at Now.
+func (g *Game) Due(Now time.Time) bool {
+	return !g.Deadline.IsZero() && !Now.Before(g.Deadline) || !g.RemindAt.IsZero() && !Now.Before(g.RemindAt)
+}
+
+// Tick reminds the players that are holding up the round, or moves the round on if its deadline has passed.
+// Calling it before then does nothing.
+func (g *Game) Tick() []Event {
+	if !g.Phase.InRound() {
+		g.setDeadline(0)
+		return nil
+	}
+	now := g.now()
+	if !g.Due(now) {
+		return nil
+	}
+	if !g.Deadline.IsZero() && !now.Before(g.Deadline) {
+		g.RemindAt, g.Deadline = time.Time{}, time.Time{}
+		return g.timeUp()
+	}
+	g.RemindAt = time.Time{}
+	if waiting := g.Waiting(); len(waiting) != 0 {
+		return []Event{DeadlineNear{waiting, g.Deadline}}
+	}
+	return nil
+}
+
+// Waiting returns the players the round is waiting for: the players that have not answered, the czar, the
+// players that have not voted, or the player whose turn it is to eliminate an answer.
+func (g *Game) Waiting() []*Player {
+	var waiting []*Player
+	switch g.Phase {
+	case CollectingAnswers:
+		for _, p := range g.Players {
+			if p.ID != g.Czar && !g.answered(p) {
+				waiting = append(waiting, p)
+			}
+		}
+	case Judging, JudgingWorst:
+		if czar := g.CzarPlayer(); czar != nil {
+			waiting = append(waiting, czar)
+		}
+	case Voting:
+		for _, p := range g.voters() {
+			if p.Vote == 0 {
+				waiting = append(waiting, p)
+			}
+		}
+	case Eliminating:
+		if p := g.Player(g.Turn); p != nil {
+			waiting = append(waiting, p)
+		}
+	}
+	return waiting
+}
+
+// timeUp moves the round on when its deadline has passed.
+func (g *Game) timeUp() []Event {
+	switch g.Phase {
+	case CollectingAnswers:
+		return g.skipWaiting()
+	case Judging:
+		return g.czarTimedOut(g.CzarPlayer())
+	case JudgingWorst:
+		// The best answer has been picked, so the round ends without a worst one.
+		events := []Event{CzarTimedOut{g.CzarPlayer(), g.Settings.CzarTimeout, true}}
+		return append(events, g.endRound()...)
+	case Voting:
+		return g.countVotes()
+	case Eliminating:
+		return g.eliminateForTurn()
+	}
+	return nil
+}
+
+// skipWaiting takes the players that have not answered out of the round.  The cards they played go back in their
+// hands.  The answers that are in are judged, or the round ends if there are none.
+func (g *Game) skipWaiting() []Event {
+	skipped := g.Waiting()
+	for _, p := range skipped {
+		p.Hand = append(p.Hand, p.Played...)
+		p.Played, p.Written, p.Answer, p.WritingIn = nil, nil, "", 0
+	}
+	events := []Event{PlayersSkipped{skipped}}
+	answered := g.mysteryAnswered()
+	for _, p := range g.Players {
+		answered = answered || p.ID != g.Czar && g.answered(p)
+	}
+	if !answered {
+		// Nobody answered, so there is nothing to judge.
+		return append(events, g.endRound()...)
+	}
+	return append(events, g.startJudging()...)
+}
+
+// czarTimedOut handles a czar that did not judge in time, by the rule in the settings.
+// A czar is replaced once a round, and only by someone who leaves another answer for them to judge.
+// A vote needs more than one answer to choose from.  Otherwise an answer is picked at random, or in Serious Business,
+// the places the czar did not take are.
+func (g *Game) czarTimedOut(Czar *Player) []Event {
+	rule := g.Settings.CzarTimeout
+	events := []Event{CzarTimedOut{Czar, rule, false}}
+	next := g.Player(g.nextCzar())
+	switch {
+	case rule == ReplaceCzar && !g.StandIn && next != nil && next.ID != g.Czar && (indexOf(g.Order, next.ID) == -1 || len(g.Order) > 1):
+		next.Hand = append(next.Hand, next.Played...)
+		next.Played, next.Written, next.Answer = nil, nil, ""
+		if i := indexOf(g.Order, next.ID); i != -1 {
+			g.Order = append(g.Order[:i], g.Order[i+1:]...)
+		}
+		g.Czar, g.StandIn = next.ID, true
+		g.Ranked = nil
+		g.setDeadline(g.Settings.CzarMinutes)
+		return append(events, CzarReplaced{Czar, next, g.Answers()})
+	case rule == VotePick && len(g.Order) > 1:
+		g.Ranked = nil
+		return append(events, g.startVote()...)
+	case len(g.Order) == 0:
+		return append(events, g.endRound()...)
+	case g.Settings.Judging == RankedJudging:
+		return append(events, g.rankAtRandom()...)
+	}
+	return append(events, g.chooseAnswer(g.intn(len(g.Order)), false, true)...)
+}
+
+// setDeadline gives the players Minutes to do what the round is waiting for, and reminds them halfway through.
+// Minutes of 0 takes the deadline away.
+func (g *Game) setDeadline(Minutes int) {
+	if Minutes <= 0 {
+		g.Deadline, g.RemindAt = time.Time{}, time.Time{}
+		return
+	}
+	now := g.now()
+	limit := time.Duration(Minutes) * time.Minute
+	g.Deadline, g.RemindAt = now.Add(limit), now.Add(limit/2)
+}
+
+// now returns the time from the game's clock.
+func (g *Game) now() time.Time {
+	if g.Clock != nil {
+		return g.Clock()
+	}
+	return time.Now()
+}
+
+// intn returns a random number in [0, n) from the game's random source.
+func (g *Game) intn(n int) int {
+	if g.Rand != nil {
+		return g.Rand.Intn(n)
+	}
+	return rand.Intn(n)
+}
@@ -0,0 +1,132 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+// timedGame creates a game whose deadlines are told by a clock that only moves when the test moves it.
+func timedGame(t *testing.T, Settings Settings, Players int) (*Game, *testClock) {
+	t.Helper()
+	g := newGame(t, Settings, Players)
+	clock := &testClock{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	g.Clock = clock.Now
+	return g, clock
+}
+
+func TestAnswerDeadline(t *testing.T) {
+	g, clock := timedGame(t, Settings{CardsInHand: 7, PointsToWin: 7, AnswerMinutes: 2}, 3)
+	begin(t, g)
+	if events := g.Tick(); len(events) != 0 {
+		t.Errorf("got %v before the reminder, want nothing", events)
+	}
+	clock.Advance(time.Minute)
+	if !g.Due(clock.Now()) {
+		t.Fatalf("the game is not due a reminder halfway to the deadline")
+	}
+	near, ok := eventOf(g.Tick(), DeadlineNear{}).(DeadlineNear)
+	if !ok || len(near.Waiting) != 2 {
+		t.Fatalf("got %v, want the 2 players that have not answered reminded", near)
+	}
+	if events := g.Tick(); len(events) != 0 {
+		t.Errorf("got %v, want the players reminded only once", events)
+	}
+	late := g.Player(3)
+	card := late.Hand[0]
+	if _, err := g.PlayCard(2, g.Round, g.Player(2).Hand[0]); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Minute)
+	events := g.Tick()
+	skipped, ok := eventOf(events, PlayersSkipped{}).(PlayersSkipped)
+	if !ok || len(skipped.Players) != 1 || skipped.Players[0] != late {
+		t.Fatalf("got %v, want player 3 skipped", events)
+	}
+	if g.Phase != Judging || len(g.Answers()) != 1 {
+		t.Errorf("got phase %v with %v answers, want the one answer judged", g.Phase, len(g.Answers()))
+	}
+	if indexOf(late.Hand, card) == -1 {
+		t.Errorf("the skipped player lost a card")
+	}
+}
+
+func TestAnswerDeadlineWithoutAnswers(t *testing.T) {
+	g, clock := timedGame(t, Settings{CardsInHand: 7, PointsToWin: 7, AnswerMinutes: 1}, 3)
+	begin(t, g)
+	clock.Advance(time.Minute)
+	events := g.Tick()
+	if eventOf(events, RoundEnded{}) == nil || g.Phase != RoundOver {
+		t.Errorf("got %v in phase %v, want the round ended with nothing to judge", events, g.Phase)
+	}
+}
+
+// czarTimesOut plays a round up to judging and lets the czar's deadline pass.
+func czarTimesOut(t *testing.T, Rule CzarTimeoutRule, Players int) (*Game, []Event) {
+	t.Helper()
+	g, clock := timedGame(t, Settings{CardsInHand: 7, PointsToWin: 7, CzarMinutes: 2, CzarTimeout: Rule}, Players)
+	begin(t, g)
+	answerAll(t, g)
+	clock.Advance(2 * time.Minute)
+	events := g.Tick()
+	if timedOut, ok := eventOf(events, CzarTimedOut{}).(CzarTimedOut); !ok || timedOut.Rule != Rule {
+		t.Fatalf("got %v, want the czar timed out by the rule to %v", events, Rule)
+	}
+	return g, events
+}
+
+func TestCzarTimeoutReplacesTheCzar(t *testing.T) {
+	g, events := czarTimesOut(t, ReplaceCzar, 4)
+	replaced, ok := eventOf(events, CzarReplaced{}).(CzarReplaced)
+	if !ok || replaced.Old.ID != 1 || replaced.New.ID != 2 {
+		t.Fatalf("got %v, want player 2 standing in for player 1", events)
+	}
+	if g.Czar != 2 || !g.StandIn || g.Phase != Judging {
+		t.Errorf("got czar %v, stand-in %v in phase %v, want player 2 standing in to judge", g.Czar, g.StandIn, g.Phase)
+	}
+	if len(g.Player(2).Played) != 0 || len(g.Answers()) != 2 {
+		t.Errorf("the stand-in's answer is still being judged")
+	}
+	g.Clock = func() time.Time { return g.Deadline }
+	events = g.Tick()
+	if chosen, ok := eventOf(events, AnswerChosen{}).(AnswerChosen); !ok || !chosen.Random {
+		t.Errorf("got %v, want the stand-in not replaced but an answer picked at random", events)
+	}
+}
+
+func TestCzarTimeoutPicksAtRandom(t *testing.T) {
+	g, events := czarTimesOut(t, RandomPick, 3)
+	if chosen, ok := eventOf(events, AnswerChosen{}).(AnswerChosen); !ok || !chosen.Random || chosen.Player.Points != 1 {
+		t.Errorf("got %v, want an answer picked at random for a point", events)
+	}
+	if g.Phase != RoundOver {
+		t.Errorf("got phase %v, want round over", g.Phase)
+	}
+}
+
+func TestCzarTimeoutStartsAVote(t *testing.T) {
+	g, events := czarTimesOut(t, VotePick, 3)
+	started, ok := eventOf(events, VoteStarted{}).(VoteStarted)
+	if !ok || len(started.Voters) != 3 || g.Phase != Voting {
+		t.Fatalf("got %v in phase %v, want all 3 players voting", events, g.Phase)
+	}
+	if waiting := g.Waiting(); len(waiting) != 3 {
+		t.Errorf("got %v waiting, want the czar waited for too", waiting)
+	}
+	if _, err := g.Vote(g.Czar, g.Round, 0); err != nil {
+		t.Errorf("the czar voting: got %v", err)
+	}
+}
+
+func TestWorstTimeoutEndsTheRound(t *testing.T) {
+	g, clock := timedGame(t, Settings{CardsInHand: 7, PointsToWin: 7, PickWorst: true, CzarMinutes: 1}, 4)
+	begin(t, g)
+	answerAll(t, g)
+	if _, err := g.ChooseAnswer(1, g.Round, 0); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Minute)
+	events := g.Tick()
+	if timedOut, ok := eventOf(events, CzarTimedOut{}).(CzarTimedOut); !ok || !timedOut.Worst || g.Phase != RoundOver {
+		t.Errorf("got %v in phase %v, want the round ended without a worst answer", events, g.Phase)
+	}
+}
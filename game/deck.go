@@ -0,0 +1,108 @@
+package game
+
+import "fmt"
+
+// Pack is a file of cards that is added to the built-in deck.  Cards without an expansion belong to the pack's Name.
+type Pack struct {
+	Name      string         `json:"name"`
+	Questions []QuestionCard `json:"questions"`
+	Answers   []AnswerCard   `json:"answers"`
+}
+
+// Add checks the cards of a pack and adds them to the deck.  Nothing is added if a card is not valid.
+// Every card needs text and an ID that no other card has, and a question with blanks must ask for one answer per blank.
+func (d *Deck) Add(P Pack) error {
+	IDs := make(map[int]bool)
+	for _, q := range d.Questions {
+		IDs[q.ID] = true
+	}
+	for _, a := range d.Answers {
+		IDs[a.ID] = true
+	}
+	check := func(Kind string, ID int, Text string) error {
+		if ID <= 0 {
+			return fmt.Errorf("%v card %q does not have an ID", Kind, Text)
+		}
+		if IDs[ID] {
+			return fmt.Errorf("%v card %v: another card has the same ID", Kind, ID)
+		}
+		if Text == "" {
+			return fmt.Errorf("%v card %v does not have any text", Kind, ID)
+		}
+		IDs[ID] = true
+		return nil
+	}
+	for i := range P.Questions {
+		q := &P.Questions[i]
+		if err := check("question", q.ID, q.Text); err != nil {
+			return err
+		}
+		if q.NumAnswers < 1 || (q.Blanks() != 0 && q.Blanks() != q.NumAnswers) {
+			return fmt.Errorf("question card %v has %v blanks but asks for %v answers", q.ID, q.Blanks(), q.NumAnswers)
+		}
+		if q.Expansion == "" {
+			q.Expansion = P.Name
+		}
+	}
+	for i := range P.Answers {
+		a := &P.Answers[i]
+		if err := check("answer", a.ID, a.Text); err != nil {
+			return err
+		}
+		if a.Expansion == "" {
+			a.Expansion = P.Name
+		}
+	}
+	d.Questions = append(d.Questions, P.Questions...)
+	d.Answers = append(d.Answers, P.Answers...)
+	d.index()
+	return nil
+}
+
+// Reload returns a deck with the cards of Fresh.  The cards of d that are not in Fresh are kept but retired,
+// so that the games holding them can still show them.
+func (d *Deck) Reload(Fresh *Deck) *Deck {
+	r := &Deck{Questions: append([]QuestionCard(nil), Fresh.Questions...), Answers: append([]AnswerCard(nil), Fresh.Answers...)}
+	for _, q := range d.Questions {
+		if !Fresh.HasQuestion(q.ID) {
+			q.Retired = true
+			r.Questions = append(r.Questions, q)
+		}
+	}
+	for _, a := range d.Answers {
+		if !Fresh.HasAnswer(a.ID) {
+			a.Retired = true
+			r.Answers = append(r.Answers, a)
+		}
+	}
+	r.index()
+	return r
+}
+
+// ForgetMissingCards takes the cards that are not in the deck out of the draw piles, the discards and the hands,
+// and deals new cards to the players that lost some.  It returns how many cards were taken out.
+// The cards played this round are left alone, because their answers have already been written.
+func (g *Game) ForgetMissingCards() int {
+	forgotten := 0
+	keep := func(IDs []int, Has func(int) bool) []int {
+		kept := IDs[:0]
+		for _, ID := range IDs {
+			if Has(ID) {
+				kept = append(kept, ID)
+			} else {
+				forgotten++
+			}
+		}
+		return kept
+	}
+	g.QuestionPile = keep(g.QuestionPile, g.Deck.HasQuestion)
+	g.AnswerPile = keep(g.AnswerPile, g.Deck.HasAnswer)
+	g.Discards = keep(g.Discards, g.Deck.HasAnswer)
+	for _, p := range g.Players {
+		n := len(p.Hand)
+		p.Hand = keep(p.Hand, g.Deck.HasAnswer)
+		p.Trading = keep(p.Trading, g.Deck.HasAnswer)
+		p.Hand = append(p.Hand, g.drawAnswers(n-len(p.Hand))...)
+	}
+	return forgotten
+}
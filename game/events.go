@@ -0,0 +1,247 @@
+package game
+
+import "time"
+
+// Event is something that happened in a game that the players should hear about.
+// The game returns events from every action so that a chat adapter can tell the players.
+type Event interface {
+	isEvent()
+}
+
+// PlayerJoined is sent when a player joins the game.
+type PlayerJoined struct {
+	Player *Player
+}
+
+// PlayerLeft is sent when a player leaves the game.
+type PlayerLeft struct {
+	Player *Player
+}
+
+// GameBegan is sent when the first round of a game is about to start.
+type GameBegan struct{}
+
+// RoundStarted is sent when a question card is shown and the players need to answer.
+type RoundStarted struct {
+	Czar     *Player
+	Question QuestionCard
+	Waiting  []*Player
+}
+
+// AnswerReceived is sent when a player plays a card.
+// If the question needs more cards, Complete is false.
+type AnswerReceived struct {
+	Player   *Player
+	Complete bool
+}
+
+// AnswersCollected is sent when every player has answered and the czar needs to judge.
+type AnswersCollected struct {
+	Czar    *Player
+	Answers []Answer
+}
+
+// AnswerChosen is sent when the czar picks the winning answer.  Random is true if it was picked at random because
+// the czar took too long, and ForFun is true if it was the final round, which is played for no points.
+type AnswerChosen struct {
+	Player *Player
+	Answer string
+	Random bool
+	ForFun bool
+}
+
+// WorstRequested is sent when the czar has picked the best answer and needs to pick the worst of the others.
+type WorstRequested struct {
+	Czar    *Player
+	Answers []Answer
+}
+
+// WorstChosen is sent when the czar picks the worst answer.  Best is nil if the best player has left the game.
+type WorstChosen struct {
+	Best        *Player
+	BestAnswer  string
+	Worst       *Player
+	WorstAnswer string
+}
+
+// RoundEnded is sent when a round is over and a new czar has been chosen.  Czar is nil if the game has no czar.
+type RoundEnded struct {
+	Czar *Player
+}
+
+// GameEnded is sent when a game is over.  Winner is nil if someone stopped the game.
+type GameEnded struct {
+	Winner    *Player
+	StoppedBy string
+	Scores    []Score
+}
+
+// EditingSettings is sent when a player starts changing the settings.
+type EditingSettings struct {
+	Player *Player
+}
+
+// SettingsChanged is sent when a player changes the settings.
+type SettingsChanged struct {
+	Player *Player
+	Old    Settings
+	New    Settings
+}
+
+// TradesOffered is sent when a round is over and the players can trade in cards.
+type TradesOffered struct {
+	Players []*Player
+}
+
+// CardsTraded is sent when a player trades in cards.  Drawn can be shorter than Discarded if the cards ran out.
+type CardsTraded struct {
+	Player    *Player
+	Discarded []int
+	Drawn     []int
+}
+
+// WriteInRequested is sent when a player plays a blank card and needs to write their answer on it.
+type WriteInRequested struct {
+	Player *Player
+}
+
+// DeadlineNear is sent halfway to the deadline of a round, to remind the players it is waiting for.
+type DeadlineNear struct {
+	Waiting  []*Player
+	Deadline time.Time
+}
+
+// PlayersSkipped is sent when the deadline for answers passes, with the players that did not answer in time.
+type PlayersSkipped struct {
+	Players []*Player
+}
+
+// CzarTimedOut is sent when the czar does not judge in time.  Worst is true if they had already picked the best
+// answer, and the round ends without a worst one; otherwise Rule is what happens next.
+type CzarTimedOut struct {
+	Czar  *Player
+	Rule  CzarTimeoutRule
+	Worst bool
+}
+
+// CzarReplaced is sent when the czar took too long and another player judges the answers instead.
+type CzarReplaced struct {
+	Old     *Player
+	New     *Player
+	Answers []Answer
+}
+
+// VoteStarted is sent when the players need to vote for the best answer.
+type VoteStarted struct {
+	Voters  []*Player
+	Answers []Answer
+}
+
+// VoteReceived is sent when a player votes.
+type VoteReceived struct {
+	Player *Player
+}
+
+// VoteWon is sent when the votes are counted.  Winners get an Awesome Point for their Answers, which had Votes each.
+// Tie is true if more than one answer had the most votes; then Settings.TieBreak decided the winners, if any.
+// ForFun is true if it was the final round, and the winners get no points.
+type VoteWon struct {
+	Winners []*Player
+	Answers []string
+	Votes   int
+	Tie     bool
+	ForFun  bool
+}
+
+// EliminationStarted is sent when the players start taking turns eliminating answers, starting with Turn.
+type EliminationStarted struct {
+	Turn    *Player
+	Answers []Answer
+}
+
+// AnswerEliminated is sent when a player eliminates an answer.  Random is true if it was eliminated at random
+// because they took too long.  Next is the player whose turn it is now, or nil if one answer is left.
+// Left is the positions in Answers of the answers that have not been eliminated.
+type AnswerEliminated struct {
+	Player  *Player
+	Answer  string
+	Random  bool
+	Next    *Player
+	Answers []Answer
+	Left    []int
+}
+
+// EliminationChanged is sent when a player leaves while the players are eliminating answers.  Turn is the player
+// whose turn it is now, and Left is the positions in Answers of the answers that have not been eliminated.
+type EliminationChanged struct {
+	Turn    *Player
+	Answers []Answer
+	Left    []int
+}
+
+// AnswersRanked is sent when the czar has ranked the best answers in Serious Business.  Random is true if some of the
+// places were picked at random because the czar took too long, and ForFun is true if it was the final round, which
+// is played for no points.
+type AnswersRanked struct {
+	Czar   *Player
+	Places []Place
+	Random bool
+	ForFun bool
+}
+
+// HouseRuleToggled is sent when a player turns a house rule on or off.
+type HouseRuleToggled struct {
+	Player *Player
+	Rule   string
+	On     bool
+}
+
+// FinalRoundAhead is sent when a player has won, but a house rule plays one more round before the game ends.
+type FinalRoundAhead struct {
+	Winner *Player
+	Rule   string
+}
+
+// UniverseRebooted is sent when a player spends an Awesome Point on a new hand.
+type UniverseRebooted struct {
+	Player *Player
+}
+
+// PackToggled is sent when a player includes or excludes an expansion pack.
+type PackToggled struct {
+	Player   *Player
+	Pack     string
+	Included bool
+}
+
+func (PlayerJoined) isEvent()       {}
+func (PlayerLeft) isEvent()         {}
+func (GameBegan) isEvent()          {}
+func (RoundStarted) isEvent()       {}
+func (AnswerReceived) isEvent()     {}
+func (AnswersCollected) isEvent()   {}
+func (AnswerChosen) isEvent()       {}
+func (WorstRequested) isEvent()     {}
+func (WorstChosen) isEvent()        {}
+func (RoundEnded) isEvent()         {}
+func (GameEnded) isEvent()          {}
+func (EditingSettings) isEvent()    {}
+func (SettingsChanged) isEvent()    {}
+func (TradesOffered) isEvent()      {}
+func (CardsTraded) isEvent()        {}
+func (PackToggled) isEvent()        {}
+func (WriteInRequested) isEvent()   {}
+func (DeadlineNear) isEvent()       {}
+func (PlayersSkipped) isEvent()     {}
+func (CzarTimedOut) isEvent()       {}
+func (CzarReplaced) isEvent()       {}
+func (VoteStarted) isEvent()        {}
+func (VoteReceived) isEvent()       {}
+func (VoteWon) isEvent()            {}
+func (HouseRuleToggled) isEvent()   {}
+func (EliminationStarted) isEvent() {}
+func (AnswerEliminated) isEvent()   {}
+func (EliminationChanged) isEvent() {}
+func (AnswersRanked) isEvent()      {}
+func (FinalRoundAhead) isEvent()    {}
+func (UniverseRebooted) isEvent()   {}
@@ -0,0 +1,745 @@
+// Package game implements the rules of Cards Against Humanity.
+// It knows nothing about Telegram or the database: a Game is a state machine
+// that owns the decks, the hands, the czar rotation and the scores, and every
+// action returns the events that the players should be told about.
+package game
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// The limits on the number of players in a game.
+const (
+	MinPlayers = 2
+	MaxPlayers = 10
+)
+
+// Errors returned when an action is not allowed by the rules.
+var (
+	ErrGameFull         = errors.New("game: the game is full")
+	ErrAlreadyInGame    = errors.New("game: player is already in the game")
+	ErrNotInGame        = errors.New("game: player is not in the game")
+	ErrNotEnoughPlayers = errors.New("game: not enough players")
+	ErrRoundInProgress  = errors.New("game: a round is in progress")
+	ErrAlreadyBegun     = errors.New("game: the game has already begun")
+	ErrWaitingOnAnswers = errors.New("game: waiting for players to answer")
+	ErrWaitingOnCzar    = errors.New("game: waiting for the czar to choose")
+	ErrNotCollecting    = errors.New("game: not collecting answers")
+	ErrNotJudging       = errors.New("game: not waiting for the czar")
+	ErrCzarCannotAnswer = errors.New("game: the czar does not answer")
+	ErrAlreadyAnswered  = errors.New("game: player has already answered")
+	ErrCardNotInHand    = errors.New("game: card is not in the player's hand")
+	ErrNotCzar          = errors.New("game: player is not the czar")
+	ErrInvalidChoice    = errors.New("game: invalid choice")
+	ErrGameOver         = errors.New("game: the game is over")
+	ErrOldRound         = errors.New("game: the choice is from an earlier round")
+	ErrSettingsLocked   = errors.New("game: someone else is changing the settings")
+	ErrNotEditing       = errors.New("game: player is not changing the settings")
+	ErrInvalidSettings  = errors.New("game: invalid settings")
+	ErrTooManyToTrade   = errors.New("game: more cards to trade in than there are in a hand")
+	ErrNotTrading       = errors.New("game: cards cannot be traded in now")
+	ErrTradeLimit       = errors.New("game: too many cards picked to trade in")
+	ErrAlreadyTraded    = errors.New("game: player has already traded in cards")
+	ErrUnknownPack      = errors.New("game: there is no such expansion pack")
+	ErrNotEnoughCards   = errors.New("game: the enabled packs do not have enough cards")
+	ErrNotWritingIn     = errors.New("game: player is not writing on a blank card")
+	ErrEmptyWriteIn     = errors.New("game: nothing was written on the blank card")
+	ErrWriteInTooLong   = errors.New("game: too much was written on the blank card")
+	ErrWaitingOnVotes   = errors.New("game: waiting for players to vote")
+	ErrNotVoting        = errors.New("game: not voting")
+	ErrAlreadyVoted     = errors.New("game: player has already voted")
+	ErrOwnAnswer        = errors.New("game: players cannot vote for their own answer")
+	ErrUnknownHouseRule = errors.New("game: there is no such house rule")
+	ErrHouseRuleOff     = errors.New("game: the house rule is not on")
+	ErrNoPoints         = errors.New("game: player does not have an Awesome Point to spend")
+	ErrWaitingOnTurns   = errors.New("game: waiting for players to eliminate answers")
+	ErrNotEliminating   = errors.New("game: not eliminating answers")
+	ErrNotYourTurn      = errors.New("game: it is not the player's turn")
+	ErrAlreadyRanked    = errors.New("game: the answer has already been ranked")
+)
+
+// Phase is the stage that a game is in.
+type Phase int
+
+// The phases of a game.
+const (
+	// Lobby is a game that has been created, but no round has been played.
+	Lobby Phase = iota
+	// CollectingAnswers is a round that is waiting for players to play cards.
+	CollectingAnswers
+	// Judging is a round that is waiting for the czar to pick an answer.
+	Judging
+	// RoundOver is a game between rounds.
+	RoundOver
+	// Finished is a game that has ended.
+	Finished
+	// JudgingWorst is a round that is waiting for the czar to pick the worst answer, after they picked the best one.
+	JudgingWorst
+	// Voting is a round that is waiting for the players to vote for the best answer.
+	Voting
+	// Eliminating is a round that is waiting for the players to take turns eliminating answers until one is left.
+	Eliminating
+)
+
+func (p Phase) String() string {
+	switch p {
+	case Lobby:
+		return "lobby"
+	case CollectingAnswers:
+		return "collecting answers"
+	case Judging:
+		return "judging"
+	case RoundOver:
+		return "round over"
+	case Finished:
+		return "finished"
+	case JudgingWorst:
+		return "judging worst"
+	case Voting:
+		return "voting"
+	case Eliminating:
+		return "eliminating"
+	}
+	return "unknown"
+}
+
+// InRound reports whether a round is being played.
+func (p Phase) InRound() bool {
+	return p == CollectingAnswers || p == Judging || p == JudgingWorst || p == Voting || p == Eliminating
+}
+
+// Player is someone playing in a game.
+type Player struct {
+	ID     int
+	ChatID int64
+	Name   string
+	Points int
+	Hand   []int
+	// Played is the cards played this round, in order.
+	Played []int
+	// Written is what the player wrote on the blank cards in Played, in the same order, or "" for the other cards.
+	Written []string
+	// WritingIn is the blank card the player is writing their answer on, or 0.
+	WritingIn int
+	// Answer is the question with the blanks filled by the cards played.
+	Answer string
+	// Trading is the cards picked to trade in after the round, and Traded is whether they have been traded in.
+	Trading []int
+	Traded  bool
+	// Vote is the ID of the player whose answer the player voted for, or 0.
+	Vote int
+}
+
+// Answer is an answer submitted for the czar to judge.
+type Answer struct {
+	PlayerID int
+	Text     string
+}
+
+// Score is the number of Awesome Points a player has.
+type Score struct {
+	Name   string
+	Points int
+}
+
+// Game is a game of Cards Against Humanity.
+// The exported fields are the state of the game, so that it can be saved and loaded.
+type Game struct {
+	ID       string
+	Settings Settings
+	Phase    Phase
+	// Players are in the order that they become the czar.
+	Players []*Player
+	// Czar is the ID of the current czar.
+	Czar int
+	// Question is the ID of the current question card, or -1.
+	Question int
+	// QuestionPile and AnswerPile are the draw piles.  Cards are drawn from the end.
+	QuestionPile []int
+	AnswerPile   []int
+	// Discards are the answer cards that have been played.
+	Discards []int
+	// Order is the IDs of the players whose answers are being judged, in the order they are shown.
+	Order []int
+	// Round is the number of rounds that have been started.  Choices offered to the players carry it,
+	// so that a choice from an earlier round, or the same choice made twice, does no harm.
+	Round int
+	// SettingsEditor is the ID of the player changing the settings, or 0.  Only one player can change them at a time.
+	SettingsEditor int
+	// Mystery is the mystery player, or nil if they have never played in this game.
+	Mystery *Player
+	// ExcludedPacks are the expansion packs whose cards are left out of the game.
+	ExcludedPacks []string
+	// Best is the ID of the player whose answer the czar picked as the best, while they pick the worst, or 0.
+	Best int
+	// Deadline is when the round moves on without the players it is waiting for, and RemindAt is when they are
+	// reminded.  They are zero when there is no deadline, and RemindAt is zero once they have been reminded.
+	Deadline time.Time
+	RemindAt time.Time
+	// StandIn is whether the czar is standing in for one that took too long to judge this round.
+	StandIn bool
+	// HouseRules are the names of the house rules that are on, in the order they were turned on.
+	HouseRules []string
+	// FinalRound is the number of the round that is played for fun after someone won, or 0.
+	// The game ends after it.
+	FinalRound int
+	// Eliminated is the IDs of the players whose answers have been eliminated this round, and Turn is the ID of the
+	// player whose turn it is to eliminate one.
+	Eliminated []int
+	Turn       int
+	// Ranked is the IDs of the players whose answers the czar ranked this round in Serious Business, from first place.
+	Ranked []int
+	// History is the Awesome Points the players won and lost, round by round.
+	History []Award
+
+	Deck *Deck
+	Rand *rand.Rand
+	// Clock tells the time for the deadlines.  If it is nil, the time is time.Now.
+	Clock func() time.Time
+}
+
+// New creates a game in the lobby with freshly shuffled piles.
+func New(ID string, Deck *Deck, Settings Settings, Rand *rand.Rand) *Game {
+	g := &Game{ID: ID, Settings: Settings, Phase: Lobby, Question: -1, Deck: Deck, Rand: Rand}
+	g.QuestionPile = g.shuffle(g.questionIDs())
+	g.AnswerPile = g.shuffle(g.answerIDs())
+	return g
+}
+
+// Player returns the player with the given ID, or nil if they are not in the game.
+func (g *Game) Player(ID int) *Player {
+	for _, p := range g.Players {
+		if p.ID == ID {
+			return p
+		}
+	}
+	return nil
+}
+
+// CzarPlayer returns the current czar, or nil if there is none.
+func (g *Game) CzarPlayer() *Player {
+	return g.Player(g.Czar)
+}
+
+// QuestionCard returns the current question card.
+func (g *Game) QuestionCard() QuestionCard {
+	return g.Deck.Question(g.Question)
+}
+
+// Hand returns the cards in a player's hand.
+func (g *Game) Hand(p *Player) []AnswerCard {
+	cards := make([]AnswerCard, len(p.Hand))
+	for i, ID := range p.Hand {
+		cards[i] = g.Deck.Answer(ID)
+	}
+	return cards
+}
+
+// Answers returns the answers being judged in the order they are shown.
+func (g *Game) Answers() []Answer {
+	answers := make([]Answer, 0, len(g.Order))
+	for _, id := range g.Order {
+		if p := g.answerer(id); p != nil {
+			answers = append(answers, Answer{p.ID, p.Answer})
+		}
+	}
+	return answers
+}
+
+// Scores returns the score of every player.
+func (g *Game) Scores() []Score {
+	scores := make([]Score, len(g.Players))
+	for i, p := range g.Players {
+		scores[i] = Score{p.Name, p.Points}
+	}
+	if g.Mystery != nil {
+		scores = append(scores, Score{g.Mystery.Name, g.Mystery.Points})
+	}
+	return scores
+}
+
+// AddPlayer adds a player to the game and deals them a hand.
+func (g *Game) AddPlayer(ID int, ChatID int64, Name string) ([]Event, error) {
+	if g.Phase == Finished {
+		return nil, ErrGameOver
+	}
+	if g.Phase.InRound() {
+		return nil, ErrRoundInProgress
+	}
+	if g.Player(ID) != nil {
+		return nil, ErrAlreadyInGame
+	}
+	if len(g.Players) >= MaxPlayers {
+		return nil, ErrGameFull
+	}
+	p := &Player{ID: ID, ChatID: ChatID, Name: Name}
+	p.Hand = g.drawAnswers(g.Settings.CardsInHand)
+	g.Players = append(g.Players, p)
+	if g.Czar == 0 && !g.Settings.Czarless {
+		g.Czar = ID
+	}
+	return []Event{PlayerJoined{p}}, nil
+}
+
+// RemovePlayer removes a player from the game.  Their cards are discarded.
+func (g *Game) RemovePlayer(ID int) ([]Event, error) {
+	p := g.Player(ID)
+	if p == nil {
+		return nil, ErrNotInGame
+	}
+	if g.Czar == ID {
+		g.Czar = g.nextCzar()
+	}
+	g.forget(ID)
+	if i := indexOf(g.Ranked, ID); i != -1 {
+		g.Ranked = append(g.Ranked[:i], g.Ranked[i+1:]...)
+	}
+	turn := g.Turn
+	if g.Phase == Eliminating && g.Turn == ID {
+		g.Turn = g.nextTurn()
+	}
+	for i := range g.Players {
+		if g.Players[i] == p {
+			g.Players = append(g.Players[:i], g.Players[i+1:]...)
+			break
+		}
+	}
+	g.Discards = append(g.Discards, p.Hand...)
+	g.Discards = append(g.Discards, p.Played...)
+	if i := indexOf(g.Order, ID); i != -1 {
+		g.Order = append(g.Order[:i], g.Order[i+1:]...)
+	}
+	if g.SettingsEditor == ID {
+		g.SettingsEditor = 0
+	}
+	events := []Event{PlayerLeft{p}}
+	if len(g.Players) == 0 {
+		g.Phase = Finished
+		return append(events, GameEnded{StoppedBy: p.Name}), nil
+	}
+	if (g.Czar == 0 || g.Czar == ID) && !g.Settings.Czarless {
+		g.Czar = g.Players[0].ID
+	}
+	if !g.Phase.InRound() {
+		return events, nil
+	}
+	if len(g.Players) < MinPlayers {
+		// There is nobody left to answer, so the round cannot finish.
+		return append(events, g.endRound()...), nil
+	}
+	if czar := g.CzarPlayer(); czar != nil && len(czar.Played) != 0 && g.Phase != Voting && g.Phase != Eliminating {
+		// The new czar already answered, so their cards go back in their hand.
+		czar.Hand = append(czar.Hand, czar.Played...)
+		czar.Played, czar.Written, czar.Answer = nil, nil, ""
+		if i := indexOf(g.Order, czar.ID); i != -1 {
+			g.Order = append(g.Order[:i], g.Order[i+1:]...)
+		}
+	}
+	if g.Phase == CollectingAnswers {
+		return append(events, g.checkAllAnswered()...), nil
+	}
+	if len(g.Order) == 0 {
+		// There is nothing left to judge.
+		return append(events, g.endRound()...), nil
+	}
+	if g.Phase == Voting && (len(g.Order) < 2 || len(g.Waiting()) == 0) {
+		return append(events, g.countVotes()...), nil
+	}
+	if g.Phase == Eliminating {
+		if len(g.Survivors()) < 2 {
+			return append(events, g.survived()...), nil
+		}
+		if g.Turn != turn {
+			g.setDeadline(g.Settings.CzarMinutes)
+		}
+		return append(events, EliminationChanged{g.Player(g.Turn), g.Answers(), g.Survivors()}), nil
+	}
+	if g.Phase == Judging && len(g.Ranked) != 0 && len(g.Ranked) >= g.Places() {
+		// The czar already ranked every answer that is left.
+		return append(events, g.scoreRanking(false)...), nil
+	}
+	return events, nil
+}
+
+// Begin starts the first round of a game.
+func (g *Game) Begin() ([]Event, error) {
+	if g.Phase != Lobby {
+		return nil, ErrAlreadyBegun
+	}
+	if len(g.Players) < MinPlayers {
+		return nil, ErrNotEnoughPlayers
+	}
+	if err := g.dealFromPacks(); err != nil {
+		return nil, err
+	}
+	events, err := g.StartRound()
+	if err != nil {
+		return nil, err
+	}
+	return append([]Event{GameBegan{}}, events...), nil
+}
+
+// StartRound draws a question card and asks the players for answers.
+func (g *Game) StartRound() ([]Event, error) {
+	switch g.Phase {
+	case CollectingAnswers:
+		return nil, ErrWaitingOnAnswers
+	case Judging, JudgingWorst:
+		return nil, ErrWaitingOnCzar
+	case Voting:
+		return nil, ErrWaitingOnVotes
+	case Eliminating:
+		return nil, ErrWaitingOnTurns
+	case Finished:
+		return nil, ErrGameOver
+	}
+	if len(g.Players) < MinPlayers {
+		return nil, ErrNotEnoughPlayers
+	}
+	g.Question = g.drawQuestion()
+	g.Order = nil
+	g.Phase = CollectingAnswers
+	g.Round++
+	g.SettingsEditor = 0
+	g.setDeadline(g.Settings.AnswerMinutes)
+	events := g.finishTrades()
+	events = append(events, g.hooks(func(r HouseRule) []Event { return r.RoundStarted(g) })...)
+
+	question := g.QuestionCard()
+	var waiting []*Player
+	for _, p := range g.Players {
+		p.Played, p.Written, p.Answer, p.WritingIn = nil, nil, "", 0
+		if p.ID == g.Czar {
+			continue
+		}
+		// Players need extra cards to answer questions with more than one blank.
+		if question.NumAnswers > 1 {
+			p.Hand = append(p.Hand, g.drawAnswers(question.NumAnswers-1)...)
+		}
+		waiting = append(waiting, p)
+	}
+	g.playMystery(question)
+	return append(events, RoundStarted{g.CzarPlayer(), question, waiting}), nil
+}
+
+// PlayCard plays a card from a player's hand as their answer to the question of Round.
+// Playing a card that was already played this round does nothing.  A blank card is only played once the player
+// has written their answer on it with WriteIn.
+func (g *Game) PlayCard(PlayerID, Round, Card int) ([]Event, error) {
+	if Round != g.Round {
+		return nil, ErrOldRound
+	}
+	if p := g.Player(PlayerID); p != nil && indexOf(p.Played, Card) != -1 {
+		return nil, nil
+	}
+	if g.Phase != CollectingAnswers {
+		return nil, ErrNotCollecting
+	}
+	p := g.Player(PlayerID)
+	if p == nil {
+		return nil, ErrNotInGame
+	}
+	if p.ID == g.Czar {
+		return nil, ErrCzarCannotAnswer
+	}
+	if g.answered(p) {
+		return nil, ErrAlreadyAnswered
+	}
+	if indexOf(p.Hand, Card) == -1 {
+		return nil, ErrCardNotInHand
+	}
+	if IsBlankCard(Card) {
+		p.WritingIn = Card
+		return []Event{WriteInRequested{p}}, nil
+	}
+	p.WritingIn = 0
+	return g.play(p, Card, ""), nil
+}
+
+// play moves a card from a player's hand to their answer.  Written is what they wrote on it if it is a blank card.
+func (g *Game) play(p *Player, Card int, Written string) []Event {
+	if i := indexOf(p.Hand, Card); i != -1 {
+		p.Hand = append(p.Hand[:i], p.Hand[i+1:]...)
+	}
+	p.Played = append(p.Played, Card)
+	p.Written = append(p.Written, Written)
+	played := make([]AnswerCard, len(p.Played))
+	for j, ID := range p.Played {
+		played[j] = g.Deck.Answer(ID)
+		if j < len(p.Written) && p.Written[j] != "" {
+			played[j].Text = WriteInMarker + p.Written[j]
+		}
+	}
+	p.Answer = FillBlanks(g.QuestionCard(), played)
+	if !g.answered(p) {
+		return []Event{AnswerReceived{p, false}}
+	}
+	if need := g.Settings.CardsInHand - len(p.Hand); need > 0 {
+		p.Hand = append(p.Hand, g.drawAnswers(need)...)
+	}
+	events := []Event{AnswerReceived{p, true}}
+	events = append(events, g.hooks(func(r HouseRule) []Event { return r.AnswerSubmitted(g, p) })...)
+	return append(events, g.checkAllAnswered()...)
+}
+
+// ChooseAnswer is the czar choosing the best answer in Round.  Choice is the position of the answer in Answers.
+// If the czar picks the worst answer too, they are asked for it next.  Choosing again after that does nothing.
+// In Serious Business, the czar chooses the answer for the next place instead.
+func (g *Game) ChooseAnswer(CzarID, Round, Choice int) ([]Event, error) {
+	if Round != g.Round {
+		return nil, ErrOldRound
+	}
+	if g.Phase == RoundOver || g.Phase == JudgingWorst {
+		return nil, nil
+	}
+	if g.Phase != Judging {
+		return nil, ErrNotJudging
+	}
+	if CzarID != g.Czar {
+		return nil, ErrNotCzar
+	}
+	if Choice < 0 || Choice >= len(g.Answers()) {
+		return nil, ErrInvalidChoice
+	}
+	if g.Settings.Judging == RankedJudging {
+		return g.rank(Choice)
+	}
+	return g.chooseAnswer(Choice, g.Settings.PickWorst, false), nil
+}
+
+// chooseAnswer gives the answer at Choice in Answers an Awesome Point.  If AskWorst is true, the czar is asked for
+// the worst answer next.  Random is whether the answer was picked at random instead of by the czar.
+func (g *Game) chooseAnswer(Choice int, AskWorst, Random bool) []Event {
+	answers := g.Answers()
+	winner := g.answerer(answers[Choice].PlayerID)
+	final := g.InFinalRound()
+	events := []Event{AnswerChosen{winner, answers[Choice].Text, Random, final}}
+	if champion := g.award([]*Player{winner}, []int{1}); champion != nil {
+		return append(events, g.win(champion)...)
+	}
+	if AskWorst && !final && len(answers) > 1 {
+		g.Best = winner.ID
+		g.Order = append(g.Order[:Choice], g.Order[Choice+1:]...)
+		g.Phase = JudgingWorst
+		g.setDeadline(g.Settings.CzarMinutes)
+		return append(events, WorstRequested{g.CzarPlayer(), g.Answers()})
+	}
+	return append(events, g.endRound()...)
+}
+
+// ChooseWorst is the czar choosing the worst answer in Round, which costs that player an Awesome Point.
+// Choice is the position of the answer in Answers, which no longer has the best answer.  Choosing again does nothing.
+func (g *Game) ChooseWorst(CzarID, Round, Choice int) ([]Event, error) {
+	if Round != g.Round {
+		return nil, ErrOldRound
+	}
+	if g.Phase == RoundOver {
+		return nil, nil
+	}
+	if g.Phase != JudgingWorst {
+		return nil, ErrNotJudging
+	}
+	if CzarID != g.Czar {
+		return nil, ErrNotCzar
+	}
+	answers := g.Answers()
+	if Choice < 0 || Choice >= len(answers) {
+		return nil, ErrInvalidChoice
+	}
+	loser := g.answerer(answers[Choice].PlayerID)
+	g.score(loser, -1)
+	chosen := WorstChosen{Worst: loser, WorstAnswer: answers[Choice].Text}
+	if best := g.answerer(g.Best); best != nil {
+		chosen.Best, chosen.BestAnswer = best, best.Answer
+	}
+	return append([]Event{chosen}, g.endRound()...), nil
+}
+
+// End stops the game.
+func (g *Game) End(StoppedBy string) []Event {
+	g.Phase = Finished
+	return []Event{GameEnded{StoppedBy: StoppedBy, Scores: g.Scores()}}
+}
+
+// endRound discards the played cards and passes the czar on to the next player.
+// If trade-ins are on, the players are offered to trade in cards.  The game ends after its final round.
+func (g *Game) endRound() []Event {
+	for _, p := range g.Players {
+		g.Discards = append(g.Discards, p.Played...)
+		p.Played, p.Written, p.Answer, p.WritingIn, p.Vote = nil, nil, "", 0, 0
+		p.Trading, p.Traded = nil, false
+	}
+	if g.Mystery != nil {
+		g.Discards = append(g.Discards, g.Mystery.Played...)
+		g.Mystery.Played, g.Mystery.Answer = nil, ""
+	}
+	g.Question = -1
+	g.Order = nil
+	g.Best = 0
+	g.StandIn = false
+	g.Eliminated, g.Turn = nil, 0
+	g.Ranked = nil
+	g.setDeadline(0)
+	events := g.hooks(func(r HouseRule) []Event { return r.RoundEnded(g) })
+	if g.InFinalRound() {
+		g.Phase = Finished
+		return append(events, GameEnded{Winner: g.leader(), Scores: g.Scores()})
+	}
+	if !g.Settings.Czarless {
+		g.Czar = g.nextCzar()
+	}
+	g.Phase = RoundOver
+	events = append(events, RoundEnded{g.CzarPlayer()})
+	if g.Settings.TradeInCards && len(g.Players) != 0 {
+		events = append(events, TradesOffered{g.Players})
+	}
+	return events
+}
+
+// checkAllAnswered moves the round to judging if every player has answered.
+func (g *Game) checkAllAnswered() []Event {
+	for _, p := range g.Players {
+		if p.ID != g.Czar && !g.answered(p) {
+			return nil
+		}
+	}
+	return g.startJudging()
+}
+
+// startJudging shows the czar the answers of the players that answered.  Without a czar, the players vote on them
+// instead, and in Survival of the Fittest they take turns eliminating them.  If there is only one answer, it wins
+// without a vote or eliminations.
+func (g *Game) startJudging() []Event {
+	g.Order = nil
+	for _, p := range g.Players {
+		if p.ID != g.Czar && g.answered(p) {
+			g.Order = append(g.Order, p.ID)
+		}
+	}
+	if g.mysteryAnswered() {
+		g.Order = append(g.Order, MysteryID)
+	}
+	g.Order = g.shuffle(g.Order)
+	events := g.hooks(func(r HouseRule) []Event { return r.Judging(g) })
+	if g.Settings.Judging == SurvivalJudging {
+		if len(g.Order) < 2 {
+			return append(events, g.survived()...)
+		}
+		return append(events, g.startElimination()...)
+	}
+	if g.Settings.Czarless {
+		if len(g.Order) < 2 {
+			return append(events, g.countVotes()...)
+		}
+		return append(events, g.startVote()...)
+	}
+	g.Phase = Judging
+	g.setDeadline(g.Settings.CzarMinutes)
+	return append(events, AnswersCollected{g.CzarPlayer(), g.Answers()})
+}
+
+func (g *Game) answered(p *Player) bool {
+	n := g.QuestionCard().NumAnswers
+	if n < 1 {
+		n = 1
+	}
+	return len(p.Played) >= n
+}
+
+// nextCzar returns the ID of the player after the current czar.
+func (g *Game) nextCzar() int {
+	if len(g.Players) == 0 {
+		return 0
+	}
+	for i, p := range g.Players {
+		if p.ID == g.Czar {
+			return g.Players[(i+1)%len(g.Players)].ID
+		}
+	}
+	return g.Players[0].ID
+}
+
+// drawAnswers draws answer cards, shuffling the discards back in when the pile runs out.
+func (g *Game) drawAnswers(n int) []int {
+	cards := make([]int, 0, n)
+	for len(cards) < n {
+		if len(g.AnswerPile) == 0 {
+			if len(g.Discards) == 0 {
+				break
+			}
+			g.AnswerPile, g.Discards = g.shuffle(g.Discards), nil
+		}
+		ID := g.AnswerPile[len(g.AnswerPile)-1]
+		g.AnswerPile = g.AnswerPile[:len(g.AnswerPile)-1]
+		// A card that was removed from the card data while the game was going is left out of it.
+		if !g.Deck.Answer(ID).Retired {
+			cards = append(cards, ID)
+		}
+	}
+	return cards
+}
+
+// drawQuestion draws a question card, shuffling the questions again when the pile runs out.
+// Retired cards are left out of the game.
+func (g *Game) drawQuestion() int {
+	for {
+		if len(g.QuestionPile) == 0 {
+			g.QuestionPile = g.shuffle(g.questionIDs())
+		}
+		ID := g.QuestionPile[len(g.QuestionPile)-1]
+		g.QuestionPile = g.QuestionPile[:len(g.QuestionPile)-1]
+		if !g.Deck.Question(ID).Retired {
+			return ID
+		}
+	}
+}
+
+func (g *Game) shuffle(arr []int) []int {
+	swap := func(i, j int) { arr[i], arr[j] = arr[j], arr[i] }
+	if g.Rand != nil {
+		g.Rand.Shuffle(len(arr), swap)
+	} else {
+		rand.Shuffle(len(arr), swap)
+	}
+	return arr
+}
+
+func indexOf(arr []int, v int) int {
+	for i := range arr {
+		if arr[i] == v {
+			return i
+		}
+	}
+	return -1
+}
+
+// Clone returns a copy of the game that shares nothing with it but the deck and the random source.
+func (g *Game) Clone() *Game {
+	c := *g
+	c.Players = make([]*Player, len(g.Players))
+	for i, p := range g.Players {
+		cp := *p
+		cp.Hand = append([]int(nil), p.Hand...)
+		cp.Played = append([]int(nil), p.Played...)
+		cp.Written = append([]string(nil), p.Written...)
+		cp.Trading = append([]int(nil), p.Trading...)
+		c.Players[i] = &cp
+	}
+	c.QuestionPile = append([]int(nil), g.QuestionPile...)
+	c.AnswerPile = append([]int(nil), g.AnswerPile...)
+	c.Discards = append([]int(nil), g.Discards...)
+	c.Order = append([]int(nil), g.Order...)
+	c.ExcludedPacks = append([]string(nil), g.ExcludedPacks...)
+	c.HouseRules = append([]string(nil), g.HouseRules...)
+	c.Eliminated = append([]int(nil), g.Eliminated...)
+	c.Ranked = append([]int(nil), g.Ranked...)
+	c.History = append([]Award(nil), g.History...)
+	if g.Mystery != nil {
+		m := *g.Mystery
+		m.Played = append([]int(nil), g.Mystery.Played...)
+		c.Mystery = &m
+	}
+	return &c
+}
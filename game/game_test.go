@@ -0,0 +1,388 @@
+package game
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// testDeck builds a deck of five questions with one blank each and a hundred answers.
+func testDeck(t *testing.T) *Deck {
+	t.Helper()
+	p := Pack{Name: "Test"}
+	for ID := 1; ID <= 5; ID++ {
+		p.Questions = append(p.Questions, QuestionCard{ID: ID, Text: "Question " + strconv.Itoa(ID) + ": _.", NumAnswers: 1})
+	}
+	for ID := 101; ID <= 200; ID++ {
+		p.Answers = append(p.Answers, AnswerCard{ID: ID, Text: "Answer " + strconv.Itoa(ID) + "."})
+	}
+	d := new(Deck)
+	if err := d.Add(p); err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+// newGame creates a game in the lobby with Players players, whose IDs and chat IDs are 1, 2 and so on.
+func newGame(t *testing.T, Settings Settings, Players int) *Game {
+	t.Helper()
+	g := New("test", testDeck(t), Settings, rand.New(rand.NewSource(1)))
+	for ID := 1; ID <= Players; ID++ {
+		if _, err := g.AddPlayer(ID, int64(ID), "player"+strconv.Itoa(ID)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return g
+}
+
+// begin begins a game and fails the test if it cannot.
+func begin(t *testing.T, g *Game) []Event {
+	t.Helper()
+	events, err := g.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return events
+}
+
+// answerAll has every player the round is waiting for play the first card in their hand until they have answered.
+func answerAll(t *testing.T, g *Game) []Event {
+	t.Helper()
+	var events []Event
+	for _, p := range g.Waiting() {
+		for g.Phase == CollectingAnswers && !g.answered(p) {
+			e, err := g.PlayCard(p.ID, g.Round, p.Hand[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			events = append(events, e...)
+		}
+	}
+	return events
+}
+
+// answerIndex returns the position in Answers of a player's answer.
+func answerIndex(t *testing.T, g *Game, PlayerID int) int {
+	t.Helper()
+	for i, a := range g.Answers() {
+		if a.PlayerID == PlayerID {
+			return i
+		}
+	}
+	t.Fatalf("player %v has no answer to judge", PlayerID)
+	return -1
+}
+
+// eventOf returns the first event of the same type as Kind, or nil.
+func eventOf(Events []Event, Kind Event) Event {
+	for _, e := range Events {
+		if reflect.TypeOf(e) == reflect.TypeOf(Kind) {
+			return e
+		}
+	}
+	return nil
+}
+
+// testClock is a clock for the deadlines that only moves when it is told to.
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestBeginDealsHands(t *testing.T) {
+	g := newGame(t, DefaultSettings, 3)
+	events := begin(t, g)
+	if g.Phase != CollectingAnswers || g.Round != 1 || g.Czar != 1 {
+		t.Fatalf("got phase %v, round %v and czar %v, want collecting answers, round 1 and czar 1", g.Phase, g.Round, g.Czar)
+	}
+	dealt := make(map[int]bool)
+	for _, p := range g.Players {
+		if len(p.Hand) != DefaultSettings.CardsInHand {
+			t.Errorf("player %v has %v cards, want %v", p.ID, len(p.Hand), DefaultSettings.CardsInHand)
+		}
+		for _, card := range p.Hand {
+			if dealt[card] {
+				t.Errorf("card %v was dealt twice", card)
+			}
+			dealt[card] = true
+		}
+	}
+	started, ok := eventOf(events, RoundStarted{}).(RoundStarted)
+	if !ok {
+		t.Fatalf("got %v, want a RoundStarted event", events)
+	}
+	if started.Czar.ID != 1 || len(started.Waiting) != 2 {
+		t.Errorf("got czar %v waiting for %v players, want czar 1 waiting for 2", started.Czar.ID, len(started.Waiting))
+	}
+	if _, err := g.Begin(); err != ErrAlreadyBegun {
+		t.Errorf("beginning again: got %v, want %v", err, ErrAlreadyBegun)
+	}
+}
+
+func TestBeginNeedsEnoughPlayers(t *testing.T) {
+	g := newGame(t, DefaultSettings, MinPlayers-1)
+	if _, err := g.Begin(); err != ErrNotEnoughPlayers {
+		t.Errorf("got %v, want %v", err, ErrNotEnoughPlayers)
+	}
+}
+
+func TestAddPlayer(t *testing.T) {
+	g := newGame(t, DefaultSettings, MaxPlayers)
+	if _, err := g.AddPlayer(1, 1, "again"); err != ErrAlreadyInGame {
+		t.Errorf("joining twice: got %v, want %v", err, ErrAlreadyInGame)
+	}
+	if _, err := g.AddPlayer(MaxPlayers+1, MaxPlayers+1, "late"); err != ErrGameFull {
+		t.Errorf("joining a full game: got %v, want %v", err, ErrGameFull)
+	}
+	g = newGame(t, DefaultSettings, 3)
+	begin(t, g)
+	if _, err := g.AddPlayer(4, 4, "late"); err != ErrRoundInProgress {
+		t.Errorf("joining during a round: got %v, want %v", err, ErrRoundInProgress)
+	}
+}
+
+func TestPlayCard(t *testing.T) {
+	g := newGame(t, DefaultSettings, 3)
+	begin(t, g)
+	czar, p := g.Player(1), g.Player(2)
+	if _, err := g.PlayCard(czar.ID, g.Round, czar.Hand[0]); err != ErrCzarCannotAnswer {
+		t.Errorf("czar answering: got %v, want %v", err, ErrCzarCannotAnswer)
+	}
+	if _, err := g.PlayCard(p.ID, g.Round, g.Player(3).Hand[0]); err != ErrCardNotInHand {
+		t.Errorf("playing someone else's card: got %v, want %v", err, ErrCardNotInHand)
+	}
+	if _, err := g.PlayCard(p.ID, g.Round-1, p.Hand[0]); err != ErrOldRound {
+		t.Errorf("playing in an old round: got %v, want %v", err, ErrOldRound)
+	}
+	card := p.Hand[0]
+	events, err := g.PlayCard(p.ID, g.Round, card)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if received, ok := eventOf(events, AnswerReceived{}).(AnswerReceived); !ok || !received.Complete {
+		t.Errorf("got %v, want a complete AnswerReceived event", events)
+	}
+	if want := "Question " + strconv.Itoa(g.Question) + ": Answer " + strconv.Itoa(card) + "."; p.Answer != want {
+		t.Errorf("got answer %q, want %q", p.Answer, want)
+	}
+	if len(p.Hand) != DefaultSettings.CardsInHand {
+		t.Errorf("got %v cards after answering, want the hand filled back up to %v", len(p.Hand), DefaultSettings.CardsInHand)
+	}
+	if events, err := g.PlayCard(p.ID, g.Round, card); err != nil || len(events) != 0 {
+		t.Errorf("playing the same card again: got %v and %v, want nothing", events, err)
+	}
+	if _, err := g.PlayCard(p.ID, g.Round, p.Hand[0]); err != ErrAlreadyAnswered {
+		t.Errorf("answering twice: got %v, want %v", err, ErrAlreadyAnswered)
+	}
+	events = answerAll(t, g)
+	collected, ok := eventOf(events, AnswersCollected{}).(AnswersCollected)
+	if !ok || g.Phase != Judging {
+		t.Fatalf("got %v in phase %v, want the answers collected for judging", events, g.Phase)
+	}
+	if collected.Czar != czar || len(collected.Answers) != 2 {
+		t.Errorf("got %v answers for czar %v, want 2 for czar 1", len(collected.Answers), collected.Czar.ID)
+	}
+}
+
+func TestQuestionWithTwoBlanks(t *testing.T) {
+	g := newGame(t, DefaultSettings, 3)
+	if err := g.Deck.Add(Pack{Name: "Test", Questions: []QuestionCard{{ID: 6, Text: "_ and _.", NumAnswers: 2}}}); err != nil {
+		t.Fatal(err)
+	}
+	begin(t, g)
+	answerAll(t, g)
+	if _, err := g.ChooseAnswer(1, g.Round, 0); err != nil {
+		t.Fatal(err)
+	}
+	g.QuestionPile = []int{6}
+	if _, err := g.StartRound(); err != nil {
+		t.Fatal(err)
+	}
+	p := g.Player(3)
+	if len(p.Hand) != DefaultSettings.CardsInHand+1 {
+		t.Fatalf("got %v cards, want an extra card for the second blank", len(p.Hand))
+	}
+	first, second := p.Hand[0], p.Hand[1]
+	events, err := g.PlayCard(p.ID, g.Round, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if received, ok := eventOf(events, AnswerReceived{}).(AnswerReceived); !ok || received.Complete {
+		t.Errorf("got %v, want an AnswerReceived event that is not complete", events)
+	}
+	if _, err := g.PlayCard(p.ID, g.Round, second); err != nil {
+		t.Fatal(err)
+	}
+	want := "Answer " + strconv.Itoa(first) + " and Answer " + strconv.Itoa(second) + "."
+	if p.Answer != want {
+		t.Errorf("got answer %q, want %q", p.Answer, want)
+	}
+}
+
+func TestChooseAnswer(t *testing.T) {
+	g := newGame(t, DefaultSettings, 3)
+	begin(t, g)
+	answerAll(t, g)
+	if _, err := g.ChooseAnswer(2, g.Round, 0); err != ErrNotCzar {
+		t.Errorf("choosing as a player: got %v, want %v", err, ErrNotCzar)
+	}
+	if _, err := g.ChooseAnswer(1, g.Round, 2); err != ErrInvalidChoice {
+		t.Errorf("choosing an answer that is not there: got %v, want %v", err, ErrInvalidChoice)
+	}
+	winner := g.Answers()[0].PlayerID
+	events, err := g.ChooseAnswer(1, g.Round, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chosen, ok := eventOf(events, AnswerChosen{}).(AnswerChosen)
+	if !ok || chosen.Player.ID != winner || chosen.Random {
+		t.Fatalf("got %v, want player %v's answer chosen by the czar", events, winner)
+	}
+	if g.Player(winner).Points != 1 {
+		t.Errorf("the winner has %v Awesome Points, want 1", g.Player(winner).Points)
+	}
+	if g.Phase != RoundOver || g.Czar != 2 {
+		t.Errorf("got phase %v and czar %v, want round over and czar 2", g.Phase, g.Czar)
+	}
+	if want := []Award{{1, winner, 1}}; !reflect.DeepEqual(g.History, want) {
+		t.Errorf("got history %v, want %v", g.History, want)
+	}
+	for _, p := range g.Players {
+		if len(p.Played) != 0 || p.Answer != "" {
+			t.Errorf("player %v still has their answer after the round", p.ID)
+		}
+	}
+	if events, err := g.ChooseAnswer(1, g.Round, 0); err != nil || len(events) != 0 {
+		t.Errorf("choosing again: got %v and %v, want nothing", events, err)
+	}
+}
+
+func TestCzarRotates(t *testing.T) {
+	g := newGame(t, DefaultSettings, 3)
+	begin(t, g)
+	for _, want := range []int{1, 2, 3, 1} {
+		if g.Czar != want {
+			t.Fatalf("round %v: got czar %v, want %v", g.Round, g.Czar, want)
+		}
+		answerAll(t, g)
+		if _, err := g.ChooseAnswer(g.Czar, g.Round, 0); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := g.StartRound(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestWinningEndsTheGame(t *testing.T) {
+	g := newGame(t, Settings{CardsInHand: 7, PointsToWin: 1}, 3)
+	begin(t, g)
+	answerAll(t, g)
+	winner := g.Answers()[0].PlayerID
+	events, err := g.ChooseAnswer(1, g.Round, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ended, ok := eventOf(events, GameEnded{}).(GameEnded)
+	if !ok || ended.Winner == nil || ended.Winner.ID != winner {
+		t.Fatalf("got %v, want the game won by player %v", events, winner)
+	}
+	if g.Phase != Finished {
+		t.Errorf("got phase %v, want finished", g.Phase)
+	}
+	if _, err := g.StartRound(); err != ErrGameOver {
+		t.Errorf("starting a round after the game: got %v, want %v", err, ErrGameOver)
+	}
+}
+
+func TestPickWorst(t *testing.T) {
+	g := newGame(t, Settings{CardsInHand: 7, PointsToWin: 7, PickWorst: true}, 4)
+	begin(t, g)
+	answerAll(t, g)
+	best := g.Answers()[0].PlayerID
+	events, err := g.ChooseAnswer(1, g.Round, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	requested, ok := eventOf(events, WorstRequested{}).(WorstRequested)
+	if !ok || g.Phase != JudgingWorst {
+		t.Fatalf("got %v in phase %v, want the czar asked for the worst answer", events, g.Phase)
+	}
+	for _, a := range requested.Answers {
+		if a.PlayerID == best {
+			t.Errorf("the best answer can be picked as the worst")
+		}
+	}
+	if len(requested.Answers) != 2 {
+		t.Errorf("got %v answers to pick the worst from, want 2", len(requested.Answers))
+	}
+	worst := g.Answers()[0].PlayerID
+	events, err = g.ChooseWorst(1, g.Round, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chosen, ok := eventOf(events, WorstChosen{}).(WorstChosen)
+	if !ok || chosen.Best.ID != best || chosen.Worst.ID != worst {
+		t.Fatalf("got %v, want player %v's answer as the best and player %v's as the worst", events, best, worst)
+	}
+	if g.Player(best).Points != 1 || g.Player(worst).Points != -1 {
+		t.Errorf("got %v and %v Awesome Points, want 1 for the best and -1 for the worst", g.Player(best).Points, g.Player(worst).Points)
+	}
+	if g.Phase != RoundOver {
+		t.Errorf("got phase %v, want round over", g.Phase)
+	}
+}
+
+func TestRemovePlayer(t *testing.T) {
+	g := newGame(t, DefaultSettings, 4)
+	begin(t, g)
+	next := g.Player(2)
+	card := next.Hand[0]
+	if _, err := g.PlayCard(next.ID, g.Round, card); err != nil {
+		t.Fatal(err)
+	}
+	events, err := g.RemovePlayer(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eventOf(events, PlayerLeft{}) == nil {
+		t.Errorf("got %v, want a PlayerLeft event", events)
+	}
+	if g.Czar != 2 {
+		t.Fatalf("got czar %v after the czar left, want 2", g.Czar)
+	}
+	if len(next.Played) != 0 || indexOf(next.Hand, card) == -1 {
+		t.Errorf("the new czar's answer did not go back in their hand")
+	}
+	if _, err := g.RemovePlayer(1); err != ErrNotInGame {
+		t.Errorf("leaving twice: got %v, want %v", err, ErrNotInGame)
+	}
+	g.RemovePlayer(3)
+	g.RemovePlayer(4)
+	if g.Phase != RoundOver {
+		t.Errorf("got phase %v with one player left, want the round over", g.Phase)
+	}
+	if events, _ := g.RemovePlayer(2); eventOf(events, GameEnded{}) == nil || g.Phase != Finished {
+		t.Errorf("got %v in phase %v when the last player left, want the game ended", events, g.Phase)
+	}
+}
+
+func TestCloneSharesNothing(t *testing.T) {
+	g := newGame(t, DefaultSettings, 3)
+	begin(t, g)
+	c := g.Clone()
+	c.Player(2).Hand[0] = 0
+	c.AnswerPile[0] = 0
+	if g.Player(2).Hand[0] == 0 || g.AnswerPile[0] == 0 {
+		t.Errorf("changing the clone changed the game")
+	}
+}
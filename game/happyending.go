@@ -0,0 +1,47 @@
+package game
+
+// HappyEnding is the name of the house rule that ends the game with a haiku.
+const HappyEnding = "Happy Ending"
+
+// HaikuID is the ID of the question card of the final round of Happy Ending.  It is not in any deck, so that the
+// round can be played whatever packs are included.
+const HaikuID = -2
+
+// HaikuCard is the question card of the final round of Happy Ending.
+var HaikuCard = QuestionCard{ID: HaikuID, Text: "Make a haiku.", NumAnswers: 3, Expansion: HappyEnding}
+
+// happyEnding plays one last round, in which everyone makes a haiku, when someone wins.
+// Nobody scores in that round, and the game ends after it.
+type happyEnding struct {
+	NoHooks
+}
+
+func init() {
+	RegisterHouseRule(happyEnding{})
+}
+
+func (happyEnding) Name() string {
+	return HappyEnding
+}
+
+func (happyEnding) Description() string {
+	return "When someone wins, the game ends with one last round, for fun: everyone makes a haiku."
+}
+
+// RoundStarted puts the question that was drawn back for the haiku in the final round.
+func (happyEnding) RoundStarted(g *Game) []Event {
+	if g.Round == g.FinalRound {
+		g.QuestionPile = append(g.QuestionPile, g.Question)
+		g.Question = HaikuID
+	}
+	return nil
+}
+
+// GameEnding keeps the game going for the final round, if there is one to play.
+func (happyEnding) GameEnding(g *Game, Winner *Player) ([]Event, bool) {
+	if g.FinalRound != 0 || len(g.Players) < MinPlayers {
+		return nil, false
+	}
+	g.FinalRound = g.Round + 1
+	return []Event{FinalRoundAhead{Winner, HappyEnding}}, true
+}
@@ -0,0 +1,136 @@
+package game
+
+import "sort"
+
+// HouseRule changes how the rounds of a game are played, for the games that turn it on.
+// The game calls its hooks at points in a round.  A hook can change the game and returns the events the players
+// should hear about.  Rules embed NoHooks, so that they only need the hooks they use.
+type HouseRule interface {
+	// Name is the name the rule is registered by and shown to the players with.
+	Name() string
+	// Description says what the rule does.
+	Description() string
+	// RoundStarted is called when a round starts, after its question is drawn and before the players are dealt the
+	// cards they need to answer it.
+	RoundStarted(g *Game) []Event
+	// AnswerSubmitted is called when a player has played every card their answer needs.
+	AnswerSubmitted(g *Game, p *Player) []Event
+	// Judging is called when the answers are in, before they are judged.
+	Judging(g *Game) []Event
+	// RoundEnded is called when a round is over, before the czar passes on.
+	RoundEnded(g *Game) []Event
+	// GameEnding is called when a player has won.  If it returns true, the game goes on and only the round ends.
+	GameEnding(g *Game, Winner *Player) ([]Event, bool)
+}
+
+// NoHooks is a HouseRule's hooks that do nothing.
+type NoHooks struct{}
+
+// RoundStarted does nothing.
+func (NoHooks) RoundStarted(g *Game) []Event { return nil }
+
+// AnswerSubmitted does nothing.
+func (NoHooks) AnswerSubmitted(g *Game, p *Player) []Event { return nil }
+
+// Judging does nothing.
+func (NoHooks) Judging(g *Game) []Event { return nil }
+
+// RoundEnded does nothing.
+func (NoHooks) RoundEnded(g *Game) []Event { return nil }
+
+// GameEnding lets the game end.
+func (NoHooks) GameEnding(g *Game, Winner *Player) ([]Event, bool) { return nil, false }
+
+var houseRules = make(map[string]HouseRule)
+
+// RegisterHouseRule makes a house rule available to games by its name.  It panics if the name is taken.
+func RegisterHouseRule(Rule HouseRule) {
+	if _, ok := houseRules[Rule.Name()]; ok {
+		panic("game: two house rules are named " + Rule.Name())
+	}
+	houseRules[Rule.Name()] = Rule
+}
+
+// HouseRules returns the registered house rules, sorted by name.
+func HouseRules() []HouseRule {
+	rules := make([]HouseRule, 0, len(houseRules))
+	for _, r := range houseRules {
+		rules = append(rules, r)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name() < rules[j].Name() })
+	return rules
+}
+
+// HouseRuleOn reports whether the house rule with the given name is turned on in the game.
+func (g *Game) HouseRuleOn(Name string) bool {
+	for _, on := range g.HouseRules {
+		if on == Name {
+			return true
+		}
+	}
+	return false
+}
+
+// ToggleHouseRule turns a house rule on if it is off, and off if it is on.
+// Only the player changing the settings can do this.
+func (g *Game) ToggleHouseRule(PlayerID int, Name string) ([]Event, error) {
+	p, err := g.settingsEditor(PlayerID, true)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := houseRules[Name]; !ok {
+		return nil, ErrUnknownHouseRule
+	}
+	for i := range g.HouseRules {
+		if g.HouseRules[i] == Name {
+			g.HouseRules = append(g.HouseRules[:i], g.HouseRules[i+1:]...)
+			return []Event{HouseRuleToggled{p, Name, false}}, nil
+		}
+	}
+	g.HouseRules = append(g.HouseRules, Name)
+	return []Event{HouseRuleToggled{p, Name, true}}, nil
+}
+
+// hooks calls a hook of every house rule that is on, in the order they were turned on, and returns their events.
+// Rules that are no longer registered are skipped.
+func (g *Game) hooks(Hook func(HouseRule) []Event) []Event {
+	var events []Event
+	for _, name := range g.HouseRules {
+		if r, ok := houseRules[name]; ok {
+			events = append(events, Hook(r)...)
+		}
+	}
+	return events
+}
+
+// win ends the game with Winner as the winner, unless a house rule keeps it going.  Then only the round ends.
+func (g *Game) win(Winner *Player) []Event {
+	for _, name := range g.HouseRules {
+		if r, ok := houseRules[name]; ok {
+			if events, more := r.GameEnding(g, Winner); more {
+				return append(events, g.endRound()...)
+			}
+		}
+	}
+	g.Phase = Finished
+	return []Event{GameEnded{Winner: Winner, Scores: g.Scores()}}
+}
+
+// InFinalRound reports whether the round being played is the last one, which is played for fun after someone won.
+func (g *Game) InFinalRound() bool {
+	return g.FinalRound != 0 && g.Round >= g.FinalRound
+}
+
+// leader returns the player, or the mystery player, with the most Awesome Points.
+func (g *Game) leader() *Player {
+	var leader *Player
+	for _, p := range g.Players {
+		if leader == nil || p.Points > leader.Points {
+			leader = p
+		}
+	}
+	if g.Mystery != nil && (leader == nil || g.Mystery.Points > leader.Points) {
+		leader = g.Mystery
+	}
+	return leader
+}
@@ -0,0 +1,124 @@
+package game
+
+import "testing"
+
+// turnOn turns house rules on as player 1, in the lobby.
+func turnOn(t *testing.T, g *Game, Rules ...string) {
+	t.Helper()
+	if _, err := g.EditSettings(1); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range Rules {
+		if _, err := g.ToggleHouseRule(1, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := g.DoneEditingSettings(1); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestToggleHouseRule(t *testing.T) {
+	g := newGame(t, DefaultSettings, 3)
+	if _, err := g.ToggleHouseRule(1, HappyEnding); err != ErrNotEditing {
+		t.Errorf("toggling without editing the settings: got %v, want %v", err, ErrNotEditing)
+	}
+	g.EditSettings(1)
+	if _, err := g.ToggleHouseRule(1, "No Such Rule"); err != ErrUnknownHouseRule {
+		t.Errorf("toggling an unknown rule: got %v, want %v", err, ErrUnknownHouseRule)
+	}
+	events, err := g.ToggleHouseRule(1, HappyEnding)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if toggled, ok := eventOf(events, HouseRuleToggled{}).(HouseRuleToggled); !ok || !toggled.On || !g.HouseRuleOn(HappyEnding) {
+		t.Fatalf("got %v, want %v turned on", events, HappyEnding)
+	}
+	events, _ = g.ToggleHouseRule(1, HappyEnding)
+	if toggled, ok := eventOf(events, HouseRuleToggled{}).(HouseRuleToggled); !ok || toggled.On || g.HouseRuleOn(HappyEnding) {
+		t.Errorf("got %v, want %v turned off", events, HappyEnding)
+	}
+	names := make(map[string]bool)
+	for _, r := range HouseRules() {
+		names[r.Name()] = true
+	}
+	if !names[HappyEnding] || !names[RebootingTheUniverse] {
+		t.Errorf("got house rules %v, want %v and %v registered", names, HappyEnding, RebootingTheUniverse)
+	}
+}
+
+func TestHappyEnding(t *testing.T) {
+	g := newGame(t, Settings{CardsInHand: 7, PointsToWin: 1}, 3)
+	turnOn(t, g, HappyEnding)
+	begin(t, g)
+	answerAll(t, g)
+	winner := g.Player(g.Answers()[0].PlayerID)
+	events, err := g.ChooseAnswer(1, g.Round, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ahead, ok := eventOf(events, FinalRoundAhead{}).(FinalRoundAhead); !ok || ahead.Winner != winner {
+		t.Fatalf("got %v, want a final round ahead for player %v's win", events, winner.ID)
+	}
+	if g.Phase != RoundOver || g.FinalRound != 2 {
+		t.Fatalf("got phase %v and final round %v, want the game to go on to round 2", g.Phase, g.FinalRound)
+	}
+	if _, err := g.StartRound(); err != nil {
+		t.Fatal(err)
+	}
+	if g.Question != HaikuID {
+		t.Fatalf("got question %v in the final round, want the haiku", g.Question)
+	}
+	for _, p := range g.Waiting() {
+		if len(p.Hand) != 9 {
+			t.Errorf("player %v has %v cards, want 2 extra for the haiku", p.ID, len(p.Hand))
+		}
+	}
+	answerAll(t, g)
+	events, err = g.ChooseAnswer(g.Czar, g.Round, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chosen, ok := eventOf(events, AnswerChosen{}).(AnswerChosen); !ok || !chosen.ForFun {
+		t.Errorf("got %v, want the haiku chosen for fun", events)
+	}
+	ended, ok := eventOf(events, GameEnded{}).(GameEnded)
+	if !ok || ended.Winner != winner || g.Phase != Finished {
+		t.Fatalf("got %v, want the game won by player %v", events, winner.ID)
+	}
+	for _, p := range g.Players {
+		if p != winner && p.Points != 0 {
+			t.Errorf("player %v scored in the final round", p.ID)
+		}
+	}
+}
+
+func TestRebootingTheUniverse(t *testing.T) {
+	g := newGame(t, DefaultSettings, 3)
+	if _, err := g.RebootUniverse(2); err != ErrHouseRuleOff {
+		t.Errorf("rebooting with the rule off: got %v, want %v", err, ErrHouseRuleOff)
+	}
+	turnOn(t, g, RebootingTheUniverse)
+	begin(t, g)
+	p := g.Player(2)
+	if _, err := g.RebootUniverse(p.ID); err != ErrNoPoints {
+		t.Errorf("rebooting without points: got %v, want %v", err, ErrNoPoints)
+	}
+	p.Points = 1
+	old := append([]int(nil), p.Hand...)
+	events, err := g.RebootUniverse(p.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eventOf(events, UniverseRebooted{}) == nil || p.Points != 0 || len(p.Hand) != 7 {
+		t.Fatalf("got %v with %v points and %v cards, want a new hand of 7 for a point", events, p.Points, len(p.Hand))
+	}
+	for _, card := range old {
+		if indexOf(p.Hand, card) != -1 {
+			t.Errorf("card %v from the old hand is still in the new one", card)
+		}
+	}
+	if want := (Award{1, p.ID, -1}); len(g.History) != 1 || g.History[0] != want {
+		t.Errorf("got history %v, want %v", g.History, []Award{want})
+	}
+}
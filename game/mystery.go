@@ -0,0 +1,53 @@
+package game
+
+// The mystery player plays a random card every round when Settings.MysteryPlayer is on.
+// They are not in Players: they never judge, hold no hand and cannot leave, but they can win.
+const (
+	MysteryID   = -1
+	MysteryName = "Jose"
+)
+
+// playMystery has the mystery player answer the question with cards drawn from the answer pile.
+func (g *Game) playMystery(Question QuestionCard) {
+	if !g.Settings.MysteryPlayer {
+		return
+	}
+	if g.Mystery == nil {
+		g.Mystery = &Player{ID: MysteryID, Name: MysteryName}
+	}
+	n := Question.NumAnswers
+	if n < 1 {
+		n = 1
+	}
+	// The mystery player cannot write on blank cards, so they put them back and draw again.
+	g.Mystery.Played = nil
+	for tries := len(g.AnswerPile) + len(g.Discards); len(g.Mystery.Played) < n && tries >= 0; tries-- {
+		cards := g.drawAnswers(1)
+		if len(cards) == 0 {
+			break
+		}
+		if IsBlankCard(cards[0]) {
+			g.Discards = append(g.Discards, cards[0])
+			continue
+		}
+		g.Mystery.Played = append(g.Mystery.Played, cards[0])
+	}
+	played := make([]AnswerCard, len(g.Mystery.Played))
+	for i, ID := range g.Mystery.Played {
+		played[i] = g.Deck.Answer(ID)
+	}
+	g.Mystery.Answer = FillBlanks(Question, played)
+}
+
+// mysteryAnswered reports whether the mystery player has an answer to be judged this round.
+func (g *Game) mysteryAnswered() bool {
+	return g.Settings.MysteryPlayer && g.Mystery != nil && len(g.Mystery.Played) != 0
+}
+
+// answerer returns the player with the given ID, or the mystery player for MysteryID.
+func (g *Game) answerer(ID int) *Player {
+	if ID == MysteryID {
+		return g.Mystery
+	}
+	return g.Player(ID)
+}
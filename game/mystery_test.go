@@ -0,0 +1,49 @@
+package game
+
+import "testing"
+
+func TestMysteryPlayerAnswersAndCanWin(t *testing.T) {
+	g := newGame(t, Settings{CardsInHand: 7, PointsToWin: 7, MysteryPlayer: true}, 3)
+	begin(t, g)
+	if g.Mystery == nil || len(g.Mystery.Played) != 1 || g.Mystery.Answer == "" {
+		t.Fatalf("the mystery player did not answer")
+	}
+	if g.Player(MysteryID) != nil {
+		t.Errorf("the mystery player is in Players")
+	}
+	answerAll(t, g)
+	if len(g.Answers()) != 3 {
+		t.Fatalf("got %v answers, want the mystery player's as well as the 2 players'", len(g.Answers()))
+	}
+	events, err := g.ChooseAnswer(1, g.Round, answerIndex(t, g, MysteryID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chosen, ok := eventOf(events, AnswerChosen{}).(AnswerChosen); !ok || chosen.Player != g.Mystery {
+		t.Fatalf("got %v, want the mystery player's answer chosen", events)
+	}
+	scores := g.Scores()
+	if last := scores[len(scores)-1]; last != (Score{MysteryName, 1}) {
+		t.Errorf("got %v, want %v with 1 Awesome Point last in the scores", last, MysteryName)
+	}
+	if len(g.Mystery.Played) != 0 {
+		t.Errorf("the mystery player's cards were not discarded after the round")
+	}
+}
+
+func TestMysteryPlayerSkipsBlankCards(t *testing.T) {
+	g := newGame(t, Settings{CardsInHand: 7, PointsToWin: 7, MysteryPlayer: true, BlankCards: 50}, 3)
+	for round := 0; round < 5; round++ {
+		if round == 0 {
+			begin(t, g)
+		} else if _, err := g.StartRound(); err != nil {
+			t.Fatal(err)
+		}
+		for _, card := range g.Mystery.Played {
+			if IsBlankCard(card) {
+				t.Fatalf("the mystery player played a blank card")
+			}
+		}
+		g.endRound()
+	}
+}
@@ -0,0 +1,76 @@
+package game
+
+// PackEnabled reports whether the cards of an expansion pack are played with in the game.
+func (g *Game) PackEnabled(Name string) bool {
+	for _, excluded := range g.ExcludedPacks {
+		if excluded == Name {
+			return false
+		}
+	}
+	return true
+}
+
+// TogglePack includes an expansion pack in the game if it is excluded, and excludes it if it is included.
+// The packs can only be changed before the game begins.
+func (g *Game) TogglePack(PlayerID int, Name string) ([]Event, error) {
+	if g.Phase != Lobby {
+		return nil, ErrAlreadyBegun
+	}
+	p := g.Player(PlayerID)
+	if p == nil {
+		return nil, ErrNotInGame
+	}
+	found := false
+	for _, e := range g.Deck.Expansions() {
+		found = found || e.Name == Name
+	}
+	if !found {
+		return nil, ErrUnknownPack
+	}
+	if g.PackEnabled(Name) {
+		g.ExcludedPacks = append(g.ExcludedPacks, Name)
+		return []Event{PackToggled{p, Name, false}}, nil
+	}
+	for i := range g.ExcludedPacks {
+		if g.ExcludedPacks[i] == Name {
+			g.ExcludedPacks = append(g.ExcludedPacks[:i], g.ExcludedPacks[i+1:]...)
+			break
+		}
+	}
+	return []Event{PackToggled{p, Name, true}}, nil
+}
+
+// questionIDs returns the IDs of the question cards in the enabled packs.
+func (g *Game) questionIDs() []int {
+	var IDs []int
+	for _, q := range g.Deck.Questions {
+		if !q.Retired && g.PackEnabled(q.Expansion) {
+			IDs = append(IDs, q.ID)
+		}
+	}
+	return IDs
+}
+
+// answerIDs returns the IDs of the answer cards in the enabled packs, and of the blank cards.
+func (g *Game) answerIDs() []int {
+	IDs := g.blankIDs()
+	for _, a := range g.Deck.Answers {
+		if !a.Retired && g.PackEnabled(a.Expansion) {
+			IDs = append(IDs, a.ID)
+		}
+	}
+	return IDs
+}
+
+// dealFromPacks rebuilds the draw piles from the enabled packs and deals everyone a new hand.
+func (g *Game) dealFromPacks() error {
+	questions, answers := g.questionIDs(), g.answerIDs()
+	if len(questions) == 0 || len(answers) < len(g.Players)*g.Settings.CardsInHand {
+		return ErrNotEnoughCards
+	}
+	g.QuestionPile, g.AnswerPile, g.Discards = g.shuffle(questions), g.shuffle(answers), nil
+	for _, p := range g.Players {
+		p.Hand = g.drawAnswers(g.Settings.CardsInHand)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package game
+
+// In Serious Business (Settings.Judging is RankedJudging), the czar ranks the best answers instead of picking one.
+// They pick the answer in first, second and third place in turn, and the places get PlacePoints.  If there are
+// fewer answers than places, only that many are ranked.  The czar is not asked for the worst answer.
+
+// PlacePoints are the Awesome Points for each place in Serious Business, from first place down.
+var PlacePoints = []int{3, 2, 1}
+
+// Place is an answer the czar ranked in Serious Business, and the Awesome Points it got for it.
+type Place struct {
+	Player *Player
+	Answer string
+	Points int
+}
+
+// Places returns how many answers the czar ranks this round.
+func (g *Game) Places() int {
+	if len(g.Order) < len(PlacePoints) {
+		return len(g.Order)
+	}
+	return len(PlacePoints)
+}
+
+// rank puts the answer at Choice in Answers in the next place.  Once every place is taken, the answers are scored.
+func (g *Game) rank(Choice int) ([]Event, error) {
+	answer := g.Answers()[Choice]
+	if indexOf(g.Ranked, answer.PlayerID) != -1 {
+		return nil, ErrAlreadyRanked
+	}
+	g.Ranked = append(g.Ranked, answer.PlayerID)
+	if len(g.Ranked) < g.Places() {
+		return nil, nil
+	}
+	return g.scoreRanking(false), nil
+}
+
+// rankAtRandom fills the places the czar did not take with answers picked at random, and scores the answers.
+func (g *Game) rankAtRandom() []Event {
+	var unranked []int
+	for _, ID := range g.Order {
+		if indexOf(g.Ranked, ID) == -1 {
+			unranked = append(unranked, ID)
+		}
+	}
+	unranked = g.shuffle(unranked)
+	g.Ranked = append(g.Ranked, unranked[:g.Places()-len(g.Ranked)]...)
+	return g.scoreRanking(true)
+}
+
+// scoreRanking gives the ranked answers the points for their places, all at once, and ends the round, or the game
+// if one of them won it.  Random is whether some of the places were picked at random.
+func (g *Game) scoreRanking(Random bool) []Event {
+	ranked := AnswersRanked{Czar: g.CzarPlayer(), Random: Random, ForFun: g.InFinalRound()}
+	var winners []*Player
+	for i, ID := range g.Ranked {
+		p := g.answerer(ID)
+		if p == nil {
+			continue
+		}
+		winners = append(winners, p)
+		ranked.Places = append(ranked.Places, Place{p, p.Answer, PlacePoints[i]})
+	}
+	points := make([]int, len(ranked.Places))
+	for i, place := range ranked.Places {
+		points[i] = place.Points
+	}
+	events := []Event{ranked}
+	if champion := g.award(winners, points); champion != nil {
+		return append(events, g.win(champion)...)
+	}
+	return append(events, g.endRound()...)
+}
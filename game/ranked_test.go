@@ -0,0 +1,121 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+// rankedGame plays a round of Serious Business up to the judging.
+func rankedGame(t *testing.T, Settings Settings, Players int) *Game {
+	t.Helper()
+	Settings.Judging = RankedJudging
+	g := newGame(t, Settings, Players)
+	begin(t, g)
+	answerAll(t, g)
+	if g.Phase != Judging {
+		t.Fatalf("got phase %v, want judging", g.Phase)
+	}
+	return g
+}
+
+// rank has the czar put Player's answer in the next place and fails the test if they cannot.
+func rank(t *testing.T, g *Game, Player int) []Event {
+	t.Helper()
+	events, err := g.ChooseAnswer(g.Czar, g.Round, answerIndex(t, g, Player))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return events
+}
+
+func TestRankedPlaces(t *testing.T) {
+	g := rankedGame(t, DefaultSettings, 5)
+	if g.Places() != 3 {
+		t.Fatalf("got %v places for 4 answers, want 3", g.Places())
+	}
+	if events := rank(t, g, 3); len(events) != 0 || g.Phase != Judging {
+		t.Fatalf("got %v in phase %v, want the czar to rank the next place", events, g.Phase)
+	}
+	if _, err := g.ChooseAnswer(1, g.Round, answerIndex(t, g, 3)); err != ErrAlreadyRanked {
+		t.Errorf("ranking an answer twice: got %v, want %v", err, ErrAlreadyRanked)
+	}
+	rank(t, g, 5)
+	events := rank(t, g, 2)
+	ranked, ok := eventOf(events, AnswersRanked{}).(AnswersRanked)
+	if !ok || ranked.Random || len(ranked.Places) != 3 {
+		t.Fatalf("got %v, want 3 places ranked by the czar", events)
+	}
+	for i, want := range []struct{ Player, Points int }{{3, 3}, {5, 2}, {2, 1}} {
+		if place := ranked.Places[i]; place.Player.ID != want.Player || place.Points != want.Points || place.Player.Points != want.Points {
+			t.Errorf("place %v: got player %v with %v Awesome Points, want player %v with %v", i+1, place.Player.ID, place.Points, want.Player, want.Points)
+		}
+	}
+	if g.Player(4).Points != 0 || g.Phase != RoundOver || len(g.Ranked) != 0 {
+		t.Errorf("got %v points for the unranked answer in phase %v, want none and the round over", g.Player(4).Points, g.Phase)
+	}
+	if len(g.History) != 3 {
+		t.Errorf("got history %v, want the 3 places scored", g.History)
+	}
+}
+
+func TestRankedPlacesWithFewAnswers(t *testing.T) {
+	g := rankedGame(t, DefaultSettings, 3)
+	if g.Places() != 2 {
+		t.Fatalf("got %v places for 2 answers, want 2", g.Places())
+	}
+	rank(t, g, 2)
+	if ranked, ok := eventOf(rank(t, g, 3), AnswersRanked{}).(AnswersRanked); !ok || len(ranked.Places) != 2 {
+		t.Errorf("got %v, want both answers ranked", ranked)
+	}
+}
+
+func TestRankedTimeoutFillsThePlacesAtRandom(t *testing.T) {
+	g := rankedGame(t, Settings{CardsInHand: 7, PointsToWin: 7, CzarMinutes: 1, CzarTimeout: RandomPick}, 5)
+	rank(t, g, 4)
+	g.Clock = func() time.Time { return g.Deadline }
+	events := g.Tick()
+	ranked, ok := eventOf(events, AnswersRanked{}).(AnswersRanked)
+	if !ok || !ranked.Random || len(ranked.Places) != 3 {
+		t.Fatalf("got %v, want the places filled at random", events)
+	}
+	if ranked.Places[0].Player.ID != 4 {
+		t.Errorf("got player %v in first place, want the czar's pick kept", ranked.Places[0].Player.ID)
+	}
+}
+
+func TestRankedWinnerHasTheMostPoints(t *testing.T) {
+	g := rankedGame(t, Settings{CardsInHand: 7, PointsToWin: 2}, 4)
+	rank(t, g, 3)
+	rank(t, g, 2)
+	events := rank(t, g, 4)
+	ended, ok := eventOf(events, GameEnded{}).(GameEnded)
+	if !ok || ended.Winner.ID != 3 {
+		t.Errorf("got %v, want the game won by player 3, with the most points", events)
+	}
+}
+
+func TestLeavingForgetsHistory(t *testing.T) {
+	g := rankedGame(t, DefaultSettings, 5)
+	rank(t, g, 2)
+	rank(t, g, 3)
+	rank(t, g, 4)
+	g.RemovePlayer(3)
+	for _, a := range g.History {
+		if a.PlayerID == 3 {
+			t.Errorf("got history %v, want player 3's points forgotten", g.History)
+		}
+	}
+}
+
+func TestRankingIsScoredWhenTheRankedAnswersAreAllThatIsLeft(t *testing.T) {
+	g := rankedGame(t, DefaultSettings, 4)
+	rank(t, g, 2)
+	rank(t, g, 3)
+	events, err := g.RemovePlayer(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ranked, ok := eventOf(events, AnswersRanked{}).(AnswersRanked); !ok || len(ranked.Places) != 2 {
+		t.Errorf("got %v, want the 2 ranked answers scored", events)
+	}
+}
@@ -0,0 +1,49 @@
+package game
+
+// RebootingTheUniverse is the name of the house rule that lets players spend an Awesome Point on a new hand.
+const RebootingTheUniverse = "Rebooting the Universe"
+
+// rebootingTheUniverse lets players trade in an Awesome Point to throw away their hand and draw a new one.
+// Players do it with RebootUniverse, so the rule needs no hooks.
+type rebootingTheUniverse struct {
+	NoHooks
+}
+
+func init() {
+	RegisterHouseRule(rebootingTheUniverse{})
+}
+
+func (rebootingTheUniverse) Name() string {
+	return RebootingTheUniverse
+}
+
+func (rebootingTheUniverse) Description() string {
+	return "At any time, players can spend an Awesome Point to throw away their hand and draw a new one with /reboot."
+}
+
+// RebootUniverse spends one of a player's Awesome Points to discard their hand and draw a new one.
+// The cards they already played this round stay played.
+func (g *Game) RebootUniverse(PlayerID int) ([]Event, error) {
+	if g.Phase == Finished {
+		return nil, ErrGameOver
+	}
+	if !g.HouseRuleOn(RebootingTheUniverse) {
+		return nil, ErrHouseRuleOff
+	}
+	p := g.Player(PlayerID)
+	if p == nil {
+		return nil, ErrNotInGame
+	}
+	if p.Points < 1 {
+		return nil, ErrNoPoints
+	}
+	g.score(p, -1)
+	n := len(p.Hand)
+	if n < g.Settings.CardsInHand {
+		n = g.Settings.CardsInHand
+	}
+	g.Discards = append(g.Discards, p.Hand...)
+	p.Hand = g.drawAnswers(n)
+	p.Trading, p.WritingIn = nil, 0
+	return []Event{UniverseRebooted{p}}, nil
+}
@@ -0,0 +1,132 @@
+package game
+
+// AllCards as NumCardsToTrade lets players trade in their whole hand.
+const AllCards = -1
+
+// Settings are the options that can be changed for a game.
+type Settings struct {
+	MysteryPlayer   bool
+	TradeInCards    bool
+	NumCardsToTrade int
+	PickWorst       bool
+	CardsInHand     int
+	PointsToWin     int
+	// BlankCards is how many blank cards are shuffled into the answer pile.
+	BlankCards int
+	// AnswerMinutes and CzarMinutes are how long the players have to answer and the czar has to judge,
+	// or 0 for as long as they like.  CzarTimeout is what happens when the czar takes too long.
+	AnswerMinutes int
+	CzarMinutes   int
+	CzarTimeout   CzarTimeoutRule
+	// Czarless is whether the game is played without a czar: everyone answers and votes for the best answer.
+	Czarless bool
+	// TieBreak is how a tie for the most votes is broken.
+	TieBreak TieBreak
+	// Judging is how the best answer of a round is found.
+	Judging JudgingMode
+}
+
+// JudgingMode is how the best answer of a round is found.
+type JudgingMode int
+
+// The ways to judge the answers.
+const (
+	// CzarJudging lets the czar pick the best answer, or the players vote on it if the game has no czar.
+	CzarJudging JudgingMode = iota
+	// SurvivalJudging has the players take turns eliminating answers until one is left.
+	SurvivalJudging
+	// RankedJudging has the czar rank the best three answers, which get PlacePoints.
+	RankedJudging
+)
+
+// DefaultSettings are the settings a new game starts with.
+var DefaultSettings = Settings{CardsInHand: 7, PointsToWin: 7}
+
+// Valid checks that the settings make a playable game.
+func (s Settings) Valid() error {
+	if s.CardsInHand < 1 || s.PointsToWin < 1 || s.BlankCards < 0 || s.AnswerMinutes < 0 || s.CzarMinutes < 0 {
+		return ErrInvalidSettings
+	}
+	if s.CzarTimeout < ReplaceCzar || s.CzarTimeout > VotePick || s.TieBreak < RandomTie || s.TieBreak > NoWinnerTie ||
+		s.Judging < CzarJudging || s.Judging > RankedJudging {
+		return ErrInvalidSettings
+	}
+	if s.NumCardsToTrade != AllCards && s.NumCardsToTrade < 0 {
+		return ErrInvalidSettings
+	}
+	if s.NumCardsToTrade != AllCards && s.NumCardsToTrade > s.CardsInHand {
+		return ErrTooManyToTrade
+	}
+	return nil
+}
+
+// EditSettings lets a player change the settings between rounds.  Nobody else can change them until they are done.
+func (g *Game) EditSettings(PlayerID int) ([]Event, error) {
+	p, err := g.settingsEditor(PlayerID, false)
+	if err != nil {
+		return nil, err
+	}
+	g.SettingsEditor = p.ID
+	return []Event{EditingSettings{p}}, nil
+}
+
+// ChangeSettings replaces the settings.  Only the player changing the settings can do this.
+// If players should hold more cards, they are dealt them now.  If they should hold fewer, they play their hands down.
+func (g *Game) ChangeSettings(PlayerID int, Settings Settings) ([]Event, error) {
+	p, err := g.settingsEditor(PlayerID, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := Settings.Valid(); err != nil {
+		return nil, err
+	}
+	old := g.Settings
+	if old == Settings {
+		return nil, nil
+	}
+	g.Settings = Settings
+	if Settings.BlankCards != old.BlankCards {
+		g.changeBlankCards(old.BlankCards, Settings.BlankCards)
+	}
+	if Settings.Czarless {
+		g.Czar = 0
+	} else if g.Czar == 0 && len(g.Players) != 0 {
+		g.Czar = g.Players[0].ID
+	}
+	for _, player := range g.Players {
+		if need := Settings.CardsInHand - len(player.Hand); need > 0 {
+			player.Hand = append(player.Hand, g.drawAnswers(need)...)
+		}
+	}
+	return []Event{SettingsChanged{p, old, Settings}}, nil
+}
+
+// DoneEditingSettings lets someone else change the settings.
+func (g *Game) DoneEditingSettings(PlayerID int) ([]Event, error) {
+	if _, err := g.settingsEditor(PlayerID, true); err != nil {
+		return nil, err
+	}
+	g.SettingsEditor = 0
+	return nil, nil
+}
+
+// settingsEditor checks that a player can change the settings.  If Editing is true, they must already be changing them.
+func (g *Game) settingsEditor(PlayerID int, Editing bool) (*Player, error) {
+	if g.Phase == Finished {
+		return nil, ErrGameOver
+	}
+	p := g.Player(PlayerID)
+	if p == nil {
+		return nil, ErrNotInGame
+	}
+	if g.Phase.InRound() {
+		return nil, ErrRoundInProgress
+	}
+	if g.SettingsEditor != 0 && g.SettingsEditor != PlayerID {
+		return nil, ErrSettingsLocked
+	}
+	if Editing && g.SettingsEditor != PlayerID {
+		return nil, ErrNotEditing
+	}
+	return p, nil
+}
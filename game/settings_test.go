@@ -0,0 +1,43 @@
+package game
+
+import "testing"
+
+func TestSettingsValid(t *testing.T) {
+	with := func(Change func(*Settings)) Settings {
+		s := DefaultSettings
+		Change(&s)
+		return s
+	}
+	for _, c := range []struct {
+		name     string
+		settings Settings
+		want     error
+	}{
+		{"the default settings", DefaultSettings, nil},
+		{"trading in the whole hand", with(func(s *Settings) { s.NumCardsToTrade = AllCards }), nil},
+		{"trading in as many cards as are in a hand", with(func(s *Settings) { s.NumCardsToTrade = s.CardsInHand }), nil},
+		{"no cards in a hand", with(func(s *Settings) { s.CardsInHand = 0 }), ErrInvalidSettings},
+		{"no points to win", with(func(s *Settings) { s.PointsToWin = 0 }), ErrInvalidSettings},
+		{"fewer than no blank cards", with(func(s *Settings) { s.BlankCards = -1 }), ErrInvalidSettings},
+		{"a judging mode that does not exist", with(func(s *Settings) { s.Judging = RankedJudging + 1 }), ErrInvalidSettings},
+		{"trading in fewer than no cards", with(func(s *Settings) { s.NumCardsToTrade = -2 }), ErrInvalidSettings},
+		{"trading in more cards than are in a hand", with(func(s *Settings) { s.NumCardsToTrade = s.CardsInHand + 1 }), ErrTooManyToTrade},
+	} {
+		if err := c.settings.Valid(); err != c.want {
+			t.Errorf("%v: got %v, want %v", c.name, err, c.want)
+		}
+	}
+}
+
+func TestChangeSettingsRefusesInvalidSettings(t *testing.T) {
+	g := newGame(t, DefaultSettings, 3)
+	g.EditSettings(1)
+	s := g.Settings
+	s.NumCardsToTrade = s.CardsInHand + 1
+	if _, err := g.ChangeSettings(1, s); err != ErrTooManyToTrade {
+		t.Errorf("got %v, want %v", err, ErrTooManyToTrade)
+	}
+	if g.Settings != DefaultSettings {
+		t.Errorf("got %+v after refusing the settings, want them unchanged", g.Settings)
+	}
+}
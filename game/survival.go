@@ -0,0 +1,126 @@
+package game
+
+// In Survival of the Fittest (Settings.Judging is SurvivalJudging), nobody picks the best answer.  The players take
+// turns, in the order they become the czar and starting with the czar, eliminating one of the answers, without
+// knowing whose they are, until one is left.  That answer gets the Awesome Point.  Nobody can eliminate their own
+// answer, so a player whose answer is still in the running is passed over for a turn, and a player that does not
+// take their turn in time has an answer eliminated for them at random.
+
+// Eliminate is a player eliminating an answer in Round on their turn.  Choice is the position of the answer in
+// Answers.  Eliminating after the last answer is left does nothing.
+func (g *Game) Eliminate(PlayerID, Round, Choice int) ([]Event, error) {
+	if Round != g.Round {
+		return nil, ErrOldRound
+	}
+	if g.Phase == RoundOver {
+		return nil, nil
+	}
+	if g.Phase != Eliminating {
+		return nil, ErrNotEliminating
+	}
+	p := g.Player(PlayerID)
+	if p == nil {
+		return nil, ErrNotInGame
+	}
+	if p.ID != g.Turn {
+		return nil, ErrNotYourTurn
+	}
+	answers := g.Answers()
+	if Choice < 0 || Choice >= len(answers) || indexOf(g.Eliminated, answers[Choice].PlayerID) != -1 {
+		return nil, ErrInvalidChoice
+	}
+	if answers[Choice].PlayerID == p.ID {
+		return nil, ErrOwnAnswer
+	}
+	return g.eliminate(Choice, false), nil
+}
+
+// Survivors returns the positions in Answers of the answers that have not been eliminated.
+func (g *Game) Survivors() []int {
+	var survivors []int
+	for i, a := range g.Answers() {
+		if indexOf(g.Eliminated, a.PlayerID) == -1 {
+			survivors = append(survivors, i)
+		}
+	}
+	return survivors
+}
+
+// startElimination starts the turns of eliminating answers with the czar, or the first player without one.
+func (g *Game) startElimination() []Event {
+	g.Phase = Eliminating
+	g.Eliminated = nil
+	g.Turn = g.Czar
+	if g.Turn == 0 {
+		g.Turn = g.Players[0].ID
+	}
+	g.setDeadline(g.Settings.CzarMinutes)
+	return []Event{EliminationStarted{g.Player(g.Turn), g.Answers()}}
+}
+
+// eliminate takes the answer at Choice in Answers out of the running and passes the turn on.
+// Random is whether it was eliminated for a player that took too long.  When one answer is left, it wins.
+func (g *Game) eliminate(Choice int, Random bool) []Event {
+	answers := g.Answers()
+	g.Eliminated = append(g.Eliminated, answers[Choice].PlayerID)
+	by := g.Player(g.Turn)
+	survivors := g.Survivors()
+	if len(survivors) < 2 {
+		events := []Event{AnswerEliminated{by, answers[Choice].Text, Random, nil, answers, survivors}}
+		return append(events, g.survived()...)
+	}
+	g.Turn = g.nextTurn()
+	g.setDeadline(g.Settings.CzarMinutes)
+	return []Event{AnswerEliminated{by, answers[Choice].Text, Random, g.Player(g.Turn), answers, survivors}}
+}
+
+// eliminateForTurn eliminates an answer at random for the player whose turn it is, but never their own.
+func (g *Game) eliminateForTurn() []Event {
+	var choices []int
+	answers := g.Answers()
+	for _, i := range g.Survivors() {
+		if answers[i].PlayerID != g.Turn {
+			choices = append(choices, i)
+		}
+	}
+	if len(choices) == 0 {
+		return g.survived()
+	}
+	return g.eliminate(choices[g.intn(len(choices))], true)
+}
+
+// survived gives the answer that was not eliminated an Awesome Point, or ends the round if there is none.
+func (g *Game) survived() []Event {
+	survivors := g.Survivors()
+	if len(survivors) == 0 {
+		return g.endRound()
+	}
+	return g.chooseAnswer(survivors[0], false, false)
+}
+
+// nextTurn returns the ID of the player whose turn is after the player whose turn it is.  The players whose answers
+// have not been eliminated are passed over, because they could only keep their own answer in the running; with two
+// answers left, they would pick the winner.  If every other player has an answer left, the next player has the turn.
+func (g *Game) nextTurn() int {
+	if len(g.Players) == 0 {
+		return 0
+	}
+	start := 0
+	for i, p := range g.Players {
+		if p.ID == g.Turn {
+			start = i + 1
+			break
+		}
+	}
+	answers := g.Answers()
+	left := make(map[int]bool)
+	for _, i := range g.Survivors() {
+		left[answers[i].PlayerID] = true
+	}
+	for n := 0; n < len(g.Players); n++ {
+		if p := g.Players[(start+n)%len(g.Players)]; p.ID != g.Turn && !left[p.ID] {
+			return p.ID
+		}
+	}
+	return g.Players[start%len(g.Players)].ID
+}
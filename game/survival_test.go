@@ -0,0 +1,133 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// survivalGame plays a round of Survival of the Fittest up to the eliminations.
+func survivalGame(t *testing.T, Players int) *Game {
+	t.Helper()
+	g := newGame(t, Settings{CardsInHand: 7, PointsToWin: 7, Judging: SurvivalJudging, CzarMinutes: 1}, Players)
+	begin(t, g)
+	events := answerAll(t, g)
+	started, ok := eventOf(events, EliminationStarted{}).(EliminationStarted)
+	if !ok || g.Phase != Eliminating {
+		t.Fatalf("got %v in phase %v, want the eliminations started", events, g.Phase)
+	}
+	if started.Turn.ID != 1 {
+		t.Fatalf("got player %v's turn first, want the czar's", started.Turn.ID)
+	}
+	return g
+}
+
+// eliminateAnswer has Player eliminate another player's answer and fails the test if they cannot.
+func eliminateAnswer(t *testing.T, g *Game, Player, Answer int) []Event {
+	t.Helper()
+	events, err := g.Eliminate(Player, g.Round, answerIndex(t, g, Answer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return events
+}
+
+func TestEliminationTurns(t *testing.T) {
+	g := survivalGame(t, 4)
+	if _, err := g.Eliminate(2, g.Round, 0); err != ErrNotYourTurn {
+		t.Errorf("eliminating out of turn: got %v, want %v", err, ErrNotYourTurn)
+	}
+	if _, err := g.StartRound(); err != ErrWaitingOnTurns {
+		t.Errorf("starting a round: got %v, want %v", err, ErrWaitingOnTurns)
+	}
+	events := eliminateAnswer(t, g, 1, 4)
+	// Players 2 and 3 would each be left to pick between their own answer and the other one, so player 4 goes next.
+	eliminated, ok := eventOf(events, AnswerEliminated{}).(AnswerEliminated)
+	if !ok || eliminated.Player.ID != 1 || eliminated.Next.ID != 4 || len(eliminated.Left) != 2 {
+		t.Fatalf("got %v, want player 1 to eliminate an answer and pass the turn to player 4", events)
+	}
+	if _, err := g.Eliminate(2, g.Round, answerIndex(t, g, 3)); err != ErrNotYourTurn {
+		t.Errorf("eliminating with an answer left: got %v, want %v", err, ErrNotYourTurn)
+	}
+	if _, err := g.Eliminate(4, g.Round, answerIndex(t, g, 4)); err != ErrInvalidChoice {
+		t.Errorf("eliminating an answer twice: got %v, want %v", err, ErrInvalidChoice)
+	}
+	events = eliminateAnswer(t, g, 4, 3)
+	chosen, ok := eventOf(events, AnswerChosen{}).(AnswerChosen)
+	if !ok || chosen.Player.ID != 2 {
+		t.Fatalf("got %v, want player 2's answer to survive", events)
+	}
+	if g.Player(2).Points != 1 || g.Phase != RoundOver || len(g.Eliminated) != 0 || g.Turn != 0 {
+		t.Errorf("got %v Awesome Points in phase %v, want 1 and the round over and cleared", g.Player(2).Points, g.Phase)
+	}
+}
+
+func TestEliminationTurnsPassOverPlayersWithAnswersLeft(t *testing.T) {
+	g := survivalGame(t, 5)
+	eliminated, _ := eventOf(eliminateAnswer(t, g, 1, 5), AnswerEliminated{}).(AnswerEliminated)
+	if eliminated.Next == nil || eliminated.Next.ID != 5 {
+		t.Fatalf("got %v, want the turn passed to player 5, whose answer is out", eliminated)
+	}
+	eliminated, _ = eventOf(eliminateAnswer(t, g, 5, 4), AnswerEliminated{}).(AnswerEliminated)
+	if eliminated.Next == nil || eliminated.Next.ID != 1 {
+		t.Fatalf("got %v, want the turn back with the czar, passing over players 2, 3 and 4", eliminated)
+	}
+	if chosen, ok := eventOf(eliminateAnswer(t, g, 1, 3), AnswerChosen{}).(AnswerChosen); !ok || chosen.Player.ID != 2 {
+		t.Errorf("got %v, want player 2's answer to survive", chosen)
+	}
+}
+
+func TestEliminationWithoutACzar(t *testing.T) {
+	g := newGame(t, Settings{CardsInHand: 7, PointsToWin: 7, Judging: SurvivalJudging, Czarless: true}, 3)
+	begin(t, g)
+	started, ok := eventOf(answerAll(t, g), EliminationStarted{}).(EliminationStarted)
+	if !ok || started.Turn.ID != 1 {
+		t.Fatalf("got %v, want player 1 to go first", started)
+	}
+	// Everyone answered, so the first player has an answer of their own in the running.
+	if _, err := g.Eliminate(1, g.Round, answerIndex(t, g, 1)); err != ErrOwnAnswer {
+		t.Errorf("eliminating your own answer: got %v, want %v", err, ErrOwnAnswer)
+	}
+	eliminated, _ := eventOf(eliminateAnswer(t, g, 1, 2), AnswerEliminated{}).(AnswerEliminated)
+	if eliminated.Next == nil || eliminated.Next.ID != 2 {
+		t.Fatalf("got %v, want the turn passed to player 2, whose answer is out", eliminated)
+	}
+	if chosen, ok := eventOf(eliminateAnswer(t, g, 2, 1), AnswerChosen{}).(AnswerChosen); !ok || chosen.Player.ID != 3 {
+		t.Errorf("got %v, want player 3's answer to survive", chosen)
+	}
+}
+
+func TestEliminationTimeoutNeverTakesYourOwnAnswer(t *testing.T) {
+	for seed := 0; seed < 10; seed++ {
+		g, clock := timedGame(t, Settings{CardsInHand: 7, PointsToWin: 7, Judging: SurvivalJudging, Czarless: true, CzarMinutes: 1}, 3)
+		g.Rand = rand.New(rand.NewSource(int64(seed)))
+		begin(t, g)
+		answerAll(t, g)
+		clock.Advance(time.Minute)
+		// It is player 1's turn, and their own answer is one of the three left.
+		events := g.Tick()
+		eliminated, ok := eventOf(events, AnswerEliminated{}).(AnswerEliminated)
+		if !ok || !eliminated.Random {
+			t.Fatalf("got %v, want an answer eliminated at random", events)
+		}
+		if indexOf(g.Eliminated, 1) != -1 || len(g.Eliminated) != 1 {
+			t.Fatalf("got %v eliminated, want one answer that is not player 1's", g.Eliminated)
+		}
+		if eliminated.Next == nil || eliminated.Next.ID != g.Eliminated[0] {
+			t.Errorf("got %v, want the turn passed to the player whose answer is out", eliminated)
+		}
+	}
+}
+
+func TestLeavingOnYourTurn(t *testing.T) {
+	g := survivalGame(t, 5)
+	eliminateAnswer(t, g, 1, 5)
+	events, err := g.RemovePlayer(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed, ok := eventOf(events, EliminationChanged{}).(EliminationChanged)
+	if !ok || changed.Turn.ID != 1 || len(changed.Left) != 3 {
+		t.Fatalf("got %v, want the turn passed back to the czar with 3 answers left", events)
+	}
+}
@@ -0,0 +1,102 @@
+package game
+
+// TradeLimit returns the most cards a player can trade in.
+func (g *Game) TradeLimit(p *Player) int {
+	if g.Settings.NumCardsToTrade == AllCards || g.Settings.NumCardsToTrade > len(p.Hand) {
+		return len(p.Hand)
+	}
+	return g.Settings.NumCardsToTrade
+}
+
+// PickTrade picks a card to trade in after Round, or puts it back if it was already picked.
+func (g *Game) PickTrade(PlayerID, Round, Card int) ([]Event, error) {
+	p, err := g.trader(PlayerID, Round)
+	if err != nil {
+		return nil, err
+	}
+	if i := indexOf(p.Trading, Card); i != -1 {
+		p.Trading = append(p.Trading[:i], p.Trading[i+1:]...)
+		return nil, nil
+	}
+	if indexOf(p.Hand, Card) == -1 {
+		return nil, ErrCardNotInHand
+	}
+	if len(p.Trading) >= g.TradeLimit(p) {
+		return nil, ErrTradeLimit
+	}
+	p.Trading = append(p.Trading, Card)
+	return nil, nil
+}
+
+// PickAllTrades picks as many cards to trade in after Round as the player is allowed, starting from the top of their hand.
+func (g *Game) PickAllTrades(PlayerID, Round int) ([]Event, error) {
+	p, err := g.trader(PlayerID, Round)
+	if err != nil {
+		return nil, err
+	}
+	for _, card := range p.Hand {
+		if len(p.Trading) >= g.TradeLimit(p) {
+			break
+		}
+		if indexOf(p.Trading, card) == -1 {
+			p.Trading = append(p.Trading, card)
+		}
+	}
+	return nil, nil
+}
+
+// TradeIn discards the cards a player picked after Round and deals them new ones.
+// Trading in no cards keeps the hand as it is.
+func (g *Game) TradeIn(PlayerID, Round int) ([]Event, error) {
+	p, err := g.trader(PlayerID, Round)
+	if err != nil {
+		return nil, err
+	}
+	return []Event{g.trade(p)}, nil
+}
+
+// finishTrades trades in the cards that players picked but did not trade in before the next round.
+func (g *Game) finishTrades() []Event {
+	var events []Event
+	for _, p := range g.Players {
+		if len(p.Trading) != 0 && !p.Traded {
+			events = append(events, g.trade(p))
+		}
+		p.Trading, p.Traded = nil, false
+	}
+	return events
+}
+
+// trade swaps the cards a player picked for new ones from the answer pile.
+func (g *Game) trade(p *Player) Event {
+	discarded := p.Trading
+	for _, card := range discarded {
+		if i := indexOf(p.Hand, card); i != -1 {
+			p.Hand = append(p.Hand[:i], p.Hand[i+1:]...)
+		}
+	}
+	// The new cards are drawn first, so that a player does not get back the cards they traded in.
+	drawn := g.drawAnswers(len(discarded))
+	g.Discards = append(g.Discards, discarded...)
+	p.Hand = append(p.Hand, drawn...)
+	p.Trading, p.Traded = nil, true
+	return CardsTraded{p, discarded, drawn}
+}
+
+// trader checks that a player can trade in cards after Round.
+func (g *Game) trader(PlayerID, Round int) (*Player, error) {
+	if Round != g.Round {
+		return nil, ErrOldRound
+	}
+	if g.Phase != RoundOver || !g.Settings.TradeInCards {
+		return nil, ErrNotTrading
+	}
+	p := g.Player(PlayerID)
+	if p == nil {
+		return nil, ErrNotInGame
+	}
+	if p.Traded {
+		return nil, ErrAlreadyTraded
+	}
+	return p, nil
+}
@@ -0,0 +1,95 @@
+package game
+
+import "testing"
+
+// playRound answers and judges a round, picking the first answer.
+func playRound(t *testing.T, g *Game) {
+	t.Helper()
+	answerAll(t, g)
+	if _, err := g.ChooseAnswer(g.Czar, g.Round, 0); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTradeIn(t *testing.T) {
+	g := newGame(t, Settings{CardsInHand: 7, PointsToWin: 7, TradeInCards: true, NumCardsToTrade: 2}, 3)
+	begin(t, g)
+	p := g.Player(2)
+	if _, err := g.PickTrade(p.ID, g.Round, p.Hand[0]); err != ErrNotTrading {
+		t.Errorf("trading during a round: got %v, want %v", err, ErrNotTrading)
+	}
+	answerAll(t, g)
+	events, err := g.ChooseAnswer(1, g.Round, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eventOf(events, TradesOffered{}) == nil {
+		t.Errorf("got %v, want the players offered to trade in cards", events)
+	}
+	traded := []int{p.Hand[0], p.Hand[1]}
+	for _, card := range traded {
+		if _, err := g.PickTrade(p.ID, g.Round, card); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := g.PickTrade(p.ID, g.Round, p.Hand[2]); err != ErrTradeLimit {
+		t.Errorf("picking a third card: got %v, want %v", err, ErrTradeLimit)
+	}
+	events, err = g.TradeIn(p.ID, g.Round)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done, ok := eventOf(events, CardsTraded{}).(CardsTraded)
+	if !ok || len(done.Discarded) != 2 || len(done.Drawn) != 2 {
+		t.Fatalf("got %v, want two cards traded in for two new ones", events)
+	}
+	if len(p.Hand) != 7 {
+		t.Errorf("got %v cards after trading in, want 7", len(p.Hand))
+	}
+	for _, card := range traded {
+		if indexOf(p.Hand, card) != -1 || indexOf(g.Discards, card) == -1 {
+			t.Errorf("card %v was not discarded", card)
+		}
+	}
+	if _, err := g.TradeIn(p.ID, g.Round); err != ErrAlreadyTraded {
+		t.Errorf("trading in twice: got %v, want %v", err, ErrAlreadyTraded)
+	}
+}
+
+func TestPickingATradeAgainPutsItBack(t *testing.T) {
+	g := newGame(t, Settings{CardsInHand: 7, PointsToWin: 7, TradeInCards: true, NumCardsToTrade: AllCards}, 3)
+	begin(t, g)
+	playRound(t, g)
+	p := g.Player(3)
+	card := p.Hand[0]
+	g.PickTrade(p.ID, g.Round, card)
+	g.PickTrade(p.ID, g.Round, card)
+	if len(p.Trading) != 0 {
+		t.Errorf("got %v picked, want the card put back", p.Trading)
+	}
+	if _, err := g.PickAllTrades(p.ID, g.Round); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Trading) != len(p.Hand) {
+		t.Errorf("got %v cards picked, want the whole hand of %v", len(p.Trading), len(p.Hand))
+	}
+}
+
+func TestPickedTradesAreTradedInWhenTheRoundStarts(t *testing.T) {
+	g := newGame(t, Settings{CardsInHand: 7, PointsToWin: 7, TradeInCards: true, NumCardsToTrade: 1}, 3)
+	begin(t, g)
+	playRound(t, g)
+	p := g.Player(2)
+	card := p.Hand[0]
+	g.PickTrade(p.ID, g.Round, card)
+	events, err := g.StartRound()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if traded, ok := eventOf(events, CardsTraded{}).(CardsTraded); !ok || traded.Player != p {
+		t.Fatalf("got %v, want player 2's picked card traded in", events)
+	}
+	if indexOf(p.Hand, card) != -1 {
+		t.Errorf("the card picked to trade in is still in the hand")
+	}
+}
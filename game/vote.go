@@ -0,0 +1,123 @@
+package game
+
+// Every player votes for the best answer when the game has no czar (Settings.Czarless), or when the czar does not
+// judge in time and Settings.CzarTimeout is VotePick.  Players that did not answer vote too, but nobody can vote for
+// their own answer.
+// The answer with the most votes wins, a tie is broken by Settings.TieBreak, and a vote nobody took part in is
+// settled at random.
+
+// TieBreak is how a tie for the most votes is broken.
+type TieBreak int
+
+// The ways to break a tie.
+const (
+	// RandomTie gives the point to one of the tied answers, picked at random.
+	RandomTie TieBreak = iota
+	// SharedTie gives every tied answer a point.
+	SharedTie
+	// NoWinnerTie gives nobody a point.
+	NoWinnerTie
+)
+
+func (t TieBreak) String() string {
+	switch t {
+	case RandomTie:
+		return "pick at random"
+	case SharedTie:
+		return "share the point"
+	case NoWinnerTie:
+		return "nobody scores"
+	}
+	return "unknown"
+}
+
+// Vote is a player voting for the best answer in Round.  Choice is the position of the answer in Answers.
+// Voting again after the votes are counted does nothing.
+func (g *Game) Vote(PlayerID, Round, Choice int) ([]Event, error) {
+	if Round != g.Round {
+		return nil, ErrOldRound
+	}
+	if g.Phase == RoundOver {
+		return nil, nil
+	}
+	if g.Phase != Voting {
+		return nil, ErrNotVoting
+	}
+	p := g.Player(PlayerID)
+	if p == nil {
+		return nil, ErrNotInGame
+	}
+	if p.Vote != 0 {
+		return nil, ErrAlreadyVoted
+	}
+	answers := g.Answers()
+	if Choice < 0 || Choice >= len(answers) {
+		return nil, ErrInvalidChoice
+	}
+	if answers[Choice].PlayerID == p.ID {
+		return nil, ErrOwnAnswer
+	}
+	p.Vote = answers[Choice].PlayerID
+	events := []Event{VoteReceived{p}}
+	if len(g.Waiting()) == 0 {
+		events = append(events, g.countVotes()...)
+	}
+	return events, nil
+}
+
+// startVote asks every player to vote for the best answer.
+func (g *Game) startVote() []Event {
+	g.Phase = Voting
+	for _, p := range g.Players {
+		p.Vote = 0
+	}
+	g.setDeadline(g.Settings.CzarMinutes)
+	return []Event{VoteStarted{g.voters(), g.Answers()}}
+}
+
+// voters returns the players that can vote: everyone in the game, whether they answered or not.
+func (g *Game) voters() []*Player {
+	return append([]*Player(nil), g.Players...)
+}
+
+// countVotes gives the answer with the most votes an Awesome Point and ends the round, or the game if they won it.
+func (g *Game) countVotes() []Event {
+	answers := g.Answers()
+	if len(answers) == 0 {
+		return g.endRound()
+	}
+	votes := make([]int, len(answers))
+	for _, p := range g.voters() {
+		for i, a := range answers {
+			if a.PlayerID == p.Vote {
+				votes[i]++
+			}
+		}
+	}
+	var best []int
+	for i := range answers {
+		if len(best) == 0 || votes[i] > votes[best[0]] {
+			best = []int{i}
+		} else if votes[i] == votes[best[0]] {
+			best = append(best, i)
+		}
+	}
+	won := VoteWon{Votes: votes[best[0]], Tie: len(best) > 1, ForFun: g.InFinalRound()}
+	switch {
+	case !won.Tie:
+	case won.Votes == 0 || g.Settings.TieBreak == RandomTie:
+		best = []int{best[g.intn(len(best))]}
+	case g.Settings.TieBreak == NoWinnerTie:
+		best = nil
+	}
+	points := make([]int, len(best))
+	for i, choice := range best {
+		won.Winners, won.Answers = append(won.Winners, g.answerer(answers[choice].PlayerID)), append(won.Answers, answers[choice].Text)
+		points[i] = 1
+	}
+	events := []Event{won}
+	if champion := g.award(won.Winners, points); champion != nil {
+		return append(events, g.win(champion)...)
+	}
+	return append(events, g.endRound()...)
+}
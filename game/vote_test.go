@@ -0,0 +1,155 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+// votingGame plays a czarless round of Players players up to the vote.
+func votingGame(t *testing.T, Tie TieBreak, Players int) *Game {
+	t.Helper()
+	g := newGame(t, Settings{CardsInHand: 7, PointsToWin: 7, Czarless: true, TieBreak: Tie}, Players)
+	begin(t, g)
+	if g.Czar != 0 {
+		t.Fatalf("got czar %v in a czarless game", g.Czar)
+	}
+	events := answerAll(t, g)
+	if eventOf(events, VoteStarted{}) == nil || g.Phase != Voting {
+		t.Fatalf("got %v in phase %v, want a vote", events, g.Phase)
+	}
+	return g
+}
+
+// vote has Voter vote for Choice's answer and fails the test if they cannot.
+func vote(t *testing.T, g *Game, Voter, Choice int) []Event {
+	t.Helper()
+	events, err := g.Vote(Voter, g.Round, answerIndex(t, g, Choice))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return events
+}
+
+func TestVote(t *testing.T) {
+	g := votingGame(t, RandomTie, 3)
+	if _, err := g.Vote(1, g.Round, answerIndex(t, g, 1)); err != ErrOwnAnswer {
+		t.Errorf("voting for your own answer: got %v, want %v", err, ErrOwnAnswer)
+	}
+	vote(t, g, 1, 2)
+	if _, err := g.Vote(1, g.Round, answerIndex(t, g, 3)); err != ErrAlreadyVoted {
+		t.Errorf("voting twice: got %v, want %v", err, ErrAlreadyVoted)
+	}
+	vote(t, g, 2, 3)
+	events := vote(t, g, 3, 2)
+	won, ok := eventOf(events, VoteWon{}).(VoteWon)
+	if !ok || won.Tie || won.Votes != 2 || len(won.Winners) != 1 || won.Winners[0].ID != 2 {
+		t.Fatalf("got %v, want player 2 to win with 2 votes", events)
+	}
+	if g.Player(2).Points != 1 || g.Phase != RoundOver {
+		t.Errorf("got %v Awesome Points in phase %v, want 1 for the winner and the round over", g.Player(2).Points, g.Phase)
+	}
+	if g.Czar != 0 {
+		t.Errorf("got czar %v after the round, want none", g.Czar)
+	}
+}
+
+// tie has three players vote in a circle, so every answer gets one vote.
+func tie(t *testing.T, Tie TieBreak) (*Game, VoteWon) {
+	t.Helper()
+	g := votingGame(t, Tie, 3)
+	vote(t, g, 1, 2)
+	vote(t, g, 2, 3)
+	events := vote(t, g, 3, 1)
+	won, ok := eventOf(events, VoteWon{}).(VoteWon)
+	if !ok || !won.Tie || won.Votes != 1 {
+		t.Fatalf("got %v, want a three-way tie", events)
+	}
+	return g, won
+}
+
+func TestTieBreaks(t *testing.T) {
+	points := func(g *Game) int {
+		total := 0
+		for _, p := range g.Players {
+			total += p.Points
+		}
+		return total
+	}
+	for _, test := range []struct {
+		Tie     TieBreak
+		Winners int
+	}{
+		{RandomTie, 1},
+		{SharedTie, 3},
+		{NoWinnerTie, 0},
+	} {
+		g, won := tie(t, test.Tie)
+		if len(won.Winners) != test.Winners || points(g) != test.Winners {
+			t.Errorf("%v: got %v winners and %v Awesome Points, want %v of each", test.Tie, len(won.Winners), points(g), test.Winners)
+		}
+	}
+}
+
+func TestVoteNobodyTookPartInIsPickedAtRandom(t *testing.T) {
+	g := votingGame(t, NoWinnerTie, 3)
+	won, ok := eventOf(g.countVotes(), VoteWon{}).(VoteWon)
+	if !ok || won.Votes != 0 || len(won.Winners) != 1 {
+		t.Errorf("got %v, want one answer picked at random", won)
+	}
+}
+
+func TestLeavingDuringAVote(t *testing.T) {
+	g := votingGame(t, RandomTie, 3)
+	vote(t, g, 1, 2)
+	vote(t, g, 2, 1)
+	events, err := g.RemovePlayer(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eventOf(events, VoteWon{}) == nil || g.Phase != RoundOver {
+		t.Errorf("got %v in phase %v, want the votes counted once nobody else can vote", events, g.Phase)
+	}
+}
+
+func TestPlayersThatDidNotAnswerVote(t *testing.T) {
+	g, clock := timedGame(t, Settings{CardsInHand: 7, PointsToWin: 7, Czarless: true, AnswerMinutes: 1, CzarMinutes: 1}, 4)
+	begin(t, g)
+	for _, p := range g.Players[:3] {
+		for !g.answered(p) {
+			if _, err := g.PlayCard(p.ID, g.Round, p.Hand[0]); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	clock.Advance(time.Minute)
+	started, ok := eventOf(g.Tick(), VoteStarted{}).(VoteStarted)
+	if !ok || len(started.Voters) != 4 || len(g.Answers()) != 3 {
+		t.Fatalf("got %v with %v answers, want all 4 players voting on 3 answers", started, len(g.Answers()))
+	}
+	if waiting := g.Waiting(); len(waiting) != 4 {
+		t.Errorf("got %v waiting, want every player", waiting)
+	}
+	vote(t, g, 4, 2)
+	vote(t, g, 1, 2)
+	vote(t, g, 2, 3)
+	events := vote(t, g, 3, 1)
+	if won, ok := eventOf(events, VoteWon{}).(VoteWon); !ok || won.Tie || won.Votes != 2 || won.Winners[0].ID != 2 {
+		t.Errorf("got %v, want player 2 to win with the vote of the player that did not answer", events)
+	}
+}
+
+func TestVoteDeadlineCountsTheVotesIn(t *testing.T) {
+	g, clock := timedGame(t, Settings{CardsInHand: 7, PointsToWin: 7, Czarless: true, CzarMinutes: 2}, 3)
+	begin(t, g)
+	answerAll(t, g)
+	vote(t, g, 1, 2)
+	clock.Advance(time.Minute)
+	if near, ok := eventOf(g.Tick(), DeadlineNear{}).(DeadlineNear); !ok || len(near.Waiting) != 2 {
+		t.Fatalf("got %v at the reminder, want the 2 players that have not voted reminded", near)
+	}
+	clock.Advance(time.Minute)
+	won, ok := eventOf(g.Tick(), VoteWon{}).(VoteWon)
+	if !ok || won.Votes != 1 || won.Winners[0].ID != 2 || g.Phase != RoundOver {
+		t.Errorf("got %v in phase %v, want the one vote counted at the deadline", won, g.Phase)
+	}
+}
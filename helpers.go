@@ -1,204 +1,690 @@
 package main
 
 import (
-	"database/sql"
-	"html"
-	"log"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
 	"math/rand"
+	"os"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/thedadams/telegram-bot-api"
+	"github.com/thedadams/cahbot/game"
 )
 
-// AnswerIsValid checks that the answer we received from the user is a valid answer.
-func AnswerIsValid(bot *CAHBot, ChatID int64, Answer string) int {
-	tx, err := bot.DBConn.Begin()
-	defer tx.Rollback()
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		bot.SendActionFailedMessage(ChatID)
-		return 0
+// BuildScoreList builds the score list for a game.
+func BuildScoreList(Scores []game.Score) string {
+	str := ""
+	for _, score := range Scores {
+		if score.Points == 1 || score.Points == -1 {
+			str += score.Name + " had " + strconv.Itoa(score.Points) + " Awesome Point\n"
+		} else {
+			str += score.Name + " had " + strconv.Itoa(score.Points) + " Awesome Points\n"
+		}
 	}
-	var response string
-	err = tx.QueryRow("SELECT get_user_cards($1)", ChatID).Scan(&response)
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		bot.SendActionFailedMessage(ChatID)
-		return 0
+	return str
+}
+
+// GameErrorMessage explains to a player why the game did not let them do something.
+func GameErrorMessage(g *game.Game, err error) string {
+	switch err {
+	case game.ErrGameFull:
+		return "Player limit of " + strconv.Itoa(game.MaxPlayers) + " reached, we can not add any more players."
+	case game.ErrAlreadyInGame:
+		return "You are already playing in this game.  Use command /leave to remove yourself."
+	case game.ErrNotInGame:
+		return "You are currently not in this game.  Use the command /join " + g.ID + " to join it."
+	case game.ErrNotEnoughPlayers:
+		return "You need at least " + strconv.Itoa(game.MinPlayers) + " players to play.  Right now, you have " + strconv.Itoa(len(g.Players)) + ".  Tell others to use the command '/join " + g.ID + "' to join your game."
+	case game.ErrRoundInProgress:
+		return "The game is in the middle of a round.  Please wait until the round is finished and try again."
+	case game.ErrAlreadyBegun:
+		return "The game has already begun.  The Card Czar can use the command /next to start the next round."
+	case game.ErrWaitingOnAnswers:
+		return "We are waiting for players to give answers."
+	case game.ErrWaitingOnCzar:
+		return "We are waiting for the Card Czar to choose an answer."
+	case game.ErrNotCollecting, game.ErrAlreadyAnswered:
+		return "We are not waiting for an answer from you right now."
+	case game.ErrCzarCannotAnswer:
+		return "You are the Card Czar for this round, so you do not get to answer."
+	case game.ErrCardNotInHand:
+		return "That card is not in your hand.  Use the command /cards to see your cards."
+	case game.ErrNotCzar:
+		return "Only the Card Czar can choose the answer."
+	case game.ErrNotJudging, game.ErrInvalidChoice:
+		return "That answer cannot be chosen right now."
+	case game.ErrGameOver:
+		return "This game is over.  Use command /create to create a new one."
+	case game.ErrOldRound:
+		return "That choice was from an earlier round."
+	case game.ErrSettingsLocked:
+		if p := g.Player(g.SettingsEditor); p != nil {
+			return p.Name + " is changing the settings right now.  Please wait until they are done."
+		}
+		return "Someone else is changing the settings right now.  Please wait until they are done."
+	case game.ErrNotEditing:
+		return "You are not changing the settings right now.  Use the command /changesettings to change them."
+	case game.ErrNotTrading:
+		return "You cannot trade in cards right now."
+	case game.ErrTradeLimit:
+		return "You have picked as many cards to trade in as you can.  Press a picked card to keep it."
+	case game.ErrAlreadyTraded:
+		return "You have already traded in cards this round."
+	case game.ErrUnknownPack:
+		return "There is no expansion pack by that name.  Use the command /packs to see the packs."
+	case game.ErrNotEnoughCards:
+		return "The included expansion packs do not have enough cards for everyone.  Use the command /packs to include more of them."
+	case game.ErrNotWritingIn:
+		return "You are not writing on a blank card right now."
+	case game.ErrEmptyWriteIn:
+		return "Please type your answer for the blank card."
+	case game.ErrWriteInTooLong:
+		return "That answer is too long for the blank card.  Please keep it to " + strconv.Itoa(game.MaxWriteIn) + " characters."
+	case game.ErrWaitingOnVotes:
+		return "We are waiting for players to vote for the best answer."
+	case game.ErrNotVoting:
+		return "There is nothing to vote on right now."
+	case game.ErrAlreadyVoted:
+		return "You have already voted this round."
+	case game.ErrOwnAnswer:
+		if g != nil && g.Phase == game.Eliminating {
+			return "You cannot eliminate your own answer."
+		}
+		return "You cannot vote for your own answer."
+	case game.ErrAlreadyRanked:
+		return "You already ranked that answer."
+	case game.ErrWaitingOnTurns:
+		return "We are waiting for the players to eliminate answers."
+	case game.ErrNotEliminating:
+		return "There are no answers to eliminate right now."
+	case game.ErrNotYourTurn:
+		if p := g.Player(g.Turn); p != nil {
+			return "It is " + p.Name + "'s turn to eliminate an answer."
+		}
+		return "It is not your turn to eliminate an answer."
+	case game.ErrUnknownHouseRule:
+		return "There is no house rule by that name."
+	case game.ErrHouseRuleOff:
+		return "That house rule is not on in this game.  It can be turned on in the /settings."
+	case game.ErrNoPoints:
+		return "You need an Awesome Point to spend on that."
+	case game.ErrInvalidSettings:
+		return "Those settings would not work.  Please pick one of the options in the settings menu."
+	case game.ErrTooManyToTrade:
+		return "Those settings would not work.  You cannot trade in more cards than you have in your hand."
+	case ErrUnknownOption:
+		return "That is not one of the options for this setting.  Please pick one from the settings menu."
+	}
+	return "I'm sorry, but it seems I have have difficulties right now.  You can try again later or contact my developer @thedadams."
+}
+
+// IsAdmin checks the password given with an admin command, like /logging, against APPPASS.
+func IsAdmin(Password string) bool {
+	hasher := sha512.New()
+	return strings.EqualFold(base64.URLEncoding.EncodeToString(hasher.Sum([]byte(Password))), os.Getenv("APPPASS"))
+}
+
+// GetRandomID creates a random string for a Game ID.
+func GetRandomID() string {
+	id := ""
+	characters := []string{"A", "B", "C", "D", "E", "F", "G", "H", "J", "K", "L", "M", "N", "O", "P", "Q", "R", "S", "T", "U", "V", "W", "X", "Y", "Z", "a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "m", "n", "o", "p", "q", "r", "s", "t", "u", "v", "w", "x", "y", "z", "!", "#", "$", "@", "?", "-", "&", "1", "2", "3", "4", "5", "6", "7", "8", "9"}
+	n := len(characters)
+	rand.Seed(time.Now().UnixNano())
+	for i := 0; i < 5; i++ {
+		id += characters[rand.Intn(n)]
 	}
-	response = response[1 : len(response)-1]
-	for _, val := range strings.Split(response, ",") {
-		var tmp int
-		tmp, _ = strconv.Atoi(val)
-		if html.UnescapeString(bot.AllAnswerCards[tmp].Text) == Answer {
-			return tmp
+	return id
+}
+
+// PacksMenuText is the text of the menu to include and exclude expansion packs.
+func PacksMenuText(g *game.Game) string {
+	return "Pick the expansion packs to include in the game.  The cards are dealt from the included packs when the game begins.\n\n" + PacksText(g)
+}
+
+// PacksText lists how many cards the included expansion packs have in total.
+func PacksText(g *game.Game) string {
+	packs, questions, answers := 0, 0, 0
+	for _, e := range g.Deck.Expansions() {
+		if g.PackEnabled(e.Name) {
+			packs++
+			questions += e.Questions
+			answers += e.Answers
 		}
 	}
-	return -1
+	count := strconv.Itoa(packs) + " packs"
+	if packs == 1 {
+		count = "1 pack"
+	}
+	return count + " with " + strconv.Itoa(questions) + " question cards and " + strconv.Itoa(answers) + " answer cards are included."
 }
 
-// ArrayTransformForPostgres transforms an array for input into postgres database.
-func ArrayTransformForPostgres(theArray []int) string {
-	value := "{"
-	for item := range theArray {
-		value += strconv.Itoa(theArray[item]) + ","
+// PackChoices builds a choice to include or exclude each expansion pack, with how many cards it has.
+func PackChoices(g *game.Game) []Choice {
+	expansions := g.Deck.Expansions()
+	choices := make([]Choice, 0, len(expansions)+1)
+	for _, e := range expansions {
+		mark := "\u274C "
+		if g.PackEnabled(e.Name) {
+			mark = "\u2705 "
+		}
+		choices = append(choices, Choice{mark + e.Name + " (" + strconv.Itoa(e.Questions) + " questions, " + strconv.Itoa(e.Answers) + " answers)", "Pack::" + e.Name})
 	}
-	value = value[0:len(value)-1] + "}"
-	return value
+	return append(choices, Choice{"Done", "Packs::Done"})
 }
 
-// BuildScoreList builds the score list from a return sql.Rows.
-func BuildScoreList(rows *sql.Rows) string {
+// ParseRoundChoice reads the round and the choice from callback data like Answer::<round>::<choice>.
+// Keyboards sent before choices carried a round get round -1, which is never the current round.
+func ParseRoundChoice(Data []string) (int, int, error) {
+	if len(Data) == 2 {
+		choice, err := strconv.Atoi(Data[1])
+		return -1, choice, err
+	}
+	if len(Data) != 3 {
+		return 0, 0, errors.New("the callback data does not have a round and a choice")
+	}
+	round, err := strconv.Atoi(Data[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	choice, err := strconv.Atoi(Data[2])
+	return round, choice, err
+}
+
+// ParseTradePick reads the round and the pick from callback data like TradeInCard::<round>::<card>.
+func ParseTradePick(Data []string) (int, string, error) {
+	if len(Data) != 3 {
+		return 0, "", errors.New("the callback data does not have a round and a pick")
+	}
+	round, err := strconv.Atoi(Data[1])
+	return round, Data[2], err
+}
+
+// SettingsText lists the settings of a game, one per line.
+func SettingsText(Settings game.Settings) string {
 	str := ""
-	for rows.Next() {
-		var response string
-		if err := rows.Scan(&response); err == nil {
-			arrResponse := strings.Split(response[1:len(response)-1], ",")
-			str += strings.Replace(arrResponse[0], "\"", "", -1) + " had " + arrResponse[1] + " Awesome Points\n"
-		} else {
-			log.Printf("ERROR: %v", err)
-			return "ERROR"
+	for _, line := range settingLines(Settings) {
+		str += line + "\n"
+	}
+	return str
+}
+
+// ChangedSettingsText lists the settings that are different in New, one per line.
+func ChangedSettingsText(Old, New game.Settings) string {
+	str := ""
+	old := settingLines(Old)
+	for i, line := range settingLines(New) {
+		if line != old[i] {
+			str += line + "\n"
 		}
 	}
 	return str
 }
 
-// CzarChoiceIsValid checks to see if we got a valid answer from the czar.
-func CzarChoiceIsValid(bot *CAHBot, GameID, Answer string) int {
-	tx, err := bot.DBConn.Begin()
-	defer tx.Rollback()
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		return 0
+func settingLines(Settings game.Settings) []string {
+	toTrade := strconv.Itoa(Settings.NumCardsToTrade)
+	if Settings.NumCardsToTrade == game.AllCards {
+		toTrade = "All"
+	}
+	return []string{
+		"Mystery player enabled: " + YesNo(Settings.MysteryPlayer),
+		"Trade in cards after every round: " + YesNo(Settings.TradeInCards),
+		"Number of cards to trade in: " + toTrade,
+		"Pick the worst answer also: " + YesNo(Settings.PickWorst),
+		"Number of cards in each players hand: " + strconv.Itoa(Settings.CardsInHand),
+		"Number of points needed to win: " + strconv.Itoa(Settings.PointsToWin),
+		"Number of blank cards: " + strconv.Itoa(Settings.BlankCards),
+		"Time to answer: " + Minutes(Settings.AnswerMinutes),
+		"Time for the czar to choose: " + Minutes(Settings.CzarMinutes),
+		"When the czar takes too long: " + CzarTimeoutText(Settings.CzarTimeout),
+		"No Card Czar, everyone votes: " + YesNo(Settings.Czarless),
+		"Ties in votes: " + TieBreakText(Settings.TieBreak),
+		"How the answers are judged: " + JudgingText(Settings.Judging),
+	}
+}
+
+// Minutes says how long a deadline is, like "1 minute", "5 minutes" or "No limit".
+func Minutes(n int) string {
+	switch n {
+	case 0:
+		return "No limit"
+	case 1:
+		return "1 minute"
+	}
+	return strconv.Itoa(n) + " minutes"
+}
+
+// TieBreakText says how a tie for the most votes is broken.
+func TieBreakText(Tie game.TieBreak) string {
+	switch Tie {
+	case game.SharedTie:
+		return "Every tied answer scores"
+	case game.NoWinnerTie:
+		return "Nobody scores"
 	}
-	var answers string
-	err = tx.QueryRow("SELECT get_answers($1)", GameID).Scan(&answers)
-	tx.Commit()
-	for _, val := range ShuffleAnswers(strings.Split(answers[1:len(answers)-1], "+=+\",")) {
-		if Answer == strings.Replace(html.UnescapeString(strings.Replace(val[1:len(val)-1], "+=+", "", -1)), "\\\"", "", -1) {
-			return 1
+	return "Pick an answer at random"
+}
+
+// JudgingText says how the best answer of a round is found.
+func JudgingText(Mode game.JudgingMode) string {
+	switch Mode {
+	case game.SurvivalJudging:
+		return "Survival of the Fittest"
+	case game.RankedJudging:
+		return "Serious Business"
+	}
+	return "The czar picks"
+}
+
+// CzarTimeoutText says what happens when the czar takes too long.
+func CzarTimeoutText(Rule game.CzarTimeoutRule) string {
+	switch Rule {
+	case game.RandomPick:
+		return "Pick an answer at random"
+	case game.VotePick:
+		return "Players vote"
+	}
+	return "Replace the czar"
+}
+
+// SettingsMenuText is the text of the settings menu shown to the player changing the settings.
+func SettingsMenuText(g *game.Game) string {
+	return "Game settings:\n" + SettingsText(g.Settings) + HouseRulesText(g) + "\n\nWhich setting would you like to change?"
+}
+
+// HouseRulesText lists the house rules that are on in a game.
+func HouseRulesText(g *game.Game) string {
+	if len(g.HouseRules) == 0 {
+		return "House rules: None"
+	}
+	return "House rules: " + strings.Join(g.HouseRules, ", ")
+}
+
+// HouseRulesMenuText is the text of the menu to turn house rules on and off, with what each of them does.
+func HouseRulesMenuText() string {
+	text := "Pick the house rules to play with:\n"
+	for _, r := range game.HouseRules() {
+		text += "\n" + r.Name() + ": " + r.Description()
+	}
+	return text
+}
+
+// HouseRuleChoices builds a choice to turn each house rule on or off, and to go back to the settings.
+func HouseRuleChoices(g *game.Game) []Choice {
+	rules := game.HouseRules()
+	choices := make([]Choice, 0, len(rules)+1)
+	for _, r := range rules {
+		mark := "\u274C "
+		if g.HouseRuleOn(r.Name()) {
+			mark = "\u2705 "
 		}
+		choices = append(choices, Choice{mark + r.Name(), "HouseRule::" + r.Name()})
 	}
-	return -1
+	return append(choices, Choice{"Back", "ChangeSetting::Back"})
 }
 
-// GameScores gets the scores for a game.
-func GameScores(GameID string, db *sql.DB) string {
-	rows, err := db.Query("SELECT get_player_scores($1)", GameID)
-	defer rows.Close()
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		return "ERROR"
+// SettingChoices builds the choices to pick a setting to change, and to finish changing them.
+func SettingChoices(Settings []Setting) []Choice {
+	choices := make([]Choice, 0, len(Settings)+1)
+	for _, s := range Settings {
+		choices = append(choices, Choice{s.Name, s.CData})
 	}
-	return BuildScoreList(rows)
+	return append(choices, Choice{"Done", "ChangeSetting::Done"})
 }
 
-// GetGameID gets the GameID for a player.
-func GetGameID(UserID int, ChatID int64, db *sql.DB) (string, error) {
-	var GameID string
-	tx, err := db.Begin()
-	defer tx.Rollback()
-	if err != nil {
-		log.Printf("ERROR: %v", err)
-		return "", err
+// OptionChoices builds the choices for the options of a setting, and to go back to the settings.
+func OptionChoices(S Setting) []Choice {
+	choices := make([]Choice, 0, len(S.Options)+1)
+	for _, o := range S.Options {
+		choices = append(choices, Choice{o.Name, o.CData})
+	}
+	return append(choices, Choice{"Back", "ChangeSetting::Back"})
+}
+
+// FindSetting finds the setting with the callback data CData, like ChangeSetting::Jose.
+func FindSetting(Settings []Setting, CData string) (Setting, bool) {
+	for _, s := range Settings {
+		if s.CData == CData {
+			return s, true
+		}
+	}
+	return Setting{}, false
+}
+
+// ErrUnknownOption is returned when an option picked from the settings menu is not one that ApplySetting knows.
+var ErrUnknownOption = errors.New("there is no such option for a setting")
+
+// ApplySetting applies an option picked from the settings menu, like Jose::Yes, to the settings of a game.
+// It returns false if the option is not one it knows.
+func ApplySetting(Settings game.Settings, Option string) (game.Settings, bool) {
+	parts := strings.Split(Option, "::")
+	if len(parts) != 2 {
+		return Settings, false
+	}
+	switch parts[0] {
+	case "WorstCardToo", "TradeInCards", "Jose", "Czarless":
+		if parts[1] != "Yes" && parts[1] != "No" {
+			return Settings, false
+		}
+		yes := parts[1] == "Yes"
+		switch parts[0] {
+		case "WorstCardToo":
+			Settings.PickWorst = yes
+		case "TradeInCards":
+			Settings.TradeInCards = yes
+			if yes && Settings.NumCardsToTrade == 0 {
+				Settings.NumCardsToTrade = 1
+			}
+		case "Jose":
+			Settings.MysteryPlayer = yes
+		case "Czarless":
+			Settings.Czarless = yes
+		}
+		return Settings, true
+	case "CzarTimeout":
+		switch parts[1] {
+		case "Replace":
+			Settings.CzarTimeout = game.ReplaceCzar
+		case "Random":
+			Settings.CzarTimeout = game.RandomPick
+		case "Vote":
+			Settings.CzarTimeout = game.VotePick
+		default:
+			return Settings, false
+		}
+		return Settings, true
+	case "TieBreak":
+		switch parts[1] {
+		case "Random":
+			Settings.TieBreak = game.RandomTie
+		case "Share":
+			Settings.TieBreak = game.SharedTie
+		case "None":
+			Settings.TieBreak = game.NoWinnerTie
+		default:
+			return Settings, false
+		}
+		return Settings, true
+	case "Judging":
+		switch parts[1] {
+		case "Czar":
+			Settings.Judging = game.CzarJudging
+		case "Survival":
+			Settings.Judging = game.SurvivalJudging
+		case "Ranked":
+			Settings.Judging = game.RankedJudging
+		default:
+			return Settings, false
+		}
+		return Settings, true
+	}
+	n, err := strconv.Atoi(parts[1])
+	if parts[0] == "NumCardsTradeIn" && parts[1] == "All" {
+		n, err = game.AllCards, nil
 	}
-	err = tx.QueryRow("SELECT get_game_id($1, $2)", UserID, ChatID).Scan(&GameID)
 	if err != nil {
-		return "", err
+		return Settings, false
 	}
-	return GameID, err
+	switch parts[0] {
+	case "NumCardsTradeIn":
+		Settings.NumCardsToTrade = n
+	case "NumCardsInHand":
+		Settings.CardsInHand = n
+	case "NumCardsToWin":
+		Settings.PointsToWin = n
+	case "BlankCards":
+		Settings.BlankCards = n
+	case "AnswerMinutes":
+		Settings.AnswerMinutes = n
+	case "CzarMinutes":
+		Settings.CzarMinutes = n
+	default:
+		return Settings, false
+	}
+	return Settings, true
 }
 
-// GetRandomID creates a random string for a Game ID.
-func GetRandomID() string {
-	id := ""
-	characters := []string{"A", "B", "C", "D", "E", "F", "G", "H", "J", "K", "L", "M", "N", "O", "P", "Q", "R", "S", "T", "U", "V", "W", "X", "Y", "Z", "a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "m", "n", "o", "p", "q", "r", "s", "t", "u", "v", "w", "x", "y", "z", "!", "#", "$", "@", "?", "-", "&", "1", "2", "3", "4", "5", "6", "7", "8", "9"}
-	n := len(characters)
-	rand.Seed(time.Now().UnixNano())
-	for i := 0; i < 5; i++ {
-		id += characters[rand.Intn(n)]
+// AnswerChoices builds a choice for each answer, with callback data like <Type>::<round>::<position>.
+func AnswerChoices(g *game.Game, Type string, Answers []game.Answer) []Choice {
+	choices := make([]Choice, len(Answers))
+	for i, a := range Answers {
+		choices[i] = Choice{a.Text, Type + "::" + strconv.Itoa(g.Round) + "::" + strconv.Itoa(i)}
 	}
-	return id
+	return choices
+}
+
+// CzarPrompt asks the czar to choose the best answer, or in Serious Business, the answer for the next place.
+func CzarPrompt(g *game.Game, Answers []game.Answer) string {
+	if g.Settings.Judging != game.RankedJudging {
+		return "Czar, please choose the best answer."
+	}
+	text := "Czar, please rank the best answers.\n"
+	for i, ID := range g.Ranked {
+		for _, a := range Answers {
+			if a.PlayerID == ID {
+				text += "\n" + Ordinal(i+1) + ": " + a.Text
+			}
+		}
+	}
+	if len(g.Ranked) != 0 {
+		text += "\n"
+	}
+	return text + "\nWhich answer is in " + Ordinal(len(g.Ranked)+1) + " place?"
+}
+
+// CzarChoices builds a choice for each answer the czar can choose.  In Serious Business, the answers they already
+// ranked are left out.
+func CzarChoices(g *game.Game, Answers []game.Answer) []Choice {
+	var choices []Choice
+	for i, c := range AnswerChoices(g, "CzarBest", Answers) {
+		if indexOf(g.Ranked, Answers[i].PlayerID) == -1 {
+			choices = append(choices, c)
+		}
+	}
+	return choices
 }
 
-// HandleCzarResponse handles a response from the card czar.
-func HandleCzarResponse(bot *CAHBot, GameID string, Message *tgbotapi.Message, Response string, CheckDigit int) {
-	if CheckDigit == -1 {
-		log.Printf("The text we received was not a valid answer.  We assume it was a message to the game so we are forwarding it.")
-		bot.ForwardMessageToGame(Message, GameID)
-	} else if CheckDigit == 0 {
-		log.Printf("GameID: %v - We encountered an error when trying to validate the Card Czar's choice.  We are reporting that error to the Card Czar.", GameID)
-		bot.SendActionFailedMessage(Message.Chat.ID)
-		log.Printf("GameID: %v - Asking the Czar to try again...", GameID)
-		bot.ListAnswers(GameID)
-	} else {
-		bot.CzarChoseAnswer(Message.Chat.ID, GameID, Message.Text, strings.Contains(Response, "best"))
+// Ordinal writes a place like 1st, 2nd or 3rd.
+func Ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
 	}
+	return strconv.Itoa(n) + suffix
 }
 
-// HandlePlayerResponse handles a response that is not a command from a player.
-func HandlePlayerResponse(bot *CAHBot, GameID string, Message *tgbotapi.Message, CheckDigit int, ThirdArg string, Handler func(int64, string, string)) {
-	if CheckDigit == -1 {
-		log.Printf("The text we received was not a valid answer.")
-		bot.SendActionFailedMessage(Message.Chat.ID)
-	} else if CheckDigit == 0 {
-		log.Printf("GameID: %v - Asking the player with ID %v to try again...", GameID, Message.From.ID)
-		message := tgbotapi.NewEditMessageText(Message.Chat.ID, Message.MessageID, "We encountered an error. Please try picking an answer again.")
-		message.ReplyMarkup = SetupInlineKeyboard(bot.Settings, 1)
-		bot.Send(message)
-	} else {
-		Handler(Message.Chat.ID, GameID, ThirdArg)
+// RankedText says how the czar ranked the answers and the Awesome Points each of them got.
+func RankedText(e game.AnswersRanked) string {
+	text := "The czar ranked the answers:\n"
+	if e.Random {
+		text = e.Czar.Name + " took too long, so the places they did not rank were picked at random:\n"
+	}
+	for i, place := range e.Places {
+		text += "\n" + Ordinal(i+1) + ": " + place.Answer + " - " + place.Player.Name
+		if !e.ForFun {
+			text += " (+" + strconv.Itoa(place.Points) + ")"
+		}
+	}
+	if e.ForFun {
+		text += "\n\nThis round is just for fun, so nobody scores."
+	}
+	return text
+}
+
+// ScoreHistoryText lists the Awesome Points the players won and lost in each round.  When more than one player
+// scored in a round, it also shows each one's share of the points won in it.
+func ScoreHistoryText(g *game.Game) string {
+	if len(g.History) == 0 {
+		return ""
+	}
+	text := "\nRound by round:\n"
+	for start := 0; start < len(g.History); {
+		end := start
+		won, scorers := 0, 0
+		for ; end < len(g.History) && g.History[end].Round == g.History[start].Round; end++ {
+			if g.History[end].Points > 0 {
+				won += g.History[end].Points
+				scorers++
+			}
+		}
+		var awards []string
+		for _, a := range g.History[start:end] {
+			name := "Someone"
+			if p := g.Player(a.PlayerID); p != nil {
+				name = p.Name
+			} else if a.PlayerID == game.MysteryID && g.Mystery != nil {
+				name = g.Mystery.Name
+			}
+			award := name + " " + strconv.Itoa(a.Points)
+			if a.Points > 0 {
+				award = name + " +" + strconv.Itoa(a.Points)
+			}
+			if a.Points > 0 && scorers > 1 {
+				award += " (" + Fraction(a.Points, won) + ")"
+			}
+			awards = append(awards, award)
+		}
+		text += "Round " + strconv.Itoa(g.History[start].Round) + ": " + strings.Join(awards, ", ") + "\n"
+		start = end
 	}
+	return text
 }
 
-// LastCharactorIsPunctuation checks to see if the last character of a string is punctuation.
-func LastCharactorIsPunctuation(TheString string) bool {
-	length := len(TheString) - 1
-	if string(TheString[length]) == "." || string(TheString[length]) == "!" || string(TheString[length]) == "?" {
-		return true
+// Fraction writes n/d in lowest terms.
+func Fraction(n, d int) string {
+	a, b := n, d
+	for b != 0 {
+		a, b = b, a%b
 	}
-	return false
+	return strconv.Itoa(n/a) + "/" + strconv.Itoa(d/a)
 }
 
-// SetupInlineKeyboard builds the inline keyboard to change a setting.
-func SetupInlineKeyboard(ButtonData []Setting, NumCols int) *tgbotapi.InlineKeyboardMarkup {
-	// Settings that need to support changing: Mystery Player, Trade In Cards, Number of Cards to Trade In, Number of Cards In Hand, Pick Worst Also, Points To Win.
-	settingIndex := 0
-	numSettings := len(ButtonData)
-	settingsKeyboard := make([][]tgbotapi.InlineKeyboardButton, (len(ButtonData)+NumCols-1)/NumCols)
-	for i := 0; i < len(settingsKeyboard) && settingIndex < numSettings; i++ {
-		settingsKeyboard[i] = make([]tgbotapi.InlineKeyboardButton, NumCols)
-		for j := 0; j < NumCols && settingIndex < numSettings; j++ {
-			settingsKeyboard[i][j] = tgbotapi.InlineKeyboardButton{Text: ButtonData[settingIndex].Name, CallbackData: &ButtonData[settingIndex].CData}
-			settingIndex++
+// VoteChoices builds a choice to vote for each answer but the voter's own.
+func VoteChoices(g *game.Game, Voter *game.Player, Answers []game.Answer) []Choice {
+	choices := make([]Choice, 0, len(Answers))
+	for i, c := range AnswerChoices(g, "Vote", Answers) {
+		if Answers[i].PlayerID != Voter.ID {
+			choices = append(choices, c)
 		}
 	}
-	return &tgbotapi.InlineKeyboardMarkup{InlineKeyboard: settingsKeyboard}
+	return choices
 }
 
-// SettingIsValid checks to see if we received valid setting from the user.
-func SettingIsValid(bot *CAHBot, Setting string) int {
+// EliminationText shows the answers of a round of Survival of the Fittest, with the ones that were eliminated crossed
+// out, and Status under them.  Left is the positions of the answers that are left.
+func EliminationText(Answers []game.Answer, Left []int, Status string) string {
+	text := "Survival of the Fittest: take turns eliminating answers until one is left.\n\n"
+	for i, a := range Answers {
+		if indexOf(Left, i) == -1 {
+			text += "\u274C " + a.Text + "\n"
+		} else {
+			text += a.Text + "\n"
+		}
+	}
+	return text + "\n" + Status
+}
 
-	return 0
+// TurnText says whose turn it is to eliminate an answer.
+func TurnText(Turn *game.Player) string {
+	return "It is " + Turn.Name + "'s turn to eliminate an answer."
 }
 
-// ShuffleAnswers shuffles the answers so they don't come out in the same order every time.
-func ShuffleAnswers(arr []string) []string {
-	rand.Seed(time.Now().UnixNano())
+// EliminationChoices builds a choice to eliminate each answer that is left, but a player's own, if it is their turn.
+func EliminationChoices(g *game.Game, p *game.Player, Answers []game.Answer, Left []int) []Choice {
+	if g.Phase != game.Eliminating || p.ID != g.Turn {
+		return nil
+	}
+	all := AnswerChoices(g, "Eliminate", Answers)
+	var choices []Choice
+	for _, i := range Left {
+		if Answers[i].PlayerID != p.ID {
+			choices = append(choices, all[i])
+		}
+	}
+	return choices
+}
+
+// VoteWonText says which answers won the vote.
+func VoteWonText(e game.VoteWon) string {
+	votes := strconv.Itoa(e.Votes) + " votes"
+	if e.Votes == 1 {
+		votes = "1 vote"
+	}
+	switch {
+	case len(e.Winners) == 0:
+		return "The votes are in!  It was a tie between answers with " + votes + " each, so nobody gets a point this round."
+	case e.Votes == 0 && e.Tie:
+		return "Nobody voted, so an answer was picked at random: " + e.Answers[0] + "\n\n" + WinnerText(e.Winners[0], e.ForFun)
+	case e.Votes == 0:
+		return "There was only one answer, so it wins: " + e.Answers[0] + "\n\n" + WinnerText(e.Winners[0], e.ForFun)
+	case len(e.Winners) == 1 && e.Tie:
+		return "The votes are in!  It was a tie, so this answer was picked at random from the ones with " + votes + ": " + e.Answers[0] + "\n\n" + WinnerText(e.Winners[0], e.ForFun)
+	case len(e.Winners) == 1:
+		return "The votes are in!  The best answer, with " + votes + ": " + e.Answers[0] + "\n\n" + WinnerText(e.Winners[0], e.ForFun)
+	}
+	text := "The votes are in!  It was a tie between the answers with " + votes + ", and each of them gets a point:\n"
+	if e.ForFun {
+		text = "The votes are in!  It was a tie between the answers with " + votes + ":\n"
+	}
+	for i, w := range e.Winners {
+		text += e.Answers[i] + " - " + w.Name + "\n"
+	}
+	return text
+}
+
+// WinnerText says whose answer won and that they get an Awesome Point, unless it was ForFun.
+func WinnerText(Winner *game.Player, ForFun bool) string {
+	if ForFun {
+		return "This was " + Winner.Name + "'s answer.  This round is just for fun, so nobody scores."
+	}
+	if Winner.ID == game.MysteryID {
+		return "This was the mystery player's answer!  " + Winner.Name + " gets one Awesome Point."
+	}
+	return "This was " + Winner.Name + "'s answer.  They get one Awesome Point!"
+}
+
+// TradeInMenu builds the menu a player picks the cards they trade in from.  The cards they picked are ticked.
+func TradeInMenu(g *game.Game, p *game.Player) (string, []Choice) {
+	text := "You can trade in up to " + strconv.Itoa(g.TradeLimit(p)) + " cards for new ones.  Pick the cards you want to get rid of, then trade them in."
+	data := "TradeInCard::" + strconv.Itoa(g.Round) + "::"
+	choices := make([]Choice, 0, len(p.Hand)+2)
+	for i, card := range g.Hand(p) {
+		mark := ""
+		for _, picked := range p.Trading {
+			if picked == p.Hand[i] {
+				mark = "\u2705 "
+			}
+		}
+		choices = append(choices, Choice{mark + card.String(), data + strconv.Itoa(p.Hand[i])})
+	}
+	if g.Settings.NumCardsToTrade == game.AllCards {
+		choices = append(choices, Choice{"Pick all my cards", data + "All"})
+	}
+	if len(p.Trading) == 0 {
+		return text, append(choices, Choice{"Keep my cards", data + "Done"})
+	}
+	return text, append(choices, Choice{"Trade in " + CountCards(len(p.Trading)), data + "Done"})
+}
 
-	for i := len(arr) - 1; i > 0; i-- {
-		j := rand.Intn(i)
-		arr[i], arr[j] = arr[j], arr[i]
+// CountCards says how many cards there are, like "1 card" or "3 cards".
+func CountCards(n int) string {
+	if n == 1 {
+		return "1 card"
 	}
-	return arr
+	return strconv.Itoa(n) + " cards"
 }
 
-// TrimPunctuation trims the punctuation on an answer to help the grammar.
-func TrimPunctuation(TheString string) string {
-	if !LastCharactorIsPunctuation(TheString) {
-		return TheString
+// YesNo turns a boolean setting into Yes or No.
+func YesNo(b bool) string {
+	if b {
+		return "Yes"
 	}
-	return TrimPunctuation(strings.TrimSuffix(strings.TrimSuffix(strings.TrimSuffix(TheString, "!"), "?"), "."))
+	return "No"
 }
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/thedadams/cahbot/game"
+	"github.com/thedadams/telegram-bot-api"
+)
+
+// LocalMessenger prints the messages for players that share one terminal.
+// Choices are printed as numbered menus.
+type LocalMessenger struct {
+	mu      sync.Mutex
+	Out     io.Writer
+	Names   map[int64]string
+	prompts map[int64]localPrompt
+	lastID  int
+}
+
+// localPrompt is the last menu a player was shown.
+type localPrompt struct {
+	MessageID int
+	Choices   []Choice
+}
+
+// NewLocalMessenger creates a LocalMessenger that prints to Out.
+// Names maps the chat of each player to the name that is printed with their messages.
+func NewLocalMessenger(Out io.Writer, Names map[int64]string) *LocalMessenger {
+	return &LocalMessenger{Out: Out, Names: Names, prompts: make(map[int64]localPrompt)}
+}
+
+// SendText prints a message for a player.
+func (l *LocalMessenger) SendText(ChatID int64, Text string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.print(ChatID, Text)
+	return nil
+}
+
+// SendChoices prints a message and a numbered menu for a player.
+func (l *LocalMessenger) SendChoices(ChatID int64, Text string, Choices []Choice) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	ID := l.nextID()
+	l.prompts[ChatID] = localPrompt{ID, Choices}
+	l.print(ChatID, Text)
+	l.printChoices(Choices)
+	return ID, nil
+}
+
+// EditChoices prints the new version of a menu.  It replaces the menu if it is the last one the player was shown.
+func (l *LocalMessenger) EditChoices(ChatID int64, MessageID int, Text string, Choices []Choice) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.prompts[ChatID].MessageID == MessageID {
+		l.prompts[ChatID] = localPrompt{MessageID, Choices}
+	}
+	l.print(ChatID, "(edited) "+Text)
+	l.printChoices(Choices)
+	return nil
+}
+
+// Relay prints a message from one player for another.
+func (l *LocalMessenger) Relay(ChatID, FromChatID int64, MessageID int, Text string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.print(ChatID, l.Names[FromChatID]+" says: "+Text)
+	return nil
+}
+
+// Choice returns the choice a player picked by number from the last menu they were shown, and the ID of that menu.
+func (l *LocalMessenger) Choice(ChatID int64, Number int) (Choice, int, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	prompt, ok := l.prompts[ChatID]
+	if !ok || Number < 1 || Number > len(prompt.Choices) {
+		return Choice{}, 0, false
+	}
+	return prompt.Choices[Number-1], prompt.MessageID, true
+}
+
+// NewMessageID returns an ID for a message typed by a player.
+func (l *LocalMessenger) NewMessageID() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.nextID()
+}
+
+func (l *LocalMessenger) nextID() int {
+	l.lastID++
+	return l.lastID
+}
+
+func (l *LocalMessenger) print(ChatID int64, Text string) {
+	fmt.Fprintf(l.Out, "[%v] %v\n", l.Names[ChatID], strings.Replace(strings.TrimRight(Text, "\n"), "\n", "\n    ", -1))
+}
+
+func (l *LocalMessenger) printChoices(Choices []Choice) {
+	for i, c := range Choices {
+		fmt.Fprintf(l.Out, "    %d) %v\n", i+1, c.Text)
+	}
+}
+
+// RunLocal runs the local command: every player plays from the same terminal and the games are kept in memory.
+// A line like "alice> /cards" is sent by alice.  A line without a name is sent by whoever sent the last line.
+// A number picks from the last menu that player was shown.  /reloadcards loads the cards in CardsDir again.
+func RunLocal(Args []string, Deck *game.Deck, CardsDir string, In io.Reader, Out io.Writer) error {
+	flags := flag.NewFlagSet("local", flag.ExitOnError)
+	players := flags.String("players", "alice,bob,carol", "comma separated names of the players")
+	verbose := flags.Bool("verbose", false, "show the bot's log")
+	flags.Parse(Args)
+	if !*verbose {
+		log.SetOutput(ioutil.Discard)
+	}
+
+	users := make(map[string]*tgbotapi.User)
+	names := make(map[int64]string)
+	var order []string
+	for _, name := range strings.Split(*players, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || users[name] != nil {
+			continue
+		}
+		users[name] = &tgbotapi.User{ID: len(order) + 1, FirstName: name}
+		names[int64(len(order)+1)] = name
+		order = append(order, name)
+	}
+	if len(order) == 0 {
+		return fmt.Errorf("usage: cahbot local --players alice,bob,carol")
+	}
+	messenger := NewLocalMessenger(Out, names)
+	bot, err := NewCAHBot(messenger, Deck, NewMemoryStore())
+	if err != nil {
+		return err
+	}
+	bot.CardsDir = CardsDir
+	stop := make(chan struct{})
+	defer close(stop)
+	go bot.CheckDeadlinesEvery(DeadlineInterval, stop)
+
+	fmt.Fprintf(Out, "Players: %v.  Type 'name> /command' to play as someone, or a number to pick from their last menu.\n", strings.Join(order, ", "))
+	current := order[0]
+	scanner := bufio.NewScanner(In)
+	for {
+		fmt.Fprintf(Out, "%v> ", current)
+		if !scanner.Scan() {
+			fmt.Fprintln(Out)
+			return scanner.Err()
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, ">"); i > 0 && !strings.ContainsAny(line[:i], " /") {
+			name := line[:i]
+			if users[name] == nil {
+				fmt.Fprintf(Out, "There is no player named %v.\n", name)
+				continue
+			}
+			current, line = name, strings.TrimSpace(line[i+1:])
+		}
+		if line == "" {
+			continue
+		}
+		user := users[current]
+		chat := &tgbotapi.Chat{ID: int64(user.ID), Type: "private"}
+		if number, err := strconv.Atoi(line); err == nil {
+			choice, messageID, ok := messenger.Choice(chat.ID, number)
+			if !ok {
+				fmt.Fprintf(Out, "%v does not have a choice numbered %v.\n", current, number)
+				continue
+			}
+			message := &tgbotapi.Message{MessageID: messageID, From: user, Chat: chat}
+			handleLocal(bot, Out, user, message, &tgbotapi.CallbackQuery{From: user, Message: message, Data: choice.Data}, "callback")
+			continue
+		}
+		message := &tgbotapi.Message{MessageID: messenger.NewMessageID(), From: user, Chat: chat, Text: line}
+		if strings.HasPrefix(line, "/") {
+			message.Entities = &[]tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: len(strings.Fields(line)[0])}}
+			handleLocal(bot, Out, user, message, nil, "command")
+		} else {
+			handleLocal(bot, Out, user, message, nil, "message")
+		}
+	}
+}
+
+// handleLocal handles a line typed by a player in order with the other work for their game, like its deadlines,
+// the same way serve handles an update.  It waits for the line to be handled, so that the next prompt comes after
+// the bot's answer.
+func handleLocal(bot *CAHBot, Out io.Writer, User *tgbotapi.User, Message *tgbotapi.Message, Callback *tgbotapi.CallbackQuery, messageType string) {
+	handled := make(chan struct{})
+	key := bot.UpdateKey(User, Message, messageType)
+	if !bot.Work.Dispatch(key, func() {
+		defer close(handled)
+		bot.HandleUpdate(User, Message, Callback, messageType)
+	}) {
+		fmt.Fprintf(Out, "There is a lot going on in your game right now.  Please try again in a moment.\n")
+		return
+	}
+	<-handled
+}
@@ -1,80 +1,151 @@
 package main
 
 import (
+	"flag"
 	"log"
 	"os"
-	"strconv"
-	"strings"
+	"os/signal"
+	"syscall"
 	"time"
-
-	"github.com/thedadams/telegram-bot-api"
 )
 
 func main() {
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
-	bot, err := NewCAHBot(os.Getenv("TOKEN"))
+	cardsDir := flag.String("cards-dir", os.Getenv("CARDS_DIR"), "a directory of *.json card packs to play with as well as the built-in cards")
+	apiEndpoint := flag.String("api-endpoint", os.Getenv("TELEGRAM_API_ENDPOINT"), "the URL of the Bot API server to use instead of Telegram's, for example http://localhost:8081")
+	flag.Parse()
+	Args := flag.Args()
+	// The cards command works with packs that may not load, so it loads them itself.
+	if len(Args) > 0 && Args[0] == "cards" {
+		if err := RunCards(Args[1:], *cardsDir, os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	Deck, err := LoadDeck(*cardsDir)
 	if err != nil {
 		log.Panic(err)
 	}
-	defer bot.DBConn.Close()
+	if len(Args) > 0 && Args[0] == "local" {
+		if err := RunLocal(Args[1:], Deck, *cardsDir, os.Stdin, os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	Store, err := NewPostgresStore(os.Getenv("DATABASE_URL"), Deck)
+	if err != nil {
+		log.Panic(err)
+	}
+	defer Store.Close()
+	if len(Args) > 0 && Args[0] == "migrate" {
+		if err := Migrate(Args[1:], Store.DB, Deck); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if err := CheckSchema(Store.DB); err != nil {
+		log.Panic(err)
+	}
+	api, err := NewTelegramAPI(os.Getenv("TOKEN"), *apiEndpoint)
+	if err != nil {
+		log.Printf("Error initializing bot: %v", err)
+		log.Panic(err)
+	}
+	bot, err := NewCAHBot(&TelegramMessenger{api}, Deck, Store)
+	if err != nil {
+		log.Panic(err)
+	}
+	bot.CardsDir = *cardsDir
 
 	// Remove when deployed
-	// bot.Debug = true
-
-	log.Printf("Authorized on account %s", bot.Self.UserName)
+	// api.Debug = true
 
-	u := tgbotapi.NewUpdate(0)
-	u.Timeout = 60
+	log.Printf("Authorized on account %s", api.Self.UserName)
 
-	updates, err := bot.GetUpdatesChan(u)
-	c := time.Tick(60 * time.Minute)
+	stop := make(chan struct{})
 	go func() {
-		for _ = range c {
-			log.Printf("Cleaning up old games.")
-			tx, err := bot.DBConn.Begin()
-			if err != nil {
-				log.Printf("ERROR: %v", err)
-				log.Printf("Failed to clean up old games.")
-				tx.Rollback()
-				continue
-			}
-			rows, err := tx.Query("SELECT clean_up_old_games()")
-			if err != nil {
-				log.Printf("ERROR: %v", err)
-				log.Printf("Failed to clean up old games.")
-				tx.Rollback()
-				rows.Close()
-				continue
-			}
-			for rows.Next() {
-				var GameID string
-				var UserID int64
-				if err := rows.Scan(&GameID); err != nil {
-					log.Printf("ERROR: %v", err)
-					log.Printf("Failed to clean up old games with id %v.", GameID)
-					continue
-				} else {
-					GameID = strings.Replace(strings.Replace(GameID, "(", "", 1), ")", "", 1)
-					UserID, _ = strconv.ParseInt(strings.Split(GameID, ",")[1], 10, 64)
-					GameID = strings.Split(GameID, ",")[0]
-					log.Printf("Game with id %v deleted.  Let user with id %v know about it.", GameID, UserID)
-					bot.Send(tgbotapi.NewMessage(UserID, "Your game has been deleted because of inactivity."))
-				}
-			}
-			if err := rows.Err(); err != nil {
-				log.Printf("ERROR: %v", err)
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		log.Printf("Received %v.  Shutting down.", <-signals)
+		close(stop)
+	}()
+	cleanedUp := make(chan struct{})
+	go func() {
+		bot.CleanUpOldGamesEvery(60*time.Minute, stop)
+		close(cleanedUp)
+	}()
+	checkedDeadlines := make(chan struct{})
+	go func() {
+		bot.CheckDeadlinesEvery(DeadlineInterval, stop)
+		close(checkedDeadlines)
+	}()
+
+	if len(Args) > 0 && Args[0] == "serve" {
+		Args = Args[1:]
+	} else if len(Args) > 0 {
+		log.Fatalf("unknown command %q: use serve, local, migrate or cards", Args[0])
+	}
+	if err := Serve(Args, bot, api, stop); err != nil {
+		log.Fatal(err)
+	}
+	<-cleanedUp
+	<-checkedDeadlines
+	log.Printf("Closing the database.")
+}
+
+// CleanUpOldGamesEvery deletes the games that have not been played for GameTimeout, and forgets the updates handled
+// before then, every Interval until Stop is closed.
+func (bot *CAHBot) CleanUpOldGamesEvery(Interval time.Duration, Stop <-chan struct{}) {
+	ticker := time.NewTicker(Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-Stop:
+			return
+		case <-ticker.C:
+		}
+		log.Printf("Cleaning up old games.")
+		games, err := bot.Store.CleanUpOldGames(time.Now().Add(-GameTimeout))
+		if err != nil {
+			log.Printf("ERROR: %v", err)
+			log.Printf("Failed to clean up old games.")
+			continue
+		}
+		for _, g := range games {
+			for _, p := range g.Players {
+				log.Printf("Game with id %v deleted.  Let user with id %v know about it.", g.ID, p.ID)
+				bot.Messenger.SendText(p.ChatID, "Your game has been deleted because of inactivity.")
 			}
-			rows.Close()
-			tx.Commit()
 		}
-	}()
+		if err := bot.Store.ForgetUpdates(time.Now().Add(-GameTimeout)); err != nil {
+			log.Printf("ERROR: %v", err)
+			log.Printf("Failed to forget old updates.")
+		}
+	}
+}
 
-	for update := range updates {
-		messageType := bot.DetectKindMessageReceived(update)
-		if messageType == "callback" {
-			go bot.HandleUpdate(update.CallbackQuery.From, update.CallbackQuery.Message, update.CallbackQuery, messageType)
-		} else {
-			go bot.HandleUpdate(update.Message.From, update.Message, update.CallbackQuery, messageType)
+// CheckDeadlinesEvery reminds the players that rounds are waiting for, and moves the rounds whose deadline has passed
+// on without them, every Interval until Stop is closed.  The games are ticked in order with their updates.
+func (bot *CAHBot) CheckDeadlinesEvery(Interval time.Duration, Stop <-chan struct{}) {
+	ticker := time.NewTicker(Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-Stop:
+			return
+		case <-ticker.C:
+		}
+		IDs, err := bot.Store.GamesDue(time.Now())
+		if err != nil {
+			log.Printf("ERROR: %v", err)
+			log.Printf("Failed to check the deadlines of games.")
+			continue
+		}
+		for _, ID := range IDs {
+			GameID := ID
+			if !bot.Work.Dispatch(GameID, func() { bot.TickGame(GameID) }) {
+				log.Printf("The queue for %v is full.  Checking its deadline later.", GameID)
+			}
 		}
 	}
 }
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thedadams/cahbot/game"
+)
+
+// MemoryStore keeps the users and games in memory.  Everything is lost when the bot stops.
+type MemoryStore struct {
+	mu      sync.Mutex
+	users   map[int]memoryUser
+	games   map[string]*memoryGame
+	offset  int
+	handled map[int]time.Time
+}
+
+type memoryUser struct {
+	ChatID                                     int64
+	FirstName, LastName, UserName, DisplayName string
+}
+
+type memoryGame struct {
+	g        *game.Game
+	modified time.Time
+	boards   map[int]int
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{users: make(map[int]memoryUser), games: make(map[string]*memoryGame), handled: make(map[int]time.Time)}
+}
+
+// AddUser adds a user, or updates their chat and names if they are already stored.
+func (s *MemoryStore) AddUser(UserID int, ChatID int64, FirstName, LastName, UserName, DisplayName string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[UserID] = memoryUser{ChatID, FirstName, LastName, UserName, DisplayName}
+	return nil
+}
+
+// RemoveUser forgets a user.
+func (s *MemoryStore) RemoveUser(UserID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.users, UserID)
+	return nil
+}
+
+// GameIDForUser returns the ID of the game a user is playing in, or "" if they are not in one.
+func (s *MemoryStore) GameIDForUser(UserID int) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ID, mg := range s.games {
+		if mg.g.Player(UserID) != nil {
+			return ID, nil
+		}
+	}
+	return "", nil
+}
+
+// GameExists checks to see if there is a game with the given ID.
+func (s *MemoryStore) GameExists(GameID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.games[GameID]
+	return ok, nil
+}
+
+// CreateGame stores a new game.
+func (s *MemoryStore) CreateGame(g *game.Game) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.games[g.ID] = &memoryGame{g.Clone(), time.Now(), make(map[int]int)}
+	return nil
+}
+
+// SetDeck replaces the deck of every game.
+func (s *MemoryStore) SetDeck(Deck *game.Deck) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, mg := range s.games {
+		mg.g.Deck = Deck
+	}
+}
+
+// LoadGame loads a game.  Changes to the game are not stored.
+func (s *MemoryStore) LoadGame(GameID string) (*game.Game, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	mg, ok := s.games[GameID]
+	if !ok {
+		return nil, ErrNoGame
+	}
+	return mg.g.Clone(), nil
+}
+
+// UpdateGame loads a game, lets Update change it and stores the changes.
+// The store is locked until the changes are stored.
+func (s *MemoryStore) UpdateGame(GameID string, Update func(*game.Game) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	mg, ok := s.games[GameID]
+	if !ok {
+		return ErrNoGame
+	}
+	g := mg.g.Clone()
+	if err := Update(g); err != nil {
+		return err
+	}
+	if g.Phase == game.Finished {
+		delete(s.games, GameID)
+	} else {
+		mg.g, mg.modified = g.Clone(), time.Now()
+	}
+	return nil
+}
+
+// ChatIDs returns the chat IDs of everyone playing in a game.
+func (s *MemoryStore) ChatIDs(GameID string) ([]int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	mg, ok := s.games[GameID]
+	if !ok {
+		return nil, ErrNoGame
+	}
+	IDs := make([]int64, len(mg.g.Players))
+	for i, p := range mg.g.Players {
+		IDs[i] = p.ChatID
+	}
+	return IDs, nil
+}
+
+// SaveBoard remembers the message that shows a player the answers being eliminated, so that it can be edited.
+func (s *MemoryStore) SaveBoard(GameID string, UserID, MessageID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p := s.player(GameID, UserID); p != nil {
+		s.games[GameID].boards[UserID] = MessageID
+	}
+	return nil
+}
+
+// Boards returns the messages that show the players of a game the answers being eliminated, by user ID.
+func (s *MemoryStore) Boards(GameID string) (map[int]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	mg, ok := s.games[GameID]
+	if !ok {
+		return nil, ErrNoGame
+	}
+	boards := make(map[int]int)
+	for _, p := range mg.g.Players {
+		if ID, ok := mg.boards[p.ID]; ok {
+			boards[p.ID] = ID
+		}
+	}
+	return boards, nil
+}
+
+// GamesDue returns the IDs of the games whose players are due a reminder, or whose deadline has passed, at Now.
+func (s *MemoryStore) GamesDue(Now time.Time) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var IDs []string
+	for ID, mg := range s.games {
+		if mg.g.Due(Now) {
+			IDs = append(IDs, ID)
+		}
+	}
+	return IDs, nil
+}
+
+// CleanUpOldGames deletes the games that have not been played since Before and returns them.
+func (s *MemoryStore) CleanUpOldGames(Before time.Time) ([]*game.Game, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var games []*game.Game
+	for ID, mg := range s.games {
+		if mg.modified.Before(Before) {
+			games = append(games, mg.g)
+			delete(s.games, ID)
+		}
+	}
+	return games, nil
+}
+
+// UpdateOffset returns the ID of the first update from Telegram that has not been confirmed, or 0.
+func (s *MemoryStore) UpdateOffset() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.offset, nil
+}
+
+// SaveUpdateOffset stores the ID of the first update from Telegram that has not been confirmed.
+func (s *MemoryStore) SaveUpdateOffset(Offset int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.offset = Offset
+	return nil
+}
+
+// UpdateHandled checks to see if an update from Telegram has already been handled.
+func (s *MemoryStore) UpdateHandled(UpdateID int) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.handled[UpdateID]
+	return ok, nil
+}
+
+// MarkUpdateHandled remembers that an update from Telegram has been handled.
+func (s *MemoryStore) MarkUpdateHandled(UpdateID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handled[UpdateID] = time.Now()
+	return nil
+}
+
+// ForgetUpdates forgets the updates that were handled before Before.
+func (s *MemoryStore) ForgetUpdates(Before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ID, handled := range s.handled {
+		if handled.Before(Before) {
+			delete(s.handled, ID)
+		}
+	}
+	return nil
+}
+
+// Close does nothing, because there is nothing to release.
+func (s *MemoryStore) Close() error {
+	return nil
+}
+
+func (s *MemoryStore) player(GameID string, UserID int) *game.Player {
+	mg, ok := s.games[GameID]
+	if !ok {
+		return nil
+	}
+	mg.modified = time.Now()
+	return mg.g.Player(UserID)
+}
@@ -0,0 +1,20 @@
+package main
+
+// Choice is one of the options a player can pick from a prompt.
+// Data is sent back to the bot when the player picks it.
+type Choice struct {
+	Text string
+	Data string
+}
+
+// Messenger is how the bot talks to players.
+type Messenger interface {
+	// SendText sends a message to a chat.
+	SendText(ChatID int64, Text string) error
+	// SendChoices sends a message with options for the player to pick from, and returns the ID of the message.
+	SendChoices(ChatID int64, Text string, Choices []Choice) (int, error)
+	// EditChoices replaces the text and options of a message that was already sent.
+	EditChoices(ChatID int64, MessageID int, Text string, Choices []Choice) error
+	// Relay passes a message from one player's chat on to another chat.
+	Relay(ChatID, FromChatID int64, MessageID int, Text string) error
+}
@@ -0,0 +1,75 @@
+package main
+
+import "sync"
+
+// Sent is a message that went through a RecordingMessenger.
+type Sent struct {
+	ChatID     int64
+	Text       string
+	Choices    []Choice
+	MessageID  int   // The message that was edited or relayed.
+	FromChatID int64 // The chat a relayed message came from.
+}
+
+// RecordingMessenger remembers everything sent through it instead of sending it.
+type RecordingMessenger struct {
+	mu   sync.Mutex
+	sent []Sent
+}
+
+// SendText records a message.
+func (r *RecordingMessenger) SendText(ChatID int64, Text string) error {
+	return r.record(Sent{ChatID: ChatID, Text: Text})
+}
+
+// SendChoices records a message with options.  Its ID is its position in All, counting from 1.
+func (r *RecordingMessenger) SendChoices(ChatID int64, Text string, Choices []Choice) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent = append(r.sent, Sent{ChatID: ChatID, Text: Text, Choices: Choices})
+	return len(r.sent), nil
+}
+
+// EditChoices records an edit to a message.
+func (r *RecordingMessenger) EditChoices(ChatID int64, MessageID int, Text string, Choices []Choice) error {
+	return r.record(Sent{ChatID: ChatID, Text: Text, Choices: Choices, MessageID: MessageID})
+}
+
+// Relay records a relayed message.
+func (r *RecordingMessenger) Relay(ChatID, FromChatID int64, MessageID int, Text string) error {
+	return r.record(Sent{ChatID: ChatID, Text: Text, MessageID: MessageID, FromChatID: FromChatID})
+}
+
+// All returns everything that was sent, in order.
+func (r *RecordingMessenger) All() []Sent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Sent(nil), r.sent...)
+}
+
+// To returns everything that was sent to a chat, in order.
+func (r *RecordingMessenger) To(ChatID int64) []Sent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var sent []Sent
+	for _, s := range r.sent {
+		if s.ChatID == ChatID {
+			sent = append(sent, s)
+		}
+	}
+	return sent
+}
+
+// Reset forgets everything that was sent.
+func (r *RecordingMessenger) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent = nil
+}
+
+func (r *RecordingMessenger) record(s Sent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent = append(r.sent, s)
+	return nil
+}
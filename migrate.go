@@ -0,0 +1,214 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/thedadams/cahbot/game"
+)
+
+// Migration is a numbered change to the database schema.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+	// Cards is whether the SQL uses the deck_cards table, which has the position and ID of every card in the deck.
+	Cards bool
+}
+
+// Migrations are the changes to the database schema, in order.
+// The bot will not start unless all of them have been applied.
+var Migrations = []Migration{
+	{1, "baseline", baselineTables + baselineFunctions, dropBaselineFunctions + dropBaselineTables, false},
+	{2, "game engine", gameEngineUp, gameEngineDown, false},
+	{3, "update tracking", updateTrackingUp, updateTrackingDown, false},
+	{4, "trade ins", tradeInsUp, tradeInsDown, false},
+	{5, "mystery player", mysteryPlayerUp, mysteryPlayerDown, false},
+	{6, "pick worst", pickWorstUp, pickWorstDown, false},
+	{7, "expansion packs", expansionPacksUp, expansionPacksDown, false},
+	{8, "card ids", cardIDsUp, cardIDsDown, true},
+	{9, "blank cards", blankCardsUp, blankCardsDown, false},
+	{10, "deadlines", deadlinesUp, deadlinesDown, false},
+	{11, "czarless voting", czarlessUp, czarlessDown, false},
+	{12, "house rules", houseRulesUp, houseRulesDown, false},
+	{13, "survival of the fittest", survivalUp, survivalDown, false},
+	{14, "serious business", seriousBusinessUp, seriousBusinessDown, false},
+}
+
+// SchemaVersion is the version of the schema that this build of the bot needs.
+var SchemaVersion = Migrations[len(Migrations)-1].Version
+
+// Migrate runs the migrate command: migrate up, migrate down or migrate status.
+// Deck is the deck the bot plays with, for the migrations that change how cards are stored.
+func Migrate(Args []string, db *sql.DB, Deck *game.Deck) error {
+	if len(Args) != 1 {
+		return fmt.Errorf("usage: %v migrate up|down|status", os.Args[0])
+	}
+	if err := createMigrationsTable(db); err != nil {
+		return err
+	}
+	switch Args[0] {
+	case "up":
+		return MigrateUp(db, Deck)
+	case "down":
+		return MigrateDown(db, Deck)
+	case "status":
+		return MigrationStatus(db)
+	}
+	return fmt.Errorf("unknown migrate command %q: use up, down or status", Args[0])
+}
+
+// MigrateUp applies every migration that has not been applied.
+func MigrateUp(db *sql.DB, Deck *game.Deck) error {
+	current, err := CurrentSchemaVersion(db)
+	if err != nil {
+		return err
+	}
+	for _, m := range Migrations {
+		if m.Version <= current {
+			continue
+		}
+		log.Printf("Applying migration %v: %v.", m.Version, m.Name)
+		if err = applyMigration(db, m, m.Up, Deck, "INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, NOW())", m.Version, m.Name); err != nil {
+			return fmt.Errorf("migration %v (%v): %v", m.Version, m.Name, err)
+		}
+	}
+	log.Printf("The database schema is at version %v.", SchemaVersion)
+	return nil
+}
+
+// MigrateDown undoes the last migration that was applied.
+func MigrateDown(db *sql.DB, Deck *game.Deck) error {
+	current, err := CurrentSchemaVersion(db)
+	if err != nil {
+		return err
+	}
+	for i := len(Migrations) - 1; i >= 0; i-- {
+		m := Migrations[i]
+		if m.Version != current {
+			continue
+		}
+		log.Printf("Undoing migration %v: %v.", m.Version, m.Name)
+		if err = applyMigration(db, m, m.Down, Deck, "DELETE FROM schema_migrations WHERE version = $1", m.Version); err != nil {
+			return fmt.Errorf("migration %v (%v): %v", m.Version, m.Name, err)
+		}
+		return nil
+	}
+	log.Printf("There are no migrations to undo.")
+	return nil
+}
+
+// MigrationStatus prints which migrations have been applied.
+func MigrationStatus(db *sql.DB) error {
+	current, err := CurrentSchemaVersion(db)
+	if err != nil {
+		return err
+	}
+	for _, m := range Migrations {
+		status := "pending"
+		if m.Version <= current {
+			status = "applied"
+		}
+		fmt.Printf("%4d  %-20s %v\n", m.Version, m.Name, status)
+	}
+	return nil
+}
+
+// CurrentSchemaVersion returns the version of the last migration applied to the database.
+func CurrentSchemaVersion(db *sql.DB) (int, error) {
+	var exists bool
+	err := db.QueryRow("SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists)
+	if err != nil || !exists {
+		return 0, err
+	}
+	var version int
+	err = db.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version)
+	return version, err
+}
+
+// CheckSchema returns an error if the database schema is not the version this build of the bot needs.
+func CheckSchema(db *sql.DB) error {
+	current, err := CurrentSchemaVersion(db)
+	if err != nil {
+		return err
+	}
+	if current < SchemaVersion {
+		return fmt.Errorf("the database schema is at version %v, but version %v is needed: run '%v migrate up'", current, SchemaVersion, os.Args[0])
+	}
+	if current > SchemaVersion {
+		return fmt.Errorf("the database schema is at version %v, which is newer than version %v that this build of the bot knows", current, SchemaVersion)
+	}
+	return nil
+}
+
+// createMigrationsTable creates the table that tracks the migrations.
+// A database that was set up by hand before migrations were tracked is marked as being at the baseline.
+func createMigrationsTable(db *sql.DB) error {
+	var exists, baseline bool
+	err := db.QueryRow("SELECT to_regclass('schema_migrations') IS NOT NULL, to_regclass('games') IS NOT NULL").Scan(&exists, &baseline)
+	if err != nil || exists {
+		return err
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	_, err = tx.Exec("CREATE TABLE schema_migrations (version integer NOT NULL PRIMARY KEY, name text NOT NULL, applied_at timestamp without time zone NOT NULL)")
+	if err != nil {
+		return err
+	}
+	if baseline {
+		log.Printf("The database was set up by hand.  Marking it as being at migration 1.")
+		_, err = tx.Exec("INSERT INTO schema_migrations (version, name, applied_at) VALUES (1, $1, NOW())", Migrations[0].Name)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// applyMigration runs the SQL for a migration and records it in one transaction.
+func applyMigration(db *sql.DB, m Migration, SQL string, Deck *game.Deck, Record string, Args ...interface{}) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if m.Cards {
+		if err = createDeckCards(tx, Deck); err != nil {
+			return err
+		}
+	}
+	if _, err = tx.Exec(SQL); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(Record, Args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// createDeckCards creates the deck_cards table for a migration.  Games used to refer to a card by its position in
+// the deck, counting from 0, so the table has the position of every card in Deck as well as its ID.
+func createDeckCards(tx *sql.Tx, Deck *game.Deck) error {
+	_, err := tx.Exec("CREATE TEMPORARY TABLE deck_cards (question boolean NOT NULL, position integer NOT NULL, id integer NOT NULL) ON COMMIT DROP")
+	if err != nil {
+		return err
+	}
+	questions := make([]int, len(Deck.Questions))
+	for i, q := range Deck.Questions {
+		questions[i] = q.ID
+	}
+	answers := make([]int, len(Deck.Answers))
+	for i, a := range Deck.Answers {
+		answers[i] = a.ID
+	}
+	_, err = tx.Exec(`INSERT INTO deck_cards (question, position, id)
+		SELECT true, n - 1, id FROM unnest($1::integer[]) WITH ORDINALITY AS q(id, n)
+		UNION ALL SELECT false, n - 1, id FROM unnest($2::integer[]) WITH ORDINALITY AS a(id, n)`, toArray(questions), toArray(answers))
+	return err
+}
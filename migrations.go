@@ -1,34 +1,41 @@
-CREATE TABLE games (id character(5) NOT NULL, answer_cards integer[], question_cards integer[], q_cards_left integer, a_cards_left integer, czar_order integer[], current_czar integer, current_q_card integer, in_round boolean, waiting_for_answers boolean, mystery_player boolean, trade_in_cards boolean, num_cards_to_trade integer, pick_worst boolean, num_cards_in_hand integer, points_to_win integer, last_modified timestamp without time zone);
+package main
 
+// The SQL for the migrations in Migrations.  Released migrations must never be changed; add a new one instead.
 
-ALTER TABLE ONLY games ADD CONSTRAINT games_p_key PRIMARY KEY (id);
+// baselineTables is the schema that was set up by hand before migrations were tracked.
+const baselineTables = `CREATE TABLE games (id character(5) NOT NULL, answer_cards integer[], question_cards integer[], q_cards_left integer, a_cards_left integer, czar_order integer[], current_czar integer, current_q_card integer, in_round boolean, waiting_for_answers boolean, mystery_player boolean, trade_in_cards boolean, num_cards_to_trade integer, pick_worst boolean, num_cards_in_hand integer, points_to_win integer, last_modified timestamp without time zone);
 
+ALTER TABLE ONLY games ADD CONSTRAINT games_p_key PRIMARY KEY (id);
 
 CREATE TABLE users (id integer NOT NULL, chat_id bigint NOT NULL, first_name character varying(32), last_name character varying(32), username character varying(32), points integer, cards_in_hand integer[], current_answer text, display_name character varying(64), waiting_for_response character varying(8), setting_status character varying(8));
 
-
 ALTER TABLE ONLY users ADD CONSTRAINT users_p_key PRIMARY KEY (id);
 
-
 ALTER TABLE ONLY games ADD CONSTRAINT games_current_czar_f_key FOREIGN KEY (current_czar) REFERENCES users(id);
 
-
 CREATE TABLE players (game_id character(5) NOT NULL, user_id integer NOT NULL);
 
-
 ALTER TABLE ONLY players ADD CONSTRAINT players_p_key PRIMARY KEY (game_id, user_id);
 
-
 ALTER TABLE ONLY players ADD CONSTRAINT players_game_id_f_key FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE;
 
-
 ALTER TABLE ONLY players ADD CONSTRAINT players_user_id_f_key FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
 
+CREATE EXTENSION IF NOT EXISTS intarray;
 
-CREATE EXTENSION intarray;
+CREATE OR REPLACE FUNCTION update_timestamp() RETURNS trigger AS $$
+BEGIN
+NEW.last_modified := transaction_timestamp();
+RETURN NEW;
+END;
+$$ LANGUAGE plpgsql VOLATILE;
 
+CREATE TRIGGER update_timestamp BEFORE INSERT OR UPDATE ON games
+FOR EACH ROW EXECUTE PROCEDURE update_timestamp();
+`
 
-CREATE OR REPLACE FUNCTION shuffle_answer_cards(game_id char(5)) RETURNS void AS $$
+// baselineFunctions are the stored functions that the game used before the rules moved into the game package.
+const baselineFunctions = `CREATE OR REPLACE FUNCTION shuffle_answer_cards(game_id char(5)) RETURNS void AS $$
 DECLARE arr integer[];
 DECLARE tmp integer;
 DECLARE rand integer;
@@ -44,7 +51,6 @@ UPDATE games SET (answer_cards, a_cards_left) = (arr, icount(arr)) WHERE games.i
 END;
 $$ LANGUAGE plpgsql VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION shuffle_question_cards(game_id char(5)) RETURNS void AS $$
 DECLARE arr integer[];
 DECLARE tmp integer;
@@ -61,7 +67,6 @@ UPDATE games SET (question_cards, q_cards_left) = (arr, icount(arr)) WHERE games
 END;
 $$ LANGUAGE plpgsql VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION add_cards_to_all_in_game(game_id char(5), num_cards integer) RETURNS void AS $$
 DECLARE id players.user_id%TYPE;
 BEGIN
@@ -71,13 +76,11 @@ END LOOP;
 END;
 $$ LANGUAGE plpgsql VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION add_cards_to_user_hand(user_id integer, num_cards integer) RETURNS void AS $$
 UPDATE users SET cards_in_hand = cards_in_hand + subarray(games.answer_cards, games.a_cards_left - num_cards + 1, num_cards) FROM games, players WHERE games.id = players.game_id AND players.user_id = users.id AND users.id = user_id;
 UPDATE games SET a_cards_left = a_cards_left - num_cards FROM players WHERE games.id = players.game_id AND players.user_id = user_id;
 $$ LANGUAGE SQL VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION add_game(game_id char(5), q_cards integer[], a_cards integer[], user_create_id integer) RETURNS void AS $$
 BEGIN
 INSERT INTO games(id, question_cards, answer_cards, q_cards_left, a_cards_left, czar_order, current_czar, current_q_card, waiting_for_answers, mystery_player, trade_in_cards, num_cards_to_trade, pick_worst, num_cards_in_hand, points_to_win, last_modified, in_round) VALUES(game_id, q_cards, a_cards, array_length(q_cards, 1), array_length(a_cards, 1), '{}', user_create_id, -1, false, false, false, 0, false, 7, 7, transaction_timestamp(), false);
@@ -86,7 +89,6 @@ PERFORM shuffle_question_cards(game_id);
 END;
 $$ LANGUAGE plpgsql VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION add_player_to_game(game_id char(5), user_id integer) RETURNS void AS $$
 DECLARE game_info record;
 BEGIN
@@ -101,18 +103,14 @@ UPDATE games SET (czar_order, a_cards_left) = (czar_order + user_id, game_info.a
 END;
 $$ LANGUAGE plpgsql VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION add_user(user_id integer, chat_id bigint, first_name varchar(32), last_name varchar(32), username varchar(32), display_name varchar(64)) RETURNS void AS $$
 INSERT INTO users (id, chat_id, first_name, last_name, username, display_name, points, cards_in_hand, current_answer, waiting_for_response, setting_status) VALUES(add_user.user_id, add_user.chat_id, add_user.first_name, add_user.last_name,add_user. username, add_user.display_name, 0, NULL, '', '', '');
 $$ LANGUAGE SQL VOLATILE;
 
-
-
 CREATE OR REPLACE FUNCTION change_pick_worst_setting(game_id char(5), change_to boolean) RETURNS VOID AS $$
 UPDATE games SET pick_worst = change_to WHERE id = game_id;
 $$ LANGUAGE SQL VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION check_game_exists(game_id char(5)) RETURNS boolean as $$
 DECLARE id TEXT;
 BEGIN
@@ -121,7 +119,6 @@ RETURN (id != '');
 END;
 $$ LANGUAGE plpgsql VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION clean_up_old_games() RETURNS TABLE(game_id char(5), user_id integer) AS $$
 BEGIN
 RETURN QUERY
@@ -132,6 +129,14 @@ END LOOP;
 END;
 $$ LANGUAGE plpgsql VOLATILE;
 
+CREATE OR REPLACE FUNCTION czar_chat_id(game_id char(5), status varchar(8)) RETURNS bigint AS $$
+DECLARE czar_chat bigint;
+BEGIN
+SELECT users.chat_id INTO czar_chat FROM games, users WHERE games.id = czar_chat_id.game_id AND users.id = games.current_czar;
+PERFORM update_user_status(games.current_czar, status, '') FROM games WHERE games.id = czar_chat_id.game_id;
+RETURN czar_chat;
+END;
+$$ LANGUAGE plpgsql VOLATILE;
 
 CREATE OR REPLACE FUNCTION czar_chose_answer(game_id char(5), answer text) RETURNS text[] AS $$
 DECLARE ans record;
@@ -155,8 +160,6 @@ RETURN info;
 END;
 $$ LANGUAGE plpgsql VOLATILE;
 
-
-
 CREATE OR REPLACE FUNCTION czar_id(game_id char(5), status varchar(8)) RETURNS integer AS $$
 DECLARE czar_id integer;
 BEGIN
@@ -166,7 +169,6 @@ RETURN czar_id;
 END;
 $$ LANGUAGE plpgsql VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION do_we_have_all_answers(game_id char(5)) RETURNS integer AS $$
 DECLARE ans users.current_answer%TYPE;
 BEGIN
@@ -181,7 +183,6 @@ RETURN 1;
 END;
 $$ LANGUAGE plpgsql VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION does_user_exist(user_id integer) RETURNS boolean as $$
 DECLARE id int = -1;
 BEGIN
@@ -190,7 +191,6 @@ RETURN (id != -1);
 END;
 $$ LANGUAGE plpgsql VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION end_game(game_id char(5)) RETURNS TABLE(name varchar(64), points text) AS $$
 BEGIN
 RETURN QUERY
@@ -200,13 +200,11 @@ DELETE FROM games WHERE games.id = end_game.game_id;
 END;
 $$ LANGUAGE plpgsql VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION end_round(game_id char(5)) RETURNS void AS $$
 UPDATE games SET (current_q_card, current_czar, waiting_for_answers, in_round) = (-1, czar_order[((idx(czar_order, current_czar) + icount(czar_order)) % (icount(czar_order))) + 1], false, false) WHERE games.id = game_id;
 UPDATE users SET current_answer = '' FROM players WHERE players.game_id = game_id AND players.user_id = users.id;
 $$ LANGUAGE SQL VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION game_settings(game_id char(5)) RETURNS text[] AS $$
 DECLARE settings text[];
 DECLARE ans record;
@@ -222,7 +220,6 @@ RETURN settings;
 END;
 $$ LANGUAGE plpgsql VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION get_answers(game_id char(5)) RETURNS text[] AS $$
 DECLARE answers text[];
 DECLARE num_answers int = 1;
@@ -236,17 +233,14 @@ RETURN answers;
 END;
 $$ LANGUAGE plpgsql VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION get_current_answer(user_id integer) RETURNS text AS $$
 SELECT current_answer FROM users WHERE users.id = user_id;
 $$ LANGUAGE SQL VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION get_display_name(user_id integer) RETURNS text AS $$
 SELECT display_name FROM users WHERE id = user_id;
 $$ LANGUAGE SQL VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION get_game_id(user_id integer, chat_id bigint) RETURNS character(5) AS $$
 DECLARE c_id bigint;
 DECLARE g_id character(5);
@@ -260,7 +254,6 @@ RETURN g_id;
 END;
 $$ LANGUAGE plpgsql VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION get_player_scores(game_id char(5)) RETURNS TABLE(display_name varchar(64), points text) AS $$
 SELECT users.display_name, users.points::text FROM users, players WHERE players.game_id = game_id AND players.user_id = users.id;
 $$ LANGUAGE SQL VOLATILE;
@@ -273,35 +266,29 @@ CREATE OR REPLACE FUNCTION get_user_cards(user_id integer) RETURNS integer[] AS
 SELECT cards_in_hand FROM users WHERE id = user_id;
 $$ LANGUAGE SQL VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION get_user_setting_status(user_id integer) RETURNS varchar(8) as $$
 SELECT setting_status FROM users WHERE id = user_id;
 $$ LANGUAGE SQL VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION get_user_status(user_id integer) RETURNS text as $$
 SELECT waiting_for_response FROM users WHERE id = user_id;
 $$ LANGUAGE SQL VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION get_chat_ids_for_game(game_id char(5)) RETURNS SETOF bigint
 AS $$
 SELECT users.chat_id FROM players, users WHERE players.game_id = game_id AND users.id = players.user_id;
 $$ LANGUAGE SQL VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION get_user_ids_for_game(game_id char(5)) RETURNS SETOF integer
 AS $$
 SELECT players.user_id FROM players, users WHERE players.game_id = game_id AND users.id = players.user_id;
 $$ LANGUAGE SQL VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION get_user_ids_we_need_answer(game_id char(5)) RETURNS SETOF integer AS $$
 UPDATE users SET waiting_for_response = 'answer' FROM players, games WHERE users.id = players.user_id AND players.game_id = game_id AND users.current_answer = '' AND users.id != games.current_czar AND games.id = game_id;
 SELECT players.user_id FROM players, users WHERE players.game_id = game_id AND users.id = players.user_id AND users.waiting_for_response = 'answer';
 $$ LANGUAGE SQL VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION is_player_in_game(user_id integer, game_id char(5)) RETURNS boolean AS $$
 DECLARE num int;
 BEGIN
@@ -310,17 +297,14 @@ RETURN (num != 0);
 END;
 $$ LANGUAGE plpgsql VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION is_game_in_round(game_id char(5)) RETURNS boolean AS $$
 SELECT in_round FROM games WHERE id = game_id;
 $$ LANGUAGE SQL VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION num_players_in_game(game_id char(5)) RETURNS bigint AS $$
 SELECT COUNT(*) FROM players WHERE players.game_id = game_id;
 $$ LANGUAGE SQL VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION received_answer_from_user(user_id integer, answer_index integer, answer text, finished boolean) RETURNS void AS $$
 DECLARE user_cards integer[];
 BEGIN
@@ -333,7 +317,6 @@ END IF;
 END;
 $$ LANGUAGE plpgsql VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION remove_player_from_game(user_id integer) RETURNS TABLE(name varchar(64), points text) AS $$
 DECLARE czar_array int[];
 DECLARE czar int;
@@ -351,12 +334,10 @@ DELETE FROM players WHERE players.user_id = remove_player_from_game.user_id;
 END;
 $$ LANGUAGE plpgsql VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION remove_user(user_id integer) RETURNS void AS $$
 DELETE FROM users WHERE id = user_id;
 $$ LANGUAGE SQL VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION start_round(game_id char(5)) RETURNS SETOF integer AS $$
 DECLARE status users%ROWTYPE;
 BEGIN
@@ -372,29 +353,210 @@ RETURN QUERY SELECT "get_user_ids_we_need_answer"(game_id);
 END;
 $$ LANGUAGE plpgsql VOLATILE;
 
-
-CREATE OR REPLACE FUNCTION update_timestamp() RETURNS trigger AS $$
-BEGIN
-NEW.last_modified := transaction_timestamp();
-RETURN NEW;
-END;
-$$ LANGUAGE plpgsql VOLATILE;
-
-
-CREATE TRIGGER update_timestamp BEFORE INSERT OR UPDATE ON games
-FOR EACH ROW EXECUTE PROCEDURE update_timestamp();
-
-
 CREATE OR REPLACE FUNCTION waiting_for_answers(game_id char(5)) RETURNS boolean AS $$
 SELECT waiting_for_answers FROM games WHERE id = game_id;
 $$ LANGUAGE SQL VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION who_is_czar(game_id char(5)) RETURNS text AS $$
 SELECT users.display_name FROM players, games, users WHERE games.id = game_id AND players.game_id = games.id AND players.user_id = games.current_czar AND users.id = players.user_id;
 $$ LANGUAGE SQL VOLATILE;
 
-
 CREATE OR REPLACE FUNCTION update_user_status(user_id integer, response_status varchar(8), user_setting_status varchar(8)) RETURNS void AS $$
 UPDATE users SET (waiting_for_response, setting_status) = (response_status, user_setting_status) WHERE users.id = user_id;
-$$ LANGUAGE SQL VOLATILE;
\ No newline at end of file
+$$ LANGUAGE SQL VOLATILE;
+`
+
+// dropBaselineFunctions drops the functions in baselineFunctions.
+const dropBaselineFunctions = `DROP FUNCTION IF EXISTS shuffle_answer_cards(char);
+DROP FUNCTION IF EXISTS shuffle_question_cards(char);
+DROP FUNCTION IF EXISTS add_cards_to_all_in_game(char, integer);
+DROP FUNCTION IF EXISTS add_cards_to_user_hand(integer, integer);
+DROP FUNCTION IF EXISTS add_game(char, integer[], integer[], integer);
+DROP FUNCTION IF EXISTS add_player_to_game(char, integer);
+DROP FUNCTION IF EXISTS add_user(integer, bigint, varchar, varchar, varchar, varchar);
+DROP FUNCTION IF EXISTS change_pick_worst_setting(char, boolean);
+DROP FUNCTION IF EXISTS check_game_exists(char);
+DROP FUNCTION IF EXISTS clean_up_old_games();
+DROP FUNCTION IF EXISTS czar_chat_id(char, varchar);
+DROP FUNCTION IF EXISTS czar_chose_answer(char, text);
+DROP FUNCTION IF EXISTS czar_id(char, varchar);
+DROP FUNCTION IF EXISTS do_we_have_all_answers(char);
+DROP FUNCTION IF EXISTS does_user_exist(integer);
+DROP FUNCTION IF EXISTS end_game(char);
+DROP FUNCTION IF EXISTS end_round(char);
+DROP FUNCTION IF EXISTS game_settings(char);
+DROP FUNCTION IF EXISTS get_answers(char);
+DROP FUNCTION IF EXISTS get_current_answer(integer);
+DROP FUNCTION IF EXISTS get_display_name(integer);
+DROP FUNCTION IF EXISTS get_game_id(integer, bigint);
+DROP FUNCTION IF EXISTS get_player_scores(char);
+DROP FUNCTION IF EXISTS get_question_card(char);
+DROP FUNCTION IF EXISTS get_user_cards(integer);
+DROP FUNCTION IF EXISTS get_user_setting_status(integer);
+DROP FUNCTION IF EXISTS get_user_status(integer);
+DROP FUNCTION IF EXISTS get_chat_ids_for_game(char);
+DROP FUNCTION IF EXISTS get_user_ids_for_game(char);
+DROP FUNCTION IF EXISTS get_user_ids_we_need_answer(char);
+DROP FUNCTION IF EXISTS is_player_in_game(integer, char);
+DROP FUNCTION IF EXISTS is_game_in_round(char);
+DROP FUNCTION IF EXISTS num_players_in_game(char);
+DROP FUNCTION IF EXISTS received_answer_from_user(integer, integer, text, boolean);
+DROP FUNCTION IF EXISTS remove_player_from_game(integer);
+DROP FUNCTION IF EXISTS remove_user(integer);
+DROP FUNCTION IF EXISTS start_round(char);
+DROP FUNCTION IF EXISTS waiting_for_answers(char);
+DROP FUNCTION IF EXISTS who_is_czar(char);
+DROP FUNCTION IF EXISTS update_user_status(integer, varchar, varchar);
+`
+
+// dropBaselineTables undoes baselineTables.
+const dropBaselineTables = `DROP TABLE IF EXISTS players;
+DROP TABLE IF EXISTS games;
+DROP TABLE IF EXISTS users;
+DROP FUNCTION IF EXISTS update_timestamp();
+`
+
+// gameEngineUp adds the state the game package keeps and drops the functions it replaced.
+// Rounds that are in progress cannot be carried over, so they are reset.
+const gameEngineUp = `ALTER TABLE games ADD COLUMN phase integer, ADD COLUMN answer_order integer[], ADD COLUMN discard_cards integer[];
+ALTER TABLE users ADD COLUMN played_cards integer[];
+UPDATE games SET (phase, current_q_card, in_round, waiting_for_answers, answer_order, discard_cards) = (0, -1, false, false, '{}', '{}');
+UPDATE users SET (played_cards, current_answer, waiting_for_response) = ('{}', '', '');
+` + dropBaselineFunctions
+
+// gameEngineDown undoes gameEngineUp.
+const gameEngineDown = `ALTER TABLE games DROP COLUMN phase, DROP COLUMN answer_order, DROP COLUMN discard_cards;
+ALTER TABLE users DROP COLUMN played_cards;
+` + baselineFunctions
+
+// updateTrackingUp stores the round of each game and which updates from Telegram have been handled.
+const updateTrackingUp = `ALTER TABLE games ADD COLUMN round integer NOT NULL DEFAULT 0;
+CREATE TABLE handled_updates (update_id bigint NOT NULL PRIMARY KEY, handled_at timestamp without time zone NOT NULL);
+CREATE INDEX handled_updates_handled_at ON handled_updates (handled_at);
+CREATE TABLE bot_state (name text NOT NULL PRIMARY KEY, value bigint NOT NULL);
+`
+
+// updateTrackingDown undoes updateTrackingUp.
+const updateTrackingDown = `DROP TABLE bot_state;
+DROP TABLE handled_updates;
+ALTER TABLE games DROP COLUMN round;
+`
+
+// tradeInsUp stores the cards each player picked to trade in.
+const tradeInsUp = `ALTER TABLE users ADD COLUMN trading_cards integer[] NOT NULL DEFAULT '{}', ADD COLUMN traded_cards boolean NOT NULL DEFAULT false;
+`
+
+// tradeInsDown undoes tradeInsUp.
+const tradeInsDown = `ALTER TABLE users DROP COLUMN trading_cards, DROP COLUMN traded_cards;
+`
+
+// mysteryPlayerUp stores the mystery player of each game.  The points are NULL until the mystery player first plays.
+const mysteryPlayerUp = `ALTER TABLE games ADD COLUMN mystery_points integer, ADD COLUMN mystery_played integer[] NOT NULL DEFAULT '{}', ADD COLUMN mystery_answer text NOT NULL DEFAULT '';
+`
+
+// mysteryPlayerDown undoes mysteryPlayerUp.
+const mysteryPlayerDown = `ALTER TABLE games DROP COLUMN mystery_points, DROP COLUMN mystery_played, DROP COLUMN mystery_answer;
+`
+
+// pickWorstUp stores whose answer the czar picked as the best while they pick the worst.
+const pickWorstUp = `ALTER TABLE games ADD COLUMN best_player integer NOT NULL DEFAULT 0;
+`
+
+// pickWorstDown undoes pickWorstUp.
+const pickWorstDown = `ALTER TABLE games DROP COLUMN best_player;
+`
+
+// expansionPacksUp stores the expansion packs each game leaves out.
+const expansionPacksUp = `ALTER TABLE games ADD COLUMN excluded_packs text[] NOT NULL DEFAULT '{}';
+`
+
+// expansionPacksDown undoes expansionPacksUp.
+const expansionPacksDown = `ALTER TABLE games DROP COLUMN excluded_packs;
+`
+
+// cardIDsUp makes games refer to cards by their IDs instead of their positions in the deck.
+// Cards that are no longer in the deck are taken out.
+const cardIDsUp = `CREATE OR REPLACE FUNCTION pg_temp.card_ids(positions integer[], question boolean) RETURNS integer[] AS $$
+SELECT COALESCE(array_agg(c.id ORDER BY p.n), '{}') FROM unnest($1) WITH ORDINALITY AS p(position, n) JOIN deck_cards c ON c.question = $2 AND c.position = p.position
+$$ LANGUAGE sql;
+UPDATE games SET (question_cards, q_cards_left, answer_cards, a_cards_left, discard_cards, current_q_card, mystery_played) = (
+pg_temp.card_ids(question_cards[1:q_cards_left], true), cardinality(pg_temp.card_ids(question_cards[1:q_cards_left], true)),
+pg_temp.card_ids(answer_cards[1:a_cards_left], false), cardinality(pg_temp.card_ids(answer_cards[1:a_cards_left], false)),
+pg_temp.card_ids(discard_cards, false), COALESCE((SELECT c.id FROM deck_cards c WHERE c.question AND c.position = current_q_card), -1), pg_temp.card_ids(mystery_played, false));
+UPDATE users SET (cards_in_hand, played_cards, trading_cards) = (pg_temp.card_ids(cards_in_hand, false), pg_temp.card_ids(played_cards, false), pg_temp.card_ids(trading_cards, false));
+DROP FUNCTION pg_temp.card_ids(integer[], boolean);
+`
+
+// cardIDsDown undoes cardIDsUp.
+const cardIDsDown = `CREATE OR REPLACE FUNCTION pg_temp.card_positions(ids integer[], question boolean) RETURNS integer[] AS $$
+SELECT COALESCE(array_agg(c.position ORDER BY i.n), '{}') FROM unnest($1) WITH ORDINALITY AS i(id, n) JOIN deck_cards c ON c.question = $2 AND c.id = i.id
+$$ LANGUAGE sql;
+UPDATE games SET (question_cards, q_cards_left, answer_cards, a_cards_left, discard_cards, current_q_card, mystery_played) = (
+pg_temp.card_positions(question_cards[1:q_cards_left], true), cardinality(pg_temp.card_positions(question_cards[1:q_cards_left], true)),
+pg_temp.card_positions(answer_cards[1:a_cards_left], false), cardinality(pg_temp.card_positions(answer_cards[1:a_cards_left], false)),
+pg_temp.card_positions(discard_cards, false), COALESCE((SELECT c.position FROM deck_cards c WHERE c.question AND c.id = current_q_card), -1), pg_temp.card_positions(mystery_played, false));
+UPDATE users SET (cards_in_hand, played_cards, trading_cards) = (pg_temp.card_positions(cards_in_hand, false), pg_temp.card_positions(played_cards, false), pg_temp.card_positions(trading_cards, false));
+DROP FUNCTION pg_temp.card_positions(integer[], boolean);
+`
+
+// blankCardsUp stores how many blank cards each game has, and what players write on them.
+const blankCardsUp = `ALTER TABLE games ADD COLUMN blank_cards integer NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN writing_in integer NOT NULL DEFAULT 0, ADD COLUMN written_answers text[] NOT NULL DEFAULT '{}';
+`
+
+// blankCardsDown undoes blankCardsUp.
+const blankCardsDown = `ALTER TABLE games DROP COLUMN blank_cards;
+ALTER TABLE users DROP COLUMN writing_in, DROP COLUMN written_answers;
+`
+
+// deadlinesUp stores how long players have to answer and judge, when the round moves on without them, and the votes
+// taken when the czar takes too long.
+const deadlinesUp = `ALTER TABLE games ADD COLUMN answer_minutes integer NOT NULL DEFAULT 0, ADD COLUMN czar_minutes integer NOT NULL DEFAULT 0,
+ADD COLUMN czar_timeout integer NOT NULL DEFAULT 0, ADD COLUMN deadline timestamp with time zone, ADD COLUMN remind_at timestamp with time zone,
+ADD COLUMN czar_stand_in boolean NOT NULL DEFAULT false;
+ALTER TABLE users ADD COLUMN vote integer NOT NULL DEFAULT 0;
+`
+
+// deadlinesDown undoes deadlinesUp.
+const deadlinesDown = `ALTER TABLE games DROP COLUMN answer_minutes, DROP COLUMN czar_minutes, DROP COLUMN czar_timeout, DROP COLUMN deadline, DROP COLUMN remind_at,
+DROP COLUMN czar_stand_in;
+ALTER TABLE users DROP COLUMN vote;
+`
+
+// czarlessUp stores whether each game is played without a czar, and how ties in votes are broken.
+const czarlessUp = `ALTER TABLE games ADD COLUMN czarless boolean NOT NULL DEFAULT false, ADD COLUMN tie_break integer NOT NULL DEFAULT 0;
+`
+
+// czarlessDown undoes czarlessUp.
+const czarlessDown = `ALTER TABLE games DROP COLUMN czarless, DROP COLUMN tie_break;
+`
+
+// houseRulesUp stores the house rules each game plays with, and the round a game ends after.
+const houseRulesUp = `ALTER TABLE games ADD COLUMN house_rules text[] NOT NULL DEFAULT '{}', ADD COLUMN final_round integer NOT NULL DEFAULT 0;
+`
+
+// houseRulesDown undoes houseRulesUp.
+const houseRulesDown = `ALTER TABLE games DROP COLUMN house_rules, DROP COLUMN final_round;
+`
+
+// survivalUp stores how each game is judged, the answers eliminated in Survival of the Fittest and whose turn it is,
+// and the message that shows each player the answers being eliminated.
+const survivalUp = `ALTER TABLE games ADD COLUMN judging integer NOT NULL DEFAULT 0, ADD COLUMN eliminated integer[] NOT NULL DEFAULT '{}',
+ADD COLUMN turn integer NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN board_message integer NOT NULL DEFAULT 0;
+`
+
+// survivalDown undoes survivalUp.
+const survivalDown = `ALTER TABLE games DROP COLUMN judging, DROP COLUMN eliminated, DROP COLUMN turn;
+ALTER TABLE users DROP COLUMN board_message;
+`
+
+// seriousBusinessUp stores the answers the czar ranked so far in Serious Business, and the Awesome Points each player
+// won or lost in each round, as one array for each field.
+const seriousBusinessUp = `ALTER TABLE games ADD COLUMN ranked integer[] NOT NULL DEFAULT '{}', ADD COLUMN history_rounds integer[] NOT NULL DEFAULT '{}',
+ADD COLUMN history_players integer[] NOT NULL DEFAULT '{}', ADD COLUMN history_points integer[] NOT NULL DEFAULT '{}';
+`
+
+// seriousBusinessDown undoes seriousBusinessUp.
+const seriousBusinessDown = `ALTER TABLE games DROP COLUMN ranked, DROP COLUMN history_rounds, DROP COLUMN history_players, DROP COLUMN history_points;
+`
@@ -0,0 +1,488 @@
+package main
+
+import (
+	"database/sql"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/thedadams/cahbot/game"
+)
+
+// editingSettings is the setting_status of the player that is changing the settings of their game.
+const editingSettings = "editing"
+
+// PostgresStore keeps the users and games in a PostgreSQL database.
+type PostgresStore struct {
+	DB *sql.DB
+
+	mu   sync.RWMutex
+	deck *game.Deck
+}
+
+// NewPostgresStore connects to the database at URL.
+func NewPostgresStore(URL string, Deck *game.Deck) (*PostgresStore, error) {
+	db, err := sql.Open("postgres", URL)
+	if err != nil {
+		return nil, err
+	}
+	return &PostgresStore{DB: db, deck: Deck}, nil
+}
+
+// SetDeck replaces the deck that games are loaded with.
+func (s *PostgresStore) SetDeck(Deck *game.Deck) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deck = Deck
+}
+
+// Deck returns the deck that games are loaded with.
+func (s *PostgresStore) Deck() *game.Deck {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.deck
+}
+
+// AddUser adds a user, or updates their chat and names if they are already stored.
+func (s *PostgresStore) AddUser(UserID int, ChatID int64, FirstName, LastName, UserName, DisplayName string) error {
+	_, err := s.DB.Exec(`INSERT INTO users (id, chat_id, first_name, last_name, username, display_name, points, cards_in_hand, played_cards, current_answer, waiting_for_response, setting_status)
+		VALUES ($1, $2, $3, $4, $5, $6, 0, '{}', '{}', '', '', '')
+		ON CONFLICT (id) DO UPDATE SET (chat_id, first_name, last_name, username, display_name) = (EXCLUDED.chat_id, EXCLUDED.first_name, EXCLUDED.last_name, EXCLUDED.username, EXCLUDED.display_name)`,
+		UserID, ChatID, FirstName, LastName, UserName, DisplayName)
+	return err
+}
+
+// RemoveUser forgets a user.
+func (s *PostgresStore) RemoveUser(UserID int) error {
+	_, err := s.DB.Exec("DELETE FROM users WHERE id = $1", UserID)
+	return err
+}
+
+// GameIDForUser returns the ID of the game a user is playing in, or "" if they are not in one.
+func (s *PostgresStore) GameIDForUser(UserID int) (string, error) {
+	var GameID string
+	err := s.DB.QueryRow("SELECT game_id FROM players WHERE user_id = $1", UserID).Scan(&GameID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return GameID, err
+}
+
+// GameExists checks to see if there is a game with the given ID.
+func (s *PostgresStore) GameExists(GameID string) (bool, error) {
+	var exists bool
+	err := s.DB.QueryRow("SELECT EXISTS (SELECT 1 FROM games WHERE id = $1)", GameID).Scan(&exists)
+	return exists, err
+}
+
+// CreateGame stores a new game.
+func (s *PostgresStore) CreateGame(g *game.Game) error {
+	return s.inTx(func(tx *sql.Tx) error {
+		return saveGame(tx, g)
+	})
+}
+
+// LoadGame loads a game.  Changes to the game are not stored.
+func (s *PostgresStore) LoadGame(GameID string) (*game.Game, error) {
+	var g *game.Game
+	err := s.inTx(func(tx *sql.Tx) (err error) {
+		g, err = loadGame(tx, GameID, s.Deck(), false)
+		return err
+	})
+	return g, err
+}
+
+// UpdateGame loads a game, lets Update change it and stores the changes.
+// The game is locked until the changes are stored.
+func (s *PostgresStore) UpdateGame(GameID string, Update func(*game.Game) error) error {
+	return s.inTx(func(tx *sql.Tx) error {
+		g, err := loadGame(tx, GameID, s.Deck(), true)
+		if err != nil {
+			return err
+		}
+		// Cards that were taken out of the card data since the game was saved cannot be dealt, so they are
+		// taken out of the game here, where the result is saved along with the update.  LoadGame leaves them.
+		if n := g.ForgetMissingCards(); n != 0 {
+			log.Printf("GameID: %v - Took out %v cards that are no longer in the deck.", GameID, n)
+		}
+		if err = Update(g); err != nil {
+			return err
+		}
+		return saveGame(tx, g)
+	})
+}
+
+// ChatIDs returns the chat IDs of everyone playing in a game.
+func (s *PostgresStore) ChatIDs(GameID string) ([]int64, error) {
+	rows, err := s.DB.Query("SELECT users.chat_id FROM players, users WHERE players.game_id = $1 AND users.id = players.user_id", GameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var IDs []int64
+	for rows.Next() {
+		var ID int64
+		if err := rows.Scan(&ID); err != nil {
+			return nil, err
+		}
+		IDs = append(IDs, ID)
+	}
+	return IDs, rows.Err()
+}
+
+// SaveBoard remembers the message that shows a player the answers being eliminated, so that it can be edited.
+func (s *PostgresStore) SaveBoard(GameID string, UserID, MessageID int) error {
+	_, err := s.DB.Exec("UPDATE users SET board_message = $3 FROM players WHERE players.user_id = users.id AND players.game_id = $1 AND users.id = $2",
+		GameID, UserID, MessageID)
+	return err
+}
+
+// Boards returns the messages that show the players of a game the answers being eliminated, by user ID.
+func (s *PostgresStore) Boards(GameID string) (map[int]int, error) {
+	rows, err := s.DB.Query("SELECT users.id, users.board_message FROM players, users WHERE players.game_id = $1 AND users.id = players.user_id AND users.board_message <> 0", GameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	boards := make(map[int]int)
+	for rows.Next() {
+		var UserID, MessageID int
+		if err := rows.Scan(&UserID, &MessageID); err != nil {
+			return nil, err
+		}
+		boards[UserID] = MessageID
+	}
+	return boards, rows.Err()
+}
+
+// GamesDue returns the IDs of the games whose players are due a reminder, or whose deadline has passed, at Now.
+func (s *PostgresStore) GamesDue(Now time.Time) ([]string, error) {
+	rows, err := s.DB.Query("SELECT id FROM games WHERE deadline <= $1 OR remind_at <= $1", Now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var IDs []string
+	for rows.Next() {
+		var ID string
+		if err := rows.Scan(&ID); err != nil {
+			return nil, err
+		}
+		IDs = append(IDs, ID)
+	}
+	return IDs, rows.Err()
+}
+
+// CleanUpOldGames deletes the games that have not been played since Before and returns them.
+func (s *PostgresStore) CleanUpOldGames(Before time.Time) ([]*game.Game, error) {
+	var games []*game.Game
+	err := s.inTx(func(tx *sql.Tx) error {
+		rows, err := tx.Query("SELECT id FROM games WHERE last_modified < $1 FOR UPDATE", Before)
+		if err != nil {
+			return err
+		}
+		var IDs []string
+		for rows.Next() {
+			var ID string
+			if err := rows.Scan(&ID); err != nil {
+				rows.Close()
+				return err
+			}
+			IDs = append(IDs, ID)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		for _, ID := range IDs {
+			g, err := loadGame(tx, ID, s.Deck(), false)
+			if err != nil {
+				return err
+			}
+			if err = deleteGame(tx, ID); err != nil {
+				return err
+			}
+			games = append(games, g)
+		}
+		return nil
+	})
+	return games, err
+}
+
+// UpdateOffset returns the ID of the first update from Telegram that has not been confirmed, or 0.
+func (s *PostgresStore) UpdateOffset() (int, error) {
+	var offset int
+	err := s.DB.QueryRow("SELECT value FROM bot_state WHERE name = 'update_offset'").Scan(&offset)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return offset, err
+}
+
+// SaveUpdateOffset stores the ID of the first update from Telegram that has not been confirmed.
+func (s *PostgresStore) SaveUpdateOffset(Offset int) error {
+	_, err := s.DB.Exec("INSERT INTO bot_state (name, value) VALUES ('update_offset', $1) ON CONFLICT (name) DO UPDATE SET value = EXCLUDED.value", Offset)
+	return err
+}
+
+// UpdateHandled checks to see if an update from Telegram has already been handled.
+func (s *PostgresStore) UpdateHandled(UpdateID int) (bool, error) {
+	var handled bool
+	err := s.DB.QueryRow("SELECT EXISTS (SELECT 1 FROM handled_updates WHERE update_id = $1)", UpdateID).Scan(&handled)
+	return handled, err
+}
+
+// MarkUpdateHandled remembers that an update from Telegram has been handled.
+func (s *PostgresStore) MarkUpdateHandled(UpdateID int) error {
+	_, err := s.DB.Exec("INSERT INTO handled_updates (update_id, handled_at) VALUES ($1, NOW()) ON CONFLICT DO NOTHING", UpdateID)
+	return err
+}
+
+// ForgetUpdates forgets the updates that were handled before Before.
+func (s *PostgresStore) ForgetUpdates(Before time.Time) error {
+	_, err := s.DB.Exec("DELETE FROM handled_updates WHERE handled_at < $1", Before)
+	return err
+}
+
+// Close closes the connection to the database.
+func (s *PostgresStore) Close() error {
+	return s.DB.Close()
+}
+
+// inTx runs f in a transaction, which is committed if f does not return an error.
+func (s *PostgresStore) inTx(f func(*sql.Tx) error) error {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err = f(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// loadGame loads a game and its players.  If lock is true, the game is locked until the transaction ends.
+func loadGame(tx *sql.Tx, GameID string, Deck *game.Deck, lock bool) (*game.Game, error) {
+	g := &game.Game{ID: GameID, Deck: Deck}
+	var questions, answers, discards, czarOrder, answerOrder, mysteryPlayed, eliminated, ranked pq.Int64Array
+	var historyRounds, historyPlayers, historyPoints pq.Int64Array
+	var czar, mysteryPoints sql.NullInt64
+	var qLeft, aLeft, phase int
+	var mysteryAnswer string
+	var excludedPacks, houseRules pq.StringArray
+	var deadline, remindAt pq.NullTime
+	var czarTimeout, tieBreak, judging int
+	query := `SELECT question_cards, q_cards_left, answer_cards, a_cards_left, discard_cards, czar_order, current_czar, current_q_card, COALESCE(phase, 0), answer_order, round,
+		mystery_player, trade_in_cards, num_cards_to_trade, pick_worst, num_cards_in_hand, points_to_win, mystery_points, mystery_played, mystery_answer, best_player, excluded_packs, blank_cards,
+		answer_minutes, czar_minutes, czar_timeout, deadline, remind_at, czar_stand_in, czarless, tie_break,
+		house_rules, final_round, judging, eliminated, turn, ranked, history_rounds, history_players, history_points FROM games WHERE id = $1`
+	if lock {
+		query += " FOR UPDATE"
+	}
+	err := tx.QueryRow(query, GameID).Scan(
+		&questions, &qLeft, &answers, &aLeft, &discards, &czarOrder, &czar, &g.Question, &phase, &answerOrder, &g.Round,
+		&g.Settings.MysteryPlayer, &g.Settings.TradeInCards, &g.Settings.NumCardsToTrade, &g.Settings.PickWorst, &g.Settings.CardsInHand, &g.Settings.PointsToWin,
+		&mysteryPoints, &mysteryPlayed, &mysteryAnswer, &g.Best, &excludedPacks, &g.Settings.BlankCards,
+		&g.Settings.AnswerMinutes, &g.Settings.CzarMinutes, &czarTimeout, &deadline, &remindAt, &g.StandIn, &g.Settings.Czarless, &tieBreak,
+		&houseRules, &g.FinalRound, &judging, &eliminated, &g.Turn, &ranked, &historyRounds, &historyPlayers, &historyPoints)
+	if err == sql.ErrNoRows {
+		return nil, ErrNoGame
+	} else if err != nil {
+		return nil, err
+	}
+	g.Phase = game.Phase(phase)
+	g.Settings.CzarTimeout = game.CzarTimeoutRule(czarTimeout)
+	g.Settings.TieBreak = game.TieBreak(tieBreak)
+	g.Settings.Judging = game.JudgingMode(judging)
+	if deadline.Valid {
+		g.Deadline = deadline.Time
+	}
+	if remindAt.Valid {
+		g.RemindAt = remindAt.Time
+	}
+	g.Czar = int(czar.Int64)
+	g.QuestionPile = pile(fromArray(questions), qLeft)
+	g.AnswerPile = pile(fromArray(answers), aLeft)
+	g.Discards = fromArray(discards)
+	g.Order = fromArray(answerOrder)
+	g.Eliminated = fromArray(eliminated)
+	g.Ranked = fromArray(ranked)
+	// The history is kept as one array for each field of an award.
+	rounds, players, points := fromArray(historyRounds), fromArray(historyPlayers), fromArray(historyPoints)
+	for i := range rounds {
+		if i < len(players) && i < len(points) {
+			g.History = append(g.History, game.Award{Round: rounds[i], PlayerID: players[i], Points: points[i]})
+		}
+	}
+	g.ExcludedPacks = []string(excludedPacks)
+	g.HouseRules = []string(houseRules)
+	if mysteryPoints.Valid {
+		g.Mystery = &game.Player{ID: game.MysteryID, Name: game.MysteryName, Points: int(mysteryPoints.Int64), Played: fromArray(mysteryPlayed), Answer: mysteryAnswer}
+	}
+
+	rows, err := tx.Query(`SELECT users.id, users.chat_id, users.display_name, users.points, users.cards_in_hand, users.played_cards, users.current_answer, COALESCE(users.setting_status, ''),
+		users.trading_cards, users.traded_cards, users.writing_in, users.written_answers, users.vote
+		FROM players, users WHERE players.game_id = $1 AND users.id = players.user_id`, GameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		p := new(game.Player)
+		var hand, played, trading pq.Int64Array
+		var written pq.StringArray
+		var settingStatus string
+		if err := rows.Scan(&p.ID, &p.ChatID, &p.Name, &p.Points, &hand, &played, &p.Answer, &settingStatus, &trading, &p.Traded, &p.WritingIn, &written, &p.Vote); err != nil {
+			return nil, err
+		}
+		p.Hand, p.Played, p.Trading, p.Written = fromArray(hand), fromArray(played), fromArray(trading), []string(written)
+		if settingStatus == editingSettings {
+			g.SettingsEditor = p.ID
+		}
+		g.Players = append(g.Players, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	// The players are kept in the order they become the czar.
+	order := fromArray(czarOrder)
+	sort.SliceStable(g.Players, func(i, j int) bool {
+		return indexOf(order, g.Players[i].ID) < indexOf(order, g.Players[j].ID)
+	})
+	return g, nil
+}
+
+// saveGame saves a game and its players.  A finished game is deleted.
+func saveGame(tx *sql.Tx, g *game.Game) error {
+	if g.Phase == game.Finished {
+		return deleteGame(tx, g.ID)
+	}
+	czarOrder := make([]int, len(g.Players))
+	for i, p := range g.Players {
+		czarOrder[i] = p.ID
+	}
+	var czar, mysteryPoints sql.NullInt64
+	if g.Czar != 0 {
+		czar = sql.NullInt64{Int64: int64(g.Czar), Valid: true}
+	}
+	var mysteryPlayed []int
+	var mysteryAnswer string
+	if g.Mystery != nil {
+		mysteryPoints = sql.NullInt64{Int64: int64(g.Mystery.Points), Valid: true}
+		mysteryPlayed, mysteryAnswer = g.Mystery.Played, g.Mystery.Answer
+	}
+	var historyRounds, historyPlayers, historyPoints []int
+	for _, a := range g.History {
+		historyRounds, historyPlayers, historyPoints = append(historyRounds, a.Round), append(historyPlayers, a.PlayerID), append(historyPoints, a.Points)
+	}
+	_, err := tx.Exec(`INSERT INTO games (id, question_cards, q_cards_left, answer_cards, a_cards_left, discard_cards, czar_order, current_czar, current_q_card, phase, answer_order,
+		in_round, waiting_for_answers, mystery_player, trade_in_cards, num_cards_to_trade, pick_worst, num_cards_in_hand, points_to_win, round,
+		mystery_points, mystery_played, mystery_answer, best_player, excluded_packs, blank_cards, answer_minutes, czar_minutes, czar_timeout, deadline, remind_at, czar_stand_in,
+		czarless, tie_break, house_rules, final_round, judging, eliminated, turn, ranked, history_rounds, history_players, history_points, last_modified)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32,
+		$33, $34, $35, $36, $37, $38, $39, $40, $41, $42, $43, transaction_timestamp())
+		ON CONFLICT (id) DO UPDATE SET (question_cards, q_cards_left, answer_cards, a_cards_left, discard_cards, czar_order, current_czar, current_q_card, phase, answer_order,
+		in_round, waiting_for_answers, mystery_player, trade_in_cards, num_cards_to_trade, pick_worst, num_cards_in_hand, points_to_win, round,
+		mystery_points, mystery_played, mystery_answer, best_player, excluded_packs, blank_cards, answer_minutes, czar_minutes, czar_timeout, deadline, remind_at, czar_stand_in,
+		czarless, tie_break, house_rules, final_round, judging, eliminated, turn, ranked, history_rounds, history_players, history_points) =
+		(EXCLUDED.question_cards, EXCLUDED.q_cards_left, EXCLUDED.answer_cards, EXCLUDED.a_cards_left, EXCLUDED.discard_cards, EXCLUDED.czar_order, EXCLUDED.current_czar,
+		EXCLUDED.current_q_card, EXCLUDED.phase, EXCLUDED.answer_order, EXCLUDED.in_round, EXCLUDED.waiting_for_answers, EXCLUDED.mystery_player, EXCLUDED.trade_in_cards,
+		EXCLUDED.num_cards_to_trade, EXCLUDED.pick_worst, EXCLUDED.num_cards_in_hand, EXCLUDED.points_to_win, EXCLUDED.round,
+		EXCLUDED.mystery_points, EXCLUDED.mystery_played, EXCLUDED.mystery_answer, EXCLUDED.best_player, EXCLUDED.excluded_packs, EXCLUDED.blank_cards,
+		EXCLUDED.answer_minutes, EXCLUDED.czar_minutes, EXCLUDED.czar_timeout, EXCLUDED.deadline, EXCLUDED.remind_at, EXCLUDED.czar_stand_in,
+		EXCLUDED.czarless, EXCLUDED.tie_break, EXCLUDED.house_rules, EXCLUDED.final_round,
+		EXCLUDED.judging, EXCLUDED.eliminated, EXCLUDED.turn, EXCLUDED.ranked, EXCLUDED.history_rounds, EXCLUDED.history_players, EXCLUDED.history_points)`,
+		g.ID, toArray(g.QuestionPile), len(g.QuestionPile), toArray(g.AnswerPile), len(g.AnswerPile), toArray(g.Discards), toArray(czarOrder), czar, g.Question, int(g.Phase), toArray(g.Order),
+		g.Phase.InRound(), g.Phase == game.CollectingAnswers, g.Settings.MysteryPlayer, g.Settings.TradeInCards, g.Settings.NumCardsToTrade, g.Settings.PickWorst, g.Settings.CardsInHand, g.Settings.PointsToWin, g.Round,
+		mysteryPoints, toArray(mysteryPlayed), mysteryAnswer, g.Best, append(pq.StringArray{}, g.ExcludedPacks...), g.Settings.BlankCards,
+		g.Settings.AnswerMinutes, g.Settings.CzarMinutes, int(g.Settings.CzarTimeout), nullTime(g.Deadline), nullTime(g.RemindAt), g.StandIn,
+		g.Settings.Czarless, int(g.Settings.TieBreak), append(pq.StringArray{}, g.HouseRules...), g.FinalRound,
+		int(g.Settings.Judging), toArray(g.Eliminated), g.Turn, toArray(g.Ranked), toArray(historyRounds), toArray(historyPlayers), toArray(historyPoints))
+	if err != nil {
+		return err
+	}
+	// Anyone that left the game is reset.
+	_, err = tx.Exec(`UPDATE users SET (cards_in_hand, played_cards, current_answer, points, waiting_for_response, setting_status, trading_cards, traded_cards, writing_in, written_answers, vote, board_message) =
+		('{}', '{}', '', 0, '', '', '{}', false, 0, '{}', 0, 0)
+		FROM players WHERE players.user_id = users.id AND players.game_id = $1 AND NOT (players.user_id = ANY($2))`, g.ID, toArray(czarOrder))
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("DELETE FROM players WHERE game_id = $1 AND NOT (user_id = ANY($2))", g.ID, toArray(czarOrder))
+	if err != nil {
+		return err
+	}
+	for _, p := range g.Players {
+		_, err = tx.Exec("INSERT INTO players (game_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", g.ID, p.ID)
+		if err != nil {
+			return err
+		}
+		settingStatus := ""
+		if p.ID == g.SettingsEditor {
+			settingStatus = editingSettings
+		}
+		_, err = tx.Exec(`UPDATE users SET (points, cards_in_hand, played_cards, current_answer, setting_status, trading_cards, traded_cards, writing_in, written_answers, vote) =
+			($2, $3, $4, $5, $6, $7, $8, $9, $10, $11) WHERE id = $1`,
+			p.ID, p.Points, toArray(p.Hand), toArray(p.Played), p.Answer, settingStatus, toArray(p.Trading), p.Traded, p.WritingIn, append(pq.StringArray{}, p.Written...), p.Vote)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteGame deletes a game and resets everyone that was playing in it.
+func deleteGame(tx *sql.Tx, GameID string) error {
+	_, err := tx.Exec(`UPDATE users SET (cards_in_hand, played_cards, current_answer, points, waiting_for_response, setting_status, trading_cards, traded_cards, writing_in, written_answers, vote, board_message) =
+		('{}', '{}', '', 0, '', '', '{}', false, 0, '{}', 0, 0)
+		FROM players WHERE players.user_id = users.id AND players.game_id = $1`, GameID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("DELETE FROM games WHERE id = $1", GameID)
+	return err
+}
+
+// nullTime stores a zero time as NULL.
+func nullTime(t time.Time) pq.NullTime {
+	return pq.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+func toArray(arr []int) pq.Int64Array {
+	a := make(pq.Int64Array, len(arr))
+	for i := range arr {
+		a[i] = int64(arr[i])
+	}
+	return a
+}
+
+func fromArray(a pq.Int64Array) []int {
+	arr := make([]int, len(a))
+	for i := range a {
+		arr[i] = int(a[i])
+	}
+	return arr
+}
+
+// pile returns the cards that are left in a draw pile.
+func pile(cards []int, left int) []int {
+	if left < 0 {
+		left = 0
+	}
+	if left > len(cards) {
+		left = len(cards)
+	}
+	return cards[:left]
+}
+
+func indexOf(arr []int, v int) int {
+	for i := range arr {
+		if arr[i] == v {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/thedadams/cahbot/game"
+)
+
+// TestPostgresStore runs the store tests against the database at TEST_DATABASE_URL, which is migrated first.
+// The tests clean up old games and forget handled updates, so it must not be a database the bot uses.
+func TestPostgresStore(t *testing.T) {
+	URL := os.Getenv("TEST_DATABASE_URL")
+	if URL == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	Deck, err := LoadDeck("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	Store, err := NewPostgresStore(URL, Deck)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer Store.Close()
+	if err := Migrate([]string{"up"}, Store.DB, Deck); err != nil {
+		t.Fatal(err)
+	}
+	testGameStore(t, Store, Deck)
+	testUpdateTracking(t, Store)
+	testForgetMissingCards(t, Store, Deck)
+}
+
+// testForgetMissingCards checks that a card taken out of the card data while a game was saved is left alone
+// when the game is loaded, and is taken out of the game, and saved that way, when the game is next updated.
+func testForgetMissingCards(t *testing.T, Store *PostgresStore, Deck *game.Deck) {
+	g := newStoredGame(t, Store, Deck, "tst09", game.DefaultSettings)
+	defer Store.UpdateGame(g.ID, func(g *game.Game) error {
+		g.Phase = game.Finished
+		return nil
+	})
+	saved := g.Player(storeTestUsers[0]).Hand
+	removed := saved[0]
+	var answers []game.AnswerCard
+	for _, a := range Deck.Answers {
+		if a.ID != removed {
+			answers = append(answers, a)
+		}
+	}
+	questions, _ := json.Marshal(Deck.Questions)
+	data, _ := json.Marshal(answers)
+	smaller, err := game.NewDeck(questions, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Store.SetDeck(smaller)
+	defer Store.SetDeck(Deck)
+
+	for i := 0; i < 2; i++ {
+		loaded, err := Store.LoadGame(g.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hand := loaded.Player(storeTestUsers[0]).Hand; !sameCards(hand, saved) {
+			t.Fatalf("load %v: got the hand %v, want it left as it was saved, %v", i+1, hand, saved)
+		}
+	}
+	var updated []int
+	if err := Store.UpdateGame(g.ID, func(g *game.Game) error {
+		updated = append([]int(nil), g.Player(storeTestUsers[0]).Hand...)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(updated) != len(saved) || indexOf(updated, removed) != -1 {
+		t.Errorf("got the hand %v to update, want %v taken out and another card dealt", updated, removed)
+	}
+	loaded, err := Store.LoadGame(g.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hand := loaded.Player(storeTestUsers[0]).Hand; !sameCards(hand, updated) {
+		t.Errorf("got the hand %v after the update, want %v to have been saved", hand, updated)
+	}
+}
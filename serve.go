@@ -0,0 +1,292 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"expvar"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/thedadams/telegram-bot-api"
+)
+
+// SecretTokenHeader is the header Telegram puts the secret token of a webhook in.
+const SecretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// RefusedUpdates is the number of updates that Telegram sent to the webhook for a game whose queue was full.
+// Telegram sends them again later.
+var RefusedUpdates = expvar.NewInt("refused_updates")
+
+// validSecretToken is what Telegram accepts as a secret token.
+var validSecretToken = regexp.MustCompile(`^[A-Za-z0-9_-]{1,256}$`)
+
+// Serve runs the serve command until Stop is closed.  The bot polls Telegram for updates unless --webhook is given.
+// When it stops, the updates that were received are handled before Serve returns.
+func Serve(Args []string, bot *CAHBot, api *tgbotapi.BotAPI, Stop <-chan struct{}) error {
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	webhook := flags.String("webhook", "", "the public https URL that Telegram sends updates to, for example https://example.com/telegram")
+	listen := flags.String("listen", ":"+port, "the address the webhook server listens on")
+	maxConnections := flags.Int("max-connections", 0, "the most connections Telegram opens to the webhook at once")
+	metrics := flags.String("metrics", "", "the address to serve metrics, like the number of queued updates, on at /debug/vars")
+	flags.Parse(Args)
+	if *metrics != "" {
+		go ServeMetrics(*metrics)
+	}
+	if *webhook == "" {
+		return Poll(bot, api, Stop)
+	}
+	return ServeWebhook(bot, api, *webhook, *listen, os.Getenv("WEBHOOK_SECRET"), *maxConnections, Stop)
+}
+
+// Poll gets updates from Telegram with long polling until Stop is closed.
+// Any webhook is removed first, because Telegram does not answer getUpdates while a webhook is set.
+//
+// Telegram forgets an update when it is asked for the updates after it, so Poll only asks for those once the
+// update has been handled.  The offset it asks from is stored, so that a restart carries on where it left off.
+// When a game's queue is full, Poll stops at its update and asks for it again once the queue has room.
+// When Poll stops, it waits for the updates it received to be handled.  Updates that arrive after Stop is
+// closed are not handled, so Telegram sends them again the next time the bot starts.
+func Poll(bot *CAHBot, api *tgbotapi.BotAPI, Stop <-chan struct{}) error {
+	if _, err := api.RemoveWebhook(); err != nil {
+		log.Printf("ERROR: %v", err)
+		log.Printf("Failed to remove the webhook.")
+	}
+	next, err := bot.Store.UpdateOffset()
+	if err != nil {
+		log.Printf("ERROR: %v", err)
+		log.Printf("Failed to load the update offset.  Starting from the first update Telegram has.")
+	}
+	saved := next
+	pending := make(map[int]bool)
+	finished := make(chan int)
+	// offset is the first update that has not been handled.
+	offset := func() int {
+		lowest := next
+		for ID := range pending {
+			if ID < lowest {
+				lowest = ID
+			}
+		}
+		return lowest
+	}
+	save := func() {
+		if o := offset(); o != saved {
+			if err := bot.Store.SaveUpdateOffset(o); err != nil {
+				log.Printf("ERROR: %v", err)
+				return
+			}
+			saved = o
+		}
+	}
+
+	type batch struct {
+		updates []tgbotapi.Update
+		err     error
+	}
+	var fetched chan batch
+	ready := true
+	// retry is when to ask again for an update that could not be queued.
+	var retry <-chan time.Time
+	log.Printf("Polling for updates from %v.", next)
+	for {
+		if ready && fetched == nil {
+			config := tgbotapi.NewUpdate(offset())
+			config.Timeout = 60
+			fetched = make(chan batch, 1)
+			go func(config tgbotapi.UpdateConfig, fetched chan<- batch) {
+				updates, err := api.GetUpdates(config)
+				fetched <- batch{updates, err}
+			}(config, fetched)
+			ready = false
+		}
+		select {
+		case <-Stop:
+			log.Printf("Stopped polling.  Waiting for the updates we received to be handled.")
+			drained := make(chan struct{})
+			go func() {
+				bot.Work.Wait()
+				close(drained)
+			}()
+			for len(pending) > 0 {
+				select {
+				case ID := <-finished:
+					delete(pending, ID)
+				case <-drained:
+					pending = nil
+				}
+			}
+			save()
+			if saved != 0 {
+				if _, err := api.GetUpdates(tgbotapi.UpdateConfig{Offset: saved, Limit: 1}); err != nil {
+					log.Printf("ERROR: %v", err)
+					log.Printf("Failed to tell Telegram which updates were handled.  They will be skipped next time.")
+				}
+			}
+			return nil
+		case ID := <-finished:
+			delete(pending, ID)
+			save()
+			ready = true
+		case <-retry:
+			retry = nil
+			ready = true
+		case b := <-fetched:
+			fetched = nil
+			if b.err != nil {
+				log.Printf("ERROR: %v", b.err)
+				log.Printf("Failed to get updates, retrying in 3 seconds...")
+				select {
+				case <-Stop:
+				case <-time.After(3 * time.Second):
+				}
+				ready = true
+				continue
+			}
+			// Updates that are still being handled come back until they are done.  Asking again before
+			// one of them is done would get the same updates straight away.
+			ready = len(pending) == 0
+			for _, update := range b.updates {
+				if update.UpdateID < next {
+					continue
+				}
+				ID := update.UpdateID
+				queued, err := bot.DispatchUpdate(update, func() { finished <- ID })
+				if err == ErrQueueFull {
+					// The update is left with Telegram, and so are the ones after it, so that they stay in order.
+					// They are asked for again when an update is finished with, or in a second.
+					ready = false
+					retry = time.After(time.Second)
+					break
+				}
+				next = ID + 1
+				ready = true
+				// An update that was not queued is one the bot does not handle, so Poll does not wait for it.
+				if queued {
+					pending[ID] = true
+				}
+			}
+			save()
+		}
+	}
+}
+
+// ServeWebhook has Telegram send updates to Webhook and serves them on Listen until Stop is closed.
+// Requests without the secret token are refused, and so are updates for a game whose queue is full, which
+// Telegram sends again later.  The webhook is removed when the server shuts down.
+func ServeWebhook(bot *CAHBot, api *tgbotapi.BotAPI, Webhook, Listen, Secret string, MaxConnections int, Stop <-chan struct{}) error {
+	if !validSecretToken.MatchString(Secret) {
+		return fmt.Errorf("WEBHOOK_SECRET must be 1-256 characters of A-Z, a-z, 0-9, _ and -")
+	}
+	u, err := url.Parse(Webhook)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("the webhook %q must be an https URL", Webhook)
+	}
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(path, WebhookHandler(bot))
+	server := &http.Server{Addr: Listen, Handler: RequireSecretToken(Secret, mux)}
+	if err := SetWebhook(api, u, Secret, MaxConnections); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		<-Stop
+		log.Printf("Shutting down the webhook server.")
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		err := server.Shutdown(ctx)
+		if err == nil {
+			// Telegram has been told that every update it sent was received, so they all have to be handled.
+			log.Printf("Waiting for the updates we received to be handled.")
+			bot.Work.Wait()
+		}
+		if _, removeErr := api.RemoveWebhook(); removeErr != nil {
+			log.Printf("ERROR: %v", removeErr)
+			log.Printf("Failed to remove the webhook.")
+		}
+		done <- err
+	}()
+
+	log.Printf("Listening for updates from %v on %v.", Webhook, Listen)
+	if err := server.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+	return <-done
+}
+
+// WebhookHandler queues the updates that Telegram sends to the webhook.  An update for a game whose queue is full
+// is refused, so that Telegram sends it again later.
+func WebhookHandler(bot *CAHBot) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var update tgbotapi.Update
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			log.Printf("ERROR: %v", err)
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		if _, err := bot.DispatchUpdate(update, nil); err == ErrQueueFull {
+			RefusedUpdates.Add(1)
+			http.Error(w, "too many updates for this game", http.StatusServiceUnavailable)
+		}
+	})
+}
+
+// SetWebhook tells Telegram to send updates to URL with the secret token in a header.
+// tgbotapi's SetWebhook does not know about secret tokens, so the request is made here.
+func SetWebhook(api *tgbotapi.BotAPI, URL *url.URL, Secret string, MaxConnections int) error {
+	v := url.Values{}
+	v.Add("url", URL.String())
+	v.Add("secret_token", Secret)
+	if MaxConnections != 0 {
+		v.Add("max_connections", strconv.Itoa(MaxConnections))
+	}
+	_, err := api.MakeRequest("setWebhook", v)
+	return err
+}
+
+// ServeMetrics serves the metrics published with expvar on Listen.
+func ServeMetrics(Listen string) {
+	mux := http.NewServeMux()
+	mux.Handle("/debug/vars", expvar.Handler())
+	log.Printf("Serving metrics on %v.", Listen)
+	if err := http.ListenAndServe(Listen, mux); err != nil {
+		log.Printf("ERROR: %v", err)
+		log.Printf("Failed to serve metrics.")
+	}
+}
+
+// RequireSecretToken only lets through POST requests that have the secret token in the header.
+func RequireSecretToken(Secret string, Next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get(SecretTokenHeader)), []byte(Secret)) != 1 {
+			log.Printf("Refused a webhook request from %v without the secret token.", r.RemoteAddr)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		Next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thedadams/cahbot/telegramtest"
+	"github.com/thedadams/telegram-bot-api"
+)
+
+// waitTime is how long the tests wait for the bot to answer.
+const waitTime = 5 * time.Second
+
+var joinCommand = regexp.MustCompile(`'/join ([^']+)'`)
+
+// startPolling starts a fake Telegram and a bot, with its games in memory, that polls it through the API endpoint.
+// The function it returns stops the bot and checks that Poll returns.
+func startPolling(t *testing.T) (*telegramtest.Server, *CAHBot, func()) {
+	t.Helper()
+	server := telegramtest.NewServer()
+	server.Token = "test-token"
+	web := httptest.NewServer(server)
+	api, err := NewTelegramAPI("test-token", web.URL)
+	if err != nil {
+		web.Close()
+		t.Fatal(err)
+	}
+	Deck, err := LoadDeck("")
+	if err != nil {
+		web.Close()
+		t.Fatal(err)
+	}
+	bot, err := NewCAHBot(&TelegramMessenger{api}, Deck, NewMemoryStore())
+	if err != nil {
+		web.Close()
+		t.Fatal(err)
+	}
+	stop := make(chan struct{})
+	polled := make(chan error, 1)
+	go func() { polled <- Poll(bot, api, stop) }()
+	return server, bot, func() {
+		t.Helper()
+		close(stop)
+		select {
+		case err := <-polled:
+			if err != nil {
+				t.Error(err)
+			}
+		case <-time.After(waitTime):
+			t.Error("Poll did not return after it was stopped.")
+		}
+		// Closing the fake Telegram ends the request for updates that Poll left waiting.
+		server.Close()
+		web.Close()
+	}
+}
+
+// waitForText waits until a message containing Text has been sent to a chat Count times.
+func waitForText(t *testing.T, server *telegramtest.Server, ChatID int64, Text string, Count int) {
+	t.Helper()
+	if !server.WaitFor(waitTime, func([]telegramtest.Request) bool { return countText(server, ChatID, Text) >= Count }) {
+		t.Fatalf("%q was not sent to %v.  The chat has:\n%v", Text, ChatID, strings.Join(server.Transcript(ChatID), "\n"))
+	}
+}
+
+func countText(server *telegramtest.Server, ChatID int64, Text string) int {
+	count := 0
+	for _, text := range server.Transcript(ChatID) {
+		if strings.Contains(text, Text) {
+			count++
+		}
+	}
+	return count
+}
+
+// choices returns the messages sent to a chat with buttons whose data starts with Prefix, in order.
+func choices(server *telegramtest.Server, ChatID int64, Prefix string) []telegramtest.Request {
+	var found []telegramtest.Request
+	for _, r := range server.Sent(ChatID) {
+		if buttons := r.Buttons(); len(buttons) != 0 && strings.HasPrefix(*buttons[0].CallbackData, Prefix) {
+			found = append(found, r)
+		}
+	}
+	return found
+}
+
+// pressFirst has a user press the first button of a message.
+func pressFirst(server *telegramtest.Server, User tgbotapi.User, Message telegramtest.Request) {
+	server.PressButton(User, Message.Message.MessageID, *Message.Buttons()[0].CallbackData)
+}
+
+func TestPlayRoundOverTelegram(t *testing.T) {
+	server, _, stop := startPolling(t)
+	defer stop()
+	players := []tgbotapi.User{{ID: 101, UserName: "alice"}, {ID: 102, UserName: "bob"}, {ID: 103, UserName: "carol"}}
+
+	server.SendCommand(players[0], "/create")
+	waitForText(t, server, 101, "/join ", 1)
+	var GameID string
+	for _, text := range server.Transcript(101) {
+		if m := joinCommand.FindStringSubmatch(text); m != nil {
+			GameID = m[1]
+		}
+	}
+	for _, p := range players[1:] {
+		server.SendCommand(p, "/join "+GameID)
+		waitForText(t, server, int64(p.ID), "Welcome to the game!", 1)
+	}
+	waitForText(t, server, 101, "carol has joined the game!", 1)
+
+	server.SendCommand(players[0], "/begin")
+	var answering []tgbotapi.User
+	var czar tgbotapi.User
+	if !server.WaitFor(waitTime, func([]telegramtest.Request) bool {
+		answering = nil
+		for _, p := range players {
+			if len(choices(server, int64(p.ID), "Answer::")) != 0 {
+				answering = append(answering, p)
+			} else {
+				czar = p
+			}
+		}
+		return len(answering) == len(players)-1
+	}) {
+		t.Fatalf("The players were not asked for answers.  %v were.", answering)
+	}
+	for _, p := range players {
+		waitForText(t, server, int64(p.ID), "Here is the question card:", 1)
+	}
+
+	// A question can take more than one answer, so each player answers until the game has their whole answer.
+	for _, p := range answering {
+		received := "We received " + p.UserName + "'s answer."
+		for countText(server, int64(p.ID), received) == 0 {
+			hands := choices(server, int64(p.ID), "Answer::")
+			pressFirst(server, p, hands[len(hands)-1])
+			if !server.WaitFor(waitTime, func([]telegramtest.Request) bool {
+				return countText(server, int64(p.ID), received) != 0 || len(choices(server, int64(p.ID), "Answer::")) > len(hands)
+			}) {
+				t.Fatalf("%v's answer was not received.  Their chat has:\n%v", p.UserName, strings.Join(server.Transcript(int64(p.ID)), "\n"))
+			}
+		}
+	}
+
+	if !server.WaitFor(waitTime, func([]telegramtest.Request) bool { return len(choices(server, int64(czar.ID), "CzarBest::")) != 0 }) {
+		t.Fatalf("The czar was not asked to choose.  Their chat has:\n%v", strings.Join(server.Transcript(int64(czar.ID)), "\n"))
+	}
+	pressFirst(server, czar, choices(server, int64(czar.ID), "CzarBest::")[0])
+	for _, p := range players {
+		waitForText(t, server, int64(p.ID), "The czar chose the best answer:", 1)
+		waitForText(t, server, int64(p.ID), "The new Card Czar is", 1)
+	}
+	for _, p := range answering {
+		if countText(server, int64(p.ID), "Please pick an answer for the question.") == 0 {
+			t.Errorf("%v was never asked for an answer.", p.UserName)
+		}
+	}
+}
+
+func TestPollSkipsUpdatesItDoesNotHandle(t *testing.T) {
+	server, _, stop := startPolling(t)
+	defer stop()
+	alice := tgbotapi.User{ID: 101, UserName: "alice"}
+
+	// An edited message has no Message, so the bot does not handle it.  Poll has to carry on past it.
+	edited := &tgbotapi.Message{MessageID: 1, From: &alice, Chat: &tgbotapi.Chat{ID: 101, Type: "private"}, Text: "hello", EditDate: int(time.Now().Unix())}
+	server.Inject(tgbotapi.Update{EditedMessage: edited})
+	server.SendCommand(alice, "/start")
+	waitForText(t, server, 101, "Welcome to Cards Against Humanity for Telegram.", 1)
+}
+
+func TestPollKeepsUpdatesForAFullQueue(t *testing.T) {
+	server, bot, stop := startPolling(t)
+	defer stop()
+	alice := tgbotapi.User{ID: 101, UserName: "alice"}
+
+	blocked, release := make(chan struct{}), make(chan struct{})
+	bot.Work.Dispatch("user:101", func() {
+		close(blocked)
+		<-release
+	})
+	<-blocked
+	for i := 0; i < QueueSize; i++ {
+		bot.Work.Dispatch("user:101", func() {})
+	}
+	polls := func(requests []telegramtest.Request) int {
+		n := 0
+		for _, r := range requests {
+			if r.Method == "getUpdates" {
+				n++
+			}
+		}
+		return n
+	}
+	before := polls(server.Requests())
+	server.SendCommand(alice, "/start")
+	// Poll gets the update, finds the queue full, and asks for it again.
+	if !server.WaitFor(waitTime, func(requests []telegramtest.Request) bool { return polls(requests) >= before+2 }) {
+		t.Fatal("Poll did not ask for the update again.")
+	}
+	if len(server.Sent(101)) != 0 {
+		t.Fatalf("got %v while the queue was full, want nothing yet", server.Transcript(101))
+	}
+	close(release)
+	waitForText(t, server, 101, "Welcome to Cards Against Humanity for Telegram.", 1)
+}
+
+func TestRequireSecretToken(t *testing.T) {
+	reached := false
+	handler := RequireSecretToken("s3cret_token-1", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { reached = true }))
+	for _, c := range []struct {
+		method, token string
+		want          int
+		reached       bool
+	}{
+		{http.MethodGet, "s3cret_token-1", http.StatusMethodNotAllowed, false},
+		{http.MethodPost, "", http.StatusUnauthorized, false},
+		{http.MethodPost, "wrong", http.StatusUnauthorized, false},
+		{http.MethodPost, "s3cret_token-", http.StatusUnauthorized, false},
+		{http.MethodPost, "s3cret_token-1", http.StatusOK, true},
+	} {
+		reached = false
+		r := httptest.NewRequest(c.method, "/telegram", strings.NewReader("{}"))
+		if c.token != "" {
+			r.Header.Set(SecretTokenHeader, c.token)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != c.want || reached != c.reached {
+			t.Errorf("%v with token %q: got status %v and reached %v, want %v and %v", c.method, c.token, w.Code, reached, c.want, c.reached)
+		}
+	}
+}
+
+func TestServeWebhookChecksItsSettings(t *testing.T) {
+	for _, c := range []struct {
+		webhook, secret string
+	}{
+		{"https://example.com/telegram", ""},
+		{"https://example.com/telegram", "not a valid token"},
+		{"https://example.com/telegram", strings.Repeat("a", 257)},
+		{"http://example.com/telegram", "s3cret"},
+		{"example.com/telegram", "s3cret"},
+		{"://example.com", "s3cret"},
+	} {
+		// The settings are checked before the bot or Telegram are used.
+		if err := ServeWebhook(nil, nil, c.webhook, ":0", c.secret, 0, nil); err == nil {
+			t.Errorf("serving %q with the secret %q: got no error", c.webhook, c.secret)
+		}
+	}
+}
+
+func TestWebhookHandlerRefusesUpdatesForAFullQueue(t *testing.T) {
+	bot, messenger := newRecordingBot(t)
+	handler := WebhookHandler(bot)
+	post := func(Body string) int {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/telegram", strings.NewReader(Body)))
+		return w.Code
+	}
+	start := `{"update_id": 1, "message": {"message_id": 1, "from": {"id": 101, "username": "alice"}, "chat": {"id": 101, "type": "private"}, "text": "/start", "entities": [{"type": "bot_command", "offset": 0, "length": 6}]}}`
+
+	if code := post("not json"); code != http.StatusBadRequest {
+		t.Errorf("posting a bad update: got status %v, want %v", code, http.StatusBadRequest)
+	}
+
+	blocked, release := make(chan struct{}), make(chan struct{})
+	bot.Work.Dispatch("user:101", func() {
+		close(blocked)
+		<-release
+	})
+	<-blocked
+	for i := 0; i < QueueSize; i++ {
+		bot.Work.Dispatch("user:101", func() {})
+	}
+	refused := RefusedUpdates.Value()
+	if code := post(start); code != http.StatusServiceUnavailable {
+		t.Errorf("posting to a full queue: got status %v, want %v", code, http.StatusServiceUnavailable)
+	}
+	if RefusedUpdates.Value() != refused+1 {
+		t.Errorf("the refused update was not counted")
+	}
+	close(release)
+	bot.Work.Wait()
+	if len(messenger.To(101)) != 0 {
+		t.Fatalf("got %v for the refused update, want nothing", messenger.To(101))
+	}
+
+	// Telegram sends the update again.
+	if code := post(start); code != http.StatusOK {
+		t.Errorf("posting again: got status %v, want %v", code, http.StatusOK)
+	}
+	bot.Work.Wait()
+	if sent := messenger.To(101); len(sent) == 0 || !strings.HasPrefix(sent[0].Text, "Welcome to Cards Against Humanity") {
+		t.Errorf("got %v, want the welcome message", sent)
+	}
+}
@@ -1,4 +1,4 @@
 package main
 
 // AllSettings contains all the settings that can be changed in the game.
-var AllSettings = []byte(`[{"name": "Pick worst card also", "cdata": "ChangeSetting::WorstCardToo", "options": [{"name": "Yes", "cdata": "WorstCardToo::Yes"}, {"name": "No", "cdata": "WorstCardToo::No"}]}, {"name": "Trade in cards at the end of every round", "cdata": "ChangeSetting::TradeInCards", "options": [{"name": "Yes", "cdata": "TradeInCards::Yes"}, {"name": "No", "cdata": "TradeInCards::No"}]}, {"name": "Number of cards to trade in","cdata": "ChangeSetting::NumCardsTradeIn", "options": [{"name": "1", "cdata": "NumCardsTradeIn::1"}, {"name":"2","cdata": "NumCardsTradeIn::2"}, {"name": "3", "cdata": "NumCardsTradeIn::3"}, {"name":"5", "cdata": "NumCardsTradeIn::5"}, {"name": "All", "cdata": "NumCardsTradeIn::All"}]}, {"name": "Number of cards in hand", "cdata": "ChangeSetting::NumCardsInHand", "options": [{"name": "5", "cdata": "NumCardsInHand::5"}, {"name": "7", "cdata": "NumCardsInHand::7"}, {"name": "10", "cdata": "NumCardsInHand::10"}]}, {"name": "Number of points to win", "cdata": "ChangeSetting::NumCardsToWin", "options": [{"name": "1", "cdata": "NumCardsToWin::1"}, {"name":"5","cdata": "NumCardsToWin::5"}, {"name": "10","cdata": "NumCardsToWin::10"}]}, {"name": "Mystery player", "cdata": "ChangeSetting::Jose", "options": [{"name": "Yes", "cdata": "Jose::Yes"}, {"name": "No", "cdata": "Jose::No"}]}]`)
+var AllSettings = []byte(`[{"name": "Pick worst card also", "cdata": "ChangeSetting::WorstCardToo", "options": [{"name": "Yes", "cdata": "WorstCardToo::Yes"}, {"name": "No", "cdata": "WorstCardToo::No"}]}, {"name": "Trade in cards at the end of every round", "cdata": "ChangeSetting::TradeInCards", "options": [{"name": "Yes", "cdata": "TradeInCards::Yes"}, {"name": "No", "cdata": "TradeInCards::No"}]}, {"name": "Number of cards to trade in","cdata": "ChangeSetting::NumCardsTradeIn", "options": [{"name": "1", "cdata": "NumCardsTradeIn::1"}, {"name":"2","cdata": "NumCardsTradeIn::2"}, {"name": "3", "cdata": "NumCardsTradeIn::3"}, {"name":"5", "cdata": "NumCardsTradeIn::5"}, {"name": "All", "cdata": "NumCardsTradeIn::All"}]}, {"name": "Number of cards in hand", "cdata": "ChangeSetting::NumCardsInHand", "options": [{"name": "5", "cdata": "NumCardsInHand::5"}, {"name": "7", "cdata": "NumCardsInHand::7"}, {"name": "10", "cdata": "NumCardsInHand::10"}]}, {"name": "Number of points to win", "cdata": "ChangeSetting::NumCardsToWin", "options": [{"name": "1", "cdata": "NumCardsToWin::1"}, {"name":"5","cdata": "NumCardsToWin::5"}, {"name": "10","cdata": "NumCardsToWin::10"}]}, {"name": "Mystery player", "cdata": "ChangeSetting::Jose", "options": [{"name": "Yes", "cdata": "Jose::Yes"}, {"name": "No", "cdata": "Jose::No"}]}, {"name": "Number of blank cards", "cdata": "ChangeSetting::BlankCards", "options": [{"name": "0", "cdata": "BlankCards::0"}, {"name": "5", "cdata": "BlankCards::5"}, {"name": "10", "cdata": "BlankCards::10"}, {"name": "20", "cdata": "BlankCards::20"}]}, {"name": "Time to answer", "cdata": "ChangeSetting::AnswerMinutes", "options": [{"name": "No limit", "cdata": "AnswerMinutes::0"}, {"name": "1 minute", "cdata": "AnswerMinutes::1"}, {"name": "2 minutes", "cdata": "AnswerMinutes::2"}, {"name": "5 minutes", "cdata": "AnswerMinutes::5"}, {"name": "10 minutes", "cdata": "AnswerMinutes::10"}, {"name": "30 minutes", "cdata": "AnswerMinutes::30"}]}, {"name": "Time for the czar to choose", "cdata": "ChangeSetting::CzarMinutes", "options": [{"name": "No limit", "cdata": "CzarMinutes::0"}, {"name": "1 minute", "cdata": "CzarMinutes::1"}, {"name": "2 minutes", "cdata": "CzarMinutes::2"}, {"name": "5 minutes", "cdata": "CzarMinutes::5"}, {"name": "10 minutes", "cdata": "CzarMinutes::10"}, {"name": "30 minutes", "cdata": "CzarMinutes::30"}]}, {"name": "When the czar takes too long", "cdata": "ChangeSetting::CzarTimeout", "options": [{"name": "Replace the czar", "cdata": "CzarTimeout::Replace"}, {"name": "Pick an answer at random", "cdata": "CzarTimeout::Random"}, {"name": "Players vote", "cdata": "CzarTimeout::Vote"}]}, {"name": "No Card Czar: everyone votes", "cdata": "ChangeSetting::Czarless", "options": [{"name": "Yes", "cdata": "Czarless::Yes"}, {"name": "No", "cdata": "Czarless::No"}]}, {"name": "Break ties in votes", "cdata": "ChangeSetting::TieBreak", "options": [{"name": "Pick an answer at random", "cdata": "TieBreak::Random"}, {"name": "Every tied answer scores", "cdata": "TieBreak::Share"}, {"name": "Nobody scores", "cdata": "TieBreak::None"}]}, {"name": "How the answers are judged", "cdata": "ChangeSetting::Judging", "options": [{"name": "The czar picks", "cdata": "Judging::Czar"}, {"name": "Survival of the Fittest", "cdata": "Judging::Survival"}, {"name": "Serious Business: the czar ranks the top three", "cdata": "Judging::Ranked"}]}, {"name": "House rules", "cdata": "ChangeSetting::HouseRules", "options": []}]`)
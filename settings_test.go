@@ -0,0 +1,136 @@
+package main
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/thedadams/cahbot/game"
+	"github.com/thedadams/telegram-bot-api"
+)
+
+// newLobbyGame stores a game that has not begun, with alice and bob, whose IDs and chats are 1 and 2.
+func newLobbyGame(t *testing.T, bot *CAHBot) *game.Game {
+	t.Helper()
+	g := game.New("setts", bot.CurrentDeck(), game.DefaultSettings, rand.New(rand.NewSource(1)))
+	for ID, name := range []string{"alice", "bob"} {
+		if _, err := g.AddPlayer(ID+1, int64(ID+1), name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := bot.Store.CreateGame(g); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// sendCommand has a user send a command to the bot in their own chat.
+func sendCommand(bot *CAHBot, User *tgbotapi.User, Command string) {
+	entities := []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: len(strings.Fields(Command)[0])}}
+	m := &tgbotapi.Message{MessageID: 1, From: User, Chat: &tgbotapi.Chat{ID: int64(User.ID), Type: "private"}, Text: Command, Entities: &entities}
+	bot.HandleUpdate(User, m, nil, "command")
+}
+
+// pressButton has a user press a button with the callback data Data on a message in their own chat.
+func pressButton(bot *CAHBot, User *tgbotapi.User, MessageID int, Data string) {
+	m := &tgbotapi.Message{MessageID: MessageID, Chat: &tgbotapi.Chat{ID: int64(User.ID), Type: "private"}}
+	bot.HandleUpdate(User, m, &tgbotapi.CallbackQuery{ID: "1", From: User, Message: m, Data: Data}, "callback")
+}
+
+// lastSent returns the last thing sent to a chat.
+func lastSent(t *testing.T, messenger *RecordingMessenger, ChatID int64) Sent {
+	t.Helper()
+	sent := messenger.To(ChatID)
+	if len(sent) == 0 {
+		t.Fatalf("nothing was sent to %v", ChatID)
+	}
+	return sent[len(sent)-1]
+}
+
+func TestChangeSettingsWithTheKeyboard(t *testing.T) {
+	bot, messenger := newRecordingBot(t)
+	newLobbyGame(t, bot)
+	alice, bob := &tgbotapi.User{ID: 1, UserName: "alice"}, &tgbotapi.User{ID: 2, UserName: "bob"}
+	stored := func() *game.Game {
+		t.Helper()
+		g, err := bot.Store.LoadGame("setts")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return g
+	}
+
+	pressButton(bot, bob, 1, "ChangeSetting::Jose")
+	if s := lastSent(t, messenger, 2); s.Text != GameErrorMessage(stored(), game.ErrNotEditing) {
+		t.Errorf("pressing a setting without /changesettings: got %q", s.Text)
+	}
+
+	sendCommand(bot, alice, "/changesettings")
+	menu := lastSent(t, messenger, 1)
+	if !strings.HasPrefix(menu.Text, "Game settings:") || len(menu.Choices) != len(bot.Settings)+1 {
+		t.Fatalf("got %q with %v, want the settings menu", menu.Text, menu.Choices)
+	}
+	MessageID := len(messenger.All())
+
+	sendCommand(bot, bob, "/changesettings")
+	if s := lastSent(t, messenger, 2); s.Text != "alice is changing the settings right now.  Please wait until they are done." {
+		t.Errorf("/changesettings while alice is changing them: got %q", s.Text)
+	}
+	pressButton(bot, bob, MessageID, "Jose::Yes")
+	if s := lastSent(t, messenger, 2); !strings.HasPrefix(s.Text, "alice is changing the settings") {
+		t.Errorf("picking an option while alice is changing the settings: got %q", s.Text)
+	}
+
+	pressButton(bot, alice, MessageID, "ChangeSetting::Jose")
+	if s := lastSent(t, messenger, 1); s.MessageID != MessageID || s.Text != "Mystery player:" || len(s.Choices) != 3 || s.Choices[2].Data != "ChangeSetting::Back" {
+		t.Errorf("got %+v, want the options for the mystery player in the menu", s)
+	}
+	pressButton(bot, alice, MessageID, "Jose::Yes")
+	if s := lastSent(t, messenger, 1); s.MessageID != MessageID || !strings.HasPrefix(s.Text, "Game settings:") {
+		t.Errorf("got %+v, want the settings menu again", s)
+	}
+	if !stored().Settings.MysteryPlayer {
+		t.Error("the mystery player was not turned on")
+	}
+
+	pressButton(bot, alice, MessageID, "Jose::Maybe")
+	if s := lastSent(t, messenger, 1); s.Text != GameErrorMessage(stored(), ErrUnknownOption) {
+		t.Errorf("picking an option that does not exist: got %q", s.Text)
+	}
+
+	pressButton(bot, alice, MessageID, "ChangeSetting::NumCardsInHand")
+	pressButton(bot, alice, MessageID, "ChangeSetting::Back")
+	if s := lastSent(t, messenger, 1); s.MessageID != MessageID || !strings.HasPrefix(s.Text, "Game settings:") {
+		t.Errorf("going back: got %+v, want the settings menu", s)
+	}
+
+	pressButton(bot, alice, MessageID, "ChangeSetting::Done")
+	if s := lastSent(t, messenger, 1); s.Text != "The settings have been saved." || len(s.Choices) != 0 {
+		t.Errorf("got %+v, want the settings to be saved", s)
+	}
+	if g := stored(); g.SettingsEditor != 0 || !g.Settings.MysteryPlayer {
+		t.Errorf("got the editor %v and %+v after saving the settings", g.SettingsEditor, g.Settings)
+	}
+	pressButton(bot, alice, MessageID, "Jose::No")
+	if s := lastSent(t, messenger, 1); s.Text != GameErrorMessage(stored(), game.ErrNotEditing) {
+		t.Errorf("picking an option after saving the settings: got %q", s.Text)
+	}
+
+	sendCommand(bot, bob, "/changesettings")
+	if s := lastSent(t, messenger, 2); !strings.HasPrefix(s.Text, "Game settings:") {
+		t.Errorf("/changesettings once alice was done: got %q, want the settings menu", s.Text)
+	}
+}
+
+func TestInvalidSettingsHaveTheirOwnMessages(t *testing.T) {
+	bot, _ := newRecordingBot(t)
+	g := newLobbyGame(t, bot)
+	seen := make(map[string]error)
+	for _, err := range []error{game.ErrInvalidSettings, game.ErrTooManyToTrade, ErrUnknownOption} {
+		text := GameErrorMessage(g, err)
+		if other, ok := seen[text]; ok {
+			t.Errorf("%v and %v both get the message %q", err, other, text)
+		}
+		seen[text] = err
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thedadams/cahbot/game"
+)
+
+// GameTimeout is how long a game can go without being played before it is deleted.
+const GameTimeout = 48 * time.Hour
+
+// DeadlineInterval is how often the deadlines of rounds are checked.
+const DeadlineInterval = 15 * time.Second
+
+// ErrNoGame is returned by a GameStore when there is no game with the given ID.
+var ErrNoGame = errors.New("there is no game with that id")
+
+// GameStore is where the bot keeps its users and games.
+type GameStore interface {
+	// AddUser adds a user, or updates their chat and names if they are already stored.
+	AddUser(UserID int, ChatID int64, FirstName, LastName, UserName, DisplayName string) error
+	// RemoveUser forgets a user.
+	RemoveUser(UserID int) error
+	// GameIDForUser returns the ID of the game a user is playing in, or "" if they are not in one.
+	GameIDForUser(UserID int) (string, error)
+	// GameExists checks to see if there is a game with the given ID.
+	GameExists(GameID string) (bool, error)
+	// CreateGame stores a new game.
+	CreateGame(g *game.Game) error
+	// LoadGame loads a game.  Changes to the game are not stored.
+	LoadGame(GameID string) (*game.Game, error)
+	// UpdateGame loads a game, lets Update change it and stores the changes.
+	// If Update returns an error, nothing is stored.  A finished game is deleted.
+	UpdateGame(GameID string, Update func(*game.Game) error) error
+	// ChatIDs returns the chat IDs of everyone playing in a game.
+	ChatIDs(GameID string) ([]int64, error)
+	// SaveBoard remembers the message that shows a player the answers being eliminated, so that it can be edited.
+	SaveBoard(GameID string, UserID, MessageID int) error
+	// Boards returns the messages that show the players of a game the answers being eliminated, by user ID.
+	Boards(GameID string) (map[int]int, error)
+	// GamesDue returns the IDs of the games whose players are due a reminder, or whose deadline has passed, at Now.
+	GamesDue(Now time.Time) ([]string, error)
+	// CleanUpOldGames deletes the games that have not been played since Before and returns them.
+	CleanUpOldGames(Before time.Time) ([]*game.Game, error)
+	// UpdateOffset returns the ID of the first update from Telegram that has not been confirmed, or 0.
+	UpdateOffset() (int, error)
+	// SaveUpdateOffset stores the ID of the first update from Telegram that has not been confirmed.
+	SaveUpdateOffset(Offset int) error
+	// UpdateHandled checks to see if an update from Telegram has already been handled.
+	UpdateHandled(UpdateID int) (bool, error)
+	// MarkUpdateHandled remembers that an update from Telegram has been handled.
+	MarkUpdateHandled(UpdateID int) error
+	// ForgetUpdates forgets the updates that were handled before Before.
+	ForgetUpdates(Before time.Time) error
+	// SetDeck replaces the deck that games are played with.  The deck must keep the references of the old one.
+	SetDeck(Deck *game.Deck)
+	// Close releases the resources held by the store.
+	Close() error
+}
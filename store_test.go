@@ -0,0 +1,304 @@
+package main
+
+import (
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thedadams/cahbot/game"
+)
+
+// The users the store tests play with.  Their IDs are high, so that they do not clash with real users in a test database.
+var storeTestUsers = []int{900001, 900002, 900003}
+
+// sameCards reports whether two lists of cards are the same.  A nil list is the same as an empty one.
+func sameCards(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// newStoredGame creates a game with the store test users, begins it and stores it.
+func newStoredGame(t *testing.T, Store GameStore, Deck *game.Deck, ID string, Settings game.Settings) *game.Game {
+	t.Helper()
+	g := game.New(ID, Deck, Settings, rand.New(rand.NewSource(1)))
+	for _, user := range storeTestUsers {
+		if err := Store.AddUser(user, int64(user), "First", "Last", "user", "user"+ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := g.AddPlayer(user, int64(user), "user"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := g.Begin(); err != nil {
+		t.Fatal(err)
+	}
+	if err := Store.CreateGame(g); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// testGameStore checks that a store saves, loads, deletes and cleans up games the way the bot uses them.
+// Store must not have any games with the IDs the test uses.
+func testGameStore(t *testing.T, Store GameStore, Deck *game.Deck) {
+	if exists, err := Store.GameExists("tst01"); err != nil || exists {
+		t.Fatalf("got %v and %v for a game that was never stored, want false", exists, err)
+	}
+	if _, err := Store.LoadGame("tst01"); err != ErrNoGame {
+		t.Fatalf("loading a game that was never stored: got %v, want %v", err, ErrNoGame)
+	}
+
+	g := newStoredGame(t, Store, Deck, "tst01", game.DefaultSettings)
+	if exists, err := Store.GameExists(g.ID); err != nil || !exists {
+		t.Fatalf("got %v and %v for a stored game, want true", exists, err)
+	}
+	if ID, err := Store.GameIDForUser(storeTestUsers[1]); err != nil || ID != g.ID {
+		t.Errorf("got game %q and %v for a player, want %q", ID, err, g.ID)
+	}
+	if IDs, err := Store.ChatIDs(g.ID); err != nil || len(IDs) != len(storeTestUsers) {
+		t.Errorf("got chats %v and %v, want one for each player", IDs, err)
+	}
+	loaded, err := Store.LoadGame(g.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Phase != g.Phase || loaded.Round != g.Round || loaded.Czar != g.Czar || loaded.Question != g.Question {
+		t.Errorf("got phase %v, round %v, czar %v and question %v, want %v, %v, %v and %v",
+			loaded.Phase, loaded.Round, loaded.Czar, loaded.Question, g.Phase, g.Round, g.Czar, g.Question)
+	}
+	if !sameCards(loaded.AnswerPile, g.AnswerPile) || !sameCards(loaded.QuestionPile, g.QuestionPile) {
+		t.Errorf("the draw piles were not stored")
+	}
+	for i, p := range g.Players {
+		if loaded.Players[i].ID != p.ID || !sameCards(loaded.Players[i].Hand, p.Hand) {
+			t.Errorf("player %v was not stored in order with their hand", p.ID)
+		}
+	}
+
+	// A change is stored, unless the update fails.
+	player := storeTestUsers[1]
+	var card int
+	err = Store.UpdateGame(g.ID, func(g *game.Game) error {
+		card = g.Player(player).Hand[0]
+		_, err := g.PlayCard(player, g.Round, card)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Store.UpdateGame(g.ID, func(g *game.Game) error {
+		g.Player(player).Points = 5
+		return game.ErrNotCzar
+	})
+	if err != game.ErrNotCzar {
+		t.Fatalf("got %v from a failed update, want %v", err, game.ErrNotCzar)
+	}
+	if loaded, err = Store.LoadGame(g.ID); err != nil {
+		t.Fatal(err)
+	}
+	if p := loaded.Player(player); !sameCards(p.Played, []int{card}) || p.Answer == "" || p.Points != 0 {
+		t.Errorf("got played %v, answer %q and %v points, want the card played and the failed update not stored", p.Played, p.Answer, p.Points)
+	}
+	if err := Store.UpdateGame("nope!", func(*game.Game) error { return nil }); err != ErrNoGame {
+		t.Errorf("updating a game that was never stored: got %v, want %v", err, ErrNoGame)
+	}
+
+	if err := Store.SaveBoard(g.ID, player, 42); err != nil {
+		t.Fatal(err)
+	}
+	if boards, err := Store.Boards(g.ID); err != nil || boards[player] != 42 {
+		t.Errorf("got boards %v and %v, want message 42 for player %v", boards, err, player)
+	}
+
+	// A player that leaves is no longer in the game, and a finished game is deleted.
+	leaver := storeTestUsers[2]
+	if err := Store.UpdateGame(g.ID, func(g *game.Game) error { _, err := g.RemovePlayer(leaver); return err }); err != nil {
+		t.Fatal(err)
+	}
+	if ID, err := Store.GameIDForUser(leaver); err != nil || ID != "" {
+		t.Errorf("got game %q and %v for a player that left, want none", ID, err)
+	}
+	if err := Store.UpdateGame(g.ID, func(g *game.Game) error { g.End("user"); return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if exists, err := Store.GameExists(g.ID); err != nil || exists {
+		t.Errorf("got %v and %v for a finished game, want it deleted", exists, err)
+	}
+	if ID, err := Store.GameIDForUser(player); err != nil || ID != "" {
+		t.Errorf("got game %q and %v for a player of a deleted game, want none", ID, err)
+	}
+
+	// Games are due when their deadline comes, and old games are cleaned up.
+	old := newStoredGame(t, Store, Deck, "tst02", game.Settings{CardsInHand: 7, PointsToWin: 7, AnswerMinutes: 1})
+	due, err := Store.GamesDue(time.Now().Add(2 * time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, ID := range due {
+		found = found || ID == old.ID
+	}
+	if !found {
+		t.Errorf("got games %v due after the deadline, want %v among them", due, old.ID)
+	}
+	games, err := Store.CleanUpOldGames(time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	found = false
+	for _, cleaned := range games {
+		found = found || cleaned.ID == old.ID && len(cleaned.Players) == len(storeTestUsers)
+	}
+	if !found {
+		t.Errorf("got %v cleaned up, want %v with its players", len(games), old.ID)
+	}
+	if exists, err := Store.GameExists(old.ID); err != nil || exists {
+		t.Errorf("got %v and %v for a cleaned up game, want it deleted", exists, err)
+	}
+
+	for _, user := range storeTestUsers {
+		if err := Store.RemoveUser(user); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+// testUpdateTracking checks that a store remembers the update offset and the updates that were handled.
+func testUpdateTracking(t *testing.T, Store GameStore) {
+	if err := Store.SaveUpdateOffset(1234); err != nil {
+		t.Fatal(err)
+	}
+	if offset, err := Store.UpdateOffset(); err != nil || offset != 1234 {
+		t.Errorf("got offset %v and %v, want 1234", offset, err)
+	}
+	if err := Store.MarkUpdateHandled(1233); err != nil {
+		t.Fatal(err)
+	}
+	if handled, err := Store.UpdateHandled(1233); err != nil || !handled {
+		t.Errorf("got %v and %v for a handled update, want true", handled, err)
+	}
+	if handled, err := Store.UpdateHandled(1234); err != nil || handled {
+		t.Errorf("got %v and %v for an update that was not handled, want false", handled, err)
+	}
+	if err := Store.ForgetUpdates(time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if handled, err := Store.UpdateHandled(1233); err != nil || handled {
+		t.Errorf("got %v and %v for a forgotten update, want false", handled, err)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	Deck, err := LoadDeck("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testGameStore(t, NewMemoryStore(), Deck)
+	testUpdateTracking(t, NewMemoryStore())
+}
+
+func TestMemoryStoreKeepsGamesPlayableWhenTheCardsAreReloaded(t *testing.T) {
+	dir := t.TempDir()
+	pack := filepath.Join(dir, "house.json")
+	data := `{"name": "House", "questions": [{"id": 5000, "text": "Who ate _?", "numAnswers": 1}],
+		"answers": [{"id": 6000, "text": "The last slice."}, {"id": 6001, "text": "A stale crust."}]}`
+	if err := ioutil.WriteFile(pack, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	Deck, err := LoadDeck(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bot, err := NewCAHBot(new(RecordingMessenger), Deck, NewMemoryStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+	bot.CardsDir = dir
+	g := newStoredGame(t, bot.Store, bot.CurrentDeck(), "swap1", game.DefaultSettings)
+	// The first player who is not the czar holds a card of the pack, and the pack's cards are drawn next.
+	var answerer int
+	if err := bot.Store.UpdateGame(g.ID, func(g *game.Game) error {
+		for _, p := range g.Players {
+			if p.ID != g.Czar {
+				answerer = p.ID
+				p.Hand[0] = 6001
+				break
+			}
+		}
+		g.AnswerPile = append(g.AnswerPile, 6000)
+		g.QuestionPile = append(g.QuestionPile, 5000)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Remove(pack); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bot.ReloadCards(); err != nil {
+		t.Fatal(err)
+	}
+	for _, ID := range []int{6000, 6001} {
+		if a := bot.CurrentDeck().Answer(ID); !a.Retired || a.Text == "" {
+			t.Errorf("got %+v after the pack was removed, want the card kept but retired", a)
+		}
+	}
+
+	var answers []game.Answer
+	if err := bot.Store.UpdateGame(g.ID, func(g *game.Game) error {
+		for _, p := range g.Waiting() {
+			if _, err := g.PlayCard(p.ID, g.Round, p.Hand[0]); err != nil {
+				return err
+			}
+		}
+		if hand := g.Player(answerer).Hand; len(hand) != g.Settings.CardsInHand || indexOf(hand, 6000) != -1 {
+			t.Errorf("got the hand %v, want %v cards without the retired one", hand, g.Settings.CardsInHand)
+		}
+		answers = g.Answers()
+		if _, err := g.ChooseAnswer(g.Czar, g.Round, 0); err != nil {
+			return err
+		}
+		_, err := g.StartRound()
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	played := false
+	for _, a := range answers {
+		played = played || strings.Contains(a.Text, "A stale crust")
+	}
+	if !played {
+		t.Errorf("got the answers %v, want the retired card that was held played with its text", answers)
+	}
+	g, err = bot.Store.LoadGame(g.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Phase != game.CollectingAnswers || g.Round != 2 || g.Question == 5000 {
+		t.Errorf("got phase %v of round %v with question %v, want the next round with a question still in the deck", g.Phase, g.Round, g.Question)
+	}
+
+	fresh := game.New("swap2", bot.CurrentDeck(), game.DefaultSettings, rand.New(rand.NewSource(1)))
+	for _, ID := range storeTestUsers {
+		fresh.AddPlayer(ID, int64(ID), "user")
+	}
+	if _, err := fresh.Begin(); err != nil {
+		t.Fatal(err)
+	}
+	for _, IDs := range [][]int{fresh.QuestionPile, fresh.AnswerPile, fresh.Player(storeTestUsers[0]).Hand} {
+		if indexOf(IDs, 5000) != -1 || indexOf(IDs, 6000) != -1 || indexOf(IDs, 6001) != -1 {
+			t.Errorf("a new game holds a retired card in %v", IDs)
+		}
+	}
+}
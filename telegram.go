@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/thedadams/telegram-bot-api"
+)
+
+// NewTelegramAPI connects to the Telegram Bot API with Token.  If Endpoint is not empty, the requests go to the
+// Bot API server at that URL, like a local telegram-bot-api server or a telegramtest.Server, instead of Telegram.
+func NewTelegramAPI(Token, Endpoint string) (*tgbotapi.BotAPI, error) {
+	if Endpoint == "" {
+		return tgbotapi.NewBotAPI(Token)
+	}
+	u, err := url.Parse(Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("the API endpoint %q must be an http or https URL, for example http://localhost:8081", Endpoint)
+	}
+	return tgbotapi.NewBotAPIWithClient(Token, &http.Client{Transport: &EndpointTransport{u, http.DefaultTransport}})
+}
+
+// EndpointTransport sends the requests that tgbotapi makes to api.telegram.org to another Bot API server.
+// tgbotapi always builds its URLs from api.telegram.org, so they are rewritten on the way out.
+type EndpointTransport struct {
+	Endpoint *url.URL
+	Next     http.RoundTripper
+}
+
+// RoundTrip sends a request to the endpoint, keeping the /bot<token>/<method> path.
+func (t *EndpointTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Scheme = t.Endpoint.Scheme
+	r.URL.Host = t.Endpoint.Host
+	r.URL.Path = strings.TrimSuffix(t.Endpoint.Path, "/") + r.URL.Path
+	r.URL.RawPath = ""
+	r.Host = t.Endpoint.Host
+	return t.Next.RoundTrip(r)
+}
+
+// TelegramMessenger sends messages through the Telegram Bot API.
+type TelegramMessenger struct {
+	*tgbotapi.BotAPI
+}
+
+// SendText sends a message to a chat.
+func (t *TelegramMessenger) SendText(ChatID int64, Text string) error {
+	_, err := t.Send(tgbotapi.NewMessage(ChatID, Text))
+	return err
+}
+
+// SendChoices sends a message with an inline keyboard that has a button for each choice.
+func (t *TelegramMessenger) SendChoices(ChatID int64, Text string, Choices []Choice) (int, error) {
+	message := tgbotapi.NewMessage(ChatID, Text)
+	if len(Choices) != 0 {
+		message.ReplyMarkup = InlineKeyboard(Choices)
+	}
+	sent, err := t.Send(message)
+	return sent.MessageID, err
+}
+
+// EditChoices replaces the text and inline keyboard of a message.
+func (t *TelegramMessenger) EditChoices(ChatID int64, MessageID int, Text string, Choices []Choice) error {
+	message := tgbotapi.NewEditMessageText(ChatID, MessageID, Text)
+	if len(Choices) != 0 {
+		message.ReplyMarkup = InlineKeyboard(Choices)
+	}
+	_, err := t.Send(message)
+	return err
+}
+
+// Relay forwards a message from one chat to another.
+func (t *TelegramMessenger) Relay(ChatID, FromChatID int64, MessageID int, Text string) error {
+	_, err := t.Send(tgbotapi.NewForward(ChatID, FromChatID, MessageID))
+	return err
+}
+
+// InlineKeyboard builds an inline keyboard with one choice in each row.
+func InlineKeyboard(Choices []Choice) *tgbotapi.InlineKeyboardMarkup {
+	keyboard := make([][]tgbotapi.InlineKeyboardButton, len(Choices))
+	for i := range Choices {
+		keyboard[i] = []tgbotapi.InlineKeyboardButton{{Text: Choices[i].Text, CallbackData: &Choices[i].Data}}
+	}
+	return &tgbotapi.InlineKeyboardMarkup{InlineKeyboard: keyboard}
+}
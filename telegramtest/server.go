@@ -0,0 +1,347 @@
+// Package telegramtest provides a stand-in for the Telegram Bot API, so the bot can be played end to end without a network.
+//
+// The Server answers getMe, getUpdates, sendMessage, forwardMessage, editMessageText, answerCallbackQuery and setWebhook.
+// Updates are injected with methods like SendCommand and PressButton, and every request the bot makes is captured.
+package telegramtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thedadams/telegram-bot-api"
+)
+
+// Request is a request the bot made to the Server.
+type Request struct {
+	Method string
+	Params url.Values
+	// Message is the message that was sent, forwarded or edited, if there was one.
+	Message *tgbotapi.Message
+}
+
+// ChatID returns the chat the request was sent to, or 0 if it was not sent to a chat.
+func (r Request) ChatID() int64 {
+	ID, _ := strconv.ParseInt(r.Params.Get("chat_id"), 10, 64)
+	return ID
+}
+
+// Text returns the text of a message that was sent, forwarded or edited.
+func (r Request) Text() string {
+	if r.Message != nil {
+		return r.Message.Text
+	}
+	return r.Params.Get("text")
+}
+
+// Buttons returns the buttons of the inline keyboard that was sent with a message, row by row.
+func (r Request) Buttons() []tgbotapi.InlineKeyboardButton {
+	var markup tgbotapi.InlineKeyboardMarkup
+	if json.Unmarshal([]byte(r.Params.Get("reply_markup")), &markup) != nil {
+		return nil
+	}
+	var buttons []tgbotapi.InlineKeyboardButton
+	for _, row := range markup.InlineKeyboard {
+		buttons = append(buttons, row...)
+	}
+	return buttons
+}
+
+// Server is a fake Telegram Bot API.  The zero value is not usable; use NewServer.
+type Server struct {
+	// Bot is the user returned by getMe.
+	Bot tgbotapi.User
+	// Token is the token the bot must use.  Any token is accepted if it is empty.
+	Token string
+
+	mu         sync.Mutex
+	updates    []tgbotapi.Update
+	lastUpdate int
+	messages   map[int]tgbotapi.Message
+	lastID     int
+	requests   []Request
+	changed    chan struct{}
+	closed     bool
+}
+
+// NewServer creates a Server with no updates waiting.
+func NewServer() *Server {
+	return &Server{
+		Bot:      tgbotapi.User{ID: 1, FirstName: "CAH Bot", UserName: "cahbot"},
+		messages: make(map[int]tgbotapi.Message),
+		changed:  make(chan struct{}),
+	}
+}
+
+// Client returns an HTTP client that sends requests for the Telegram Bot API to the Server instead of the network.
+// Use it with tgbotapi.NewBotAPIWithClient.
+func (s *Server) Client() *http.Client {
+	return &http.Client{Transport: s}
+}
+
+// RoundTrip handles a request from the Client.
+func (s *Server) RoundTrip(r *http.Request) (*http.Response, error) {
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	return w.Result(), nil
+}
+
+// Close stops the Server.  Anyone waiting for updates gets an empty list.
+func (s *Server) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		s.notify()
+	}
+}
+
+// ServeHTTP answers a request to /bot<token>/<method>.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+	if len(parts) != 2 || !strings.HasPrefix(parts[0], "bot") {
+		reply(w, http.StatusNotFound, nil, "Not Found")
+		return
+	}
+	if s.Token != "" && parts[0] != "bot"+s.Token {
+		reply(w, http.StatusUnauthorized, nil, "Unauthorized")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		reply(w, http.StatusBadRequest, nil, "Bad Request: "+err.Error())
+		return
+	}
+	req := Request{Method: parts[1], Params: r.Form}
+	defer func() { s.record(req) }()
+
+	switch req.Method {
+	case "getMe":
+		reply(w, http.StatusOK, s.Bot, "")
+	case "getUpdates":
+		reply(w, http.StatusOK, s.getUpdates(r.Form), "")
+	case "sendMessage":
+		message := tgbotapi.Message{Text: r.Form.Get("text")}
+		req.Message = s.sendMessage(w, r.Form, &message)
+	case "forwardMessage":
+		from, _ := strconv.ParseInt(r.Form.Get("from_chat_id"), 10, 64)
+		ID, _ := strconv.Atoi(r.Form.Get("message_id"))
+		original, ok := s.message(ID)
+		if !ok || original.Chat == nil || original.Chat.ID != from {
+			reply(w, http.StatusBadRequest, nil, "Bad Request: message to forward not found")
+			return
+		}
+		message := tgbotapi.Message{Text: original.Text, Sticker: original.Sticker, ForwardFrom: original.From, ForwardFromMessageID: ID}
+		req.Message = s.sendMessage(w, r.Form, &message)
+	case "editMessageText":
+		ID, _ := strconv.Atoi(r.Form.Get("message_id"))
+		message, ok := s.message(ID)
+		if !ok {
+			reply(w, http.StatusBadRequest, nil, "Bad Request: message to edit not found")
+			return
+		}
+		message.Text = r.Form.Get("text")
+		message.EditDate = int(time.Now().Unix())
+		s.mu.Lock()
+		s.messages[ID] = message
+		s.mu.Unlock()
+		req.Message = &message
+		reply(w, http.StatusOK, message, "")
+	case "answerCallbackQuery", "setWebhook":
+		reply(w, http.StatusOK, true, "")
+	default:
+		reply(w, http.StatusNotFound, nil, "Not Found: method not found")
+	}
+}
+
+// Inject adds an update for the bot to fetch and returns its ID.
+// The ID of the update is set by the Server.
+func (s *Server) Inject(Update tgbotapi.Update) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastUpdate++
+	Update.UpdateID = s.lastUpdate
+	if Update.Message != nil {
+		s.messages[Update.Message.MessageID] = *Update.Message
+	}
+	s.updates = append(s.updates, Update)
+	s.notify()
+	return Update.UpdateID
+}
+
+// SendCommand injects a command, like "/join abcde", sent by a user from their private chat.
+func (s *Server) SendCommand(From tgbotapi.User, Text string) int {
+	message := s.newMessage(From)
+	message.Text = Text
+	message.Entities = &[]tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: len(strings.Fields(Text)[0])}}
+	return s.Inject(tgbotapi.Update{Message: message})
+}
+
+// SendText injects a plain message sent by a user from their private chat.
+func (s *Server) SendText(From tgbotapi.User, Text string) int {
+	message := s.newMessage(From)
+	message.Text = Text
+	return s.Inject(tgbotapi.Update{Message: message})
+}
+
+// SendSticker injects a sticker sent by a user from their private chat.
+func (s *Server) SendSticker(From tgbotapi.User, FileID string) int {
+	message := s.newMessage(From)
+	message.Sticker = &tgbotapi.Sticker{FileID: FileID, Width: 512, Height: 512}
+	return s.Inject(tgbotapi.Update{Message: message})
+}
+
+// PressButton injects a callback query for a user pressing a button with Data on a message the bot sent them.
+func (s *Server) PressButton(From tgbotapi.User, MessageID int, Data string) int {
+	message, _ := s.message(MessageID)
+	s.mu.Lock()
+	ID := strconv.Itoa(s.lastUpdate + 1)
+	s.mu.Unlock()
+	return s.Inject(tgbotapi.Update{CallbackQuery: &tgbotapi.CallbackQuery{ID: ID, From: &From, Message: &message, ChatInstance: strconv.Itoa(From.ID), Data: Data}})
+}
+
+// Requests returns every request the bot has made, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Sent returns the requests that sent, forwarded or edited a message in a chat, in order.
+func (s *Server) Sent(ChatID int64) []Request {
+	var sent []Request
+	for _, r := range s.Requests() {
+		if (r.Method == "sendMessage" || r.Method == "forwardMessage" || r.Method == "editMessageText") && r.ChatID() == ChatID {
+			sent = append(sent, r)
+		}
+	}
+	return sent
+}
+
+// Transcript returns the text of every message sent, forwarded or edited in a chat, in order.
+func (s *Server) Transcript(ChatID int64) []string {
+	var texts []string
+	for _, r := range s.Sent(ChatID) {
+		texts = append(texts, r.Text())
+	}
+	return texts
+}
+
+// WaitFor waits until Done is true for the requests the bot has made, or until Timeout has passed.
+func (s *Server) WaitFor(Timeout time.Duration, Done func([]Request) bool) bool {
+	deadline := time.After(Timeout)
+	for {
+		s.mu.Lock()
+		requests := append([]Request(nil), s.requests...)
+		changed := s.changed
+		s.mu.Unlock()
+		if Done(requests) {
+			return true
+		}
+		select {
+		case <-changed:
+		case <-deadline:
+			return false
+		}
+	}
+}
+
+func (s *Server) getUpdates(Params url.Values) []tgbotapi.Update {
+	offset, _ := strconv.Atoi(Params.Get("offset"))
+	limit, _ := strconv.Atoi(Params.Get("limit"))
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+	timeout, _ := strconv.Atoi(Params.Get("timeout"))
+	deadline := time.After(time.Duration(timeout) * time.Second)
+	for {
+		s.mu.Lock()
+		// Updates before the offset have been seen by the bot, so they are forgotten.
+		for len(s.updates) > 0 && s.updates[0].UpdateID < offset {
+			s.updates = s.updates[1:]
+		}
+		updates := s.updates
+		if len(updates) > limit {
+			updates = updates[:limit]
+		}
+		updates = append([]tgbotapi.Update{}, updates...)
+		changed, closed := s.changed, s.closed
+		s.mu.Unlock()
+		if len(updates) > 0 || closed || timeout <= 0 {
+			return updates
+		}
+		select {
+		case <-changed:
+		case <-deadline:
+			return updates
+		}
+	}
+}
+
+// sendMessage gives a message an ID and puts it in a chat.
+func (s *Server) sendMessage(w http.ResponseWriter, Params url.Values, Message *tgbotapi.Message) *tgbotapi.Message {
+	chatID, err := strconv.ParseInt(Params.Get("chat_id"), 10, 64)
+	if err != nil {
+		reply(w, http.StatusBadRequest, nil, "Bad Request: chat not found")
+		return nil
+	}
+	s.mu.Lock()
+	s.lastID++
+	Message.MessageID = s.lastID
+	Message.From = &s.Bot
+	Message.Chat = &tgbotapi.Chat{ID: chatID, Type: "private"}
+	Message.Date = int(time.Now().Unix())
+	s.messages[Message.MessageID] = *Message
+	s.mu.Unlock()
+	reply(w, http.StatusOK, Message, "")
+	return Message
+}
+
+func (s *Server) newMessage(From tgbotapi.User) *tgbotapi.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastID++
+	return &tgbotapi.Message{
+		MessageID: s.lastID,
+		From:      &From,
+		Chat:      &tgbotapi.Chat{ID: int64(From.ID), Type: "private", UserName: From.UserName, FirstName: From.FirstName, LastName: From.LastName},
+		Date:      int(time.Now().Unix()),
+	}
+}
+
+func (s *Server) message(ID int) (tgbotapi.Message, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.messages[ID]
+	return m, ok
+}
+
+func (s *Server) record(r Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r)
+	s.notify()
+}
+
+// notify wakes everyone waiting for a change.  s.mu must be held.
+func (s *Server) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// reply writes a response in the form the Telegram Bot API uses.
+func reply(w http.ResponseWriter, Status int, Result interface{}, Description string) {
+	resp := tgbotapi.APIResponse{Ok: Status == http.StatusOK, ErrorCode: Status, Description: Description}
+	if resp.Ok {
+		resp.ErrorCode = 0
+		resp.Result, _ = json.Marshal(Result)
+	}
+	body, _ := json.Marshal(resp)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(Status)
+	w.Write(body)
+}
@@ -1,70 +1,35 @@
 package main
 
 import (
-	"database/sql"
 	"encoding/json"
 	"log"
-	"os"
+	"sync"
 
-	_ "github.com/lib/pq"
-	"github.com/thedadams/telegram-bot-api"
+	"github.com/thedadams/cahbot/game"
 )
 
-// CAHBot inherits from tgbotapi.
+// CAHBot plays games with the players it reaches through its Messenger.
 type CAHBot struct {
-	*tgbotapi.BotAPI
-	DBConn           *sql.DB
-	AllQuestionCards []QuestionCard `json:"all_question_cards"`
-	AllAnswerCards   []AnswerCard   `json:"all_answer_cards"`
-	Settings         []Setting      `json:"settings"`
+	Messenger Messenger   `json:"-"`
+	Store     GameStore   `json:"-"`
+	Work      *Dispatcher `json:"-"`
+	Deck      *game.Deck  `json:"deck"`
+	Settings  []Setting   `json:"settings"`
+	// CardsDir is the directory of card packs that /reloadcards loads, or "" for only the built-in cards.
+	CardsDir string `json:"-"`
+
+	deckMu sync.RWMutex
 }
 
-// NewCAHBot creates a new CAHBot.
-func NewCAHBot(token string) (*CAHBot, error) {
-	GenericBot, err := tgbotapi.NewBotAPI(os.Getenv("TOKEN"))
-	if err != nil {
-		log.Printf("Error initializing bot: %v", err)
-		return nil, err
-	}
-	// Need to get the card data
-	var AllQuestionCards []QuestionCard
-	err = json.Unmarshal(AllQuestions, &AllQuestionCards)
-	if err != nil {
-		log.Printf("%v", err)
-		return nil, err
-	}
-	var AllAnswerCards []AnswerCard
-	err = json.Unmarshal(AllAnswers, &AllAnswerCards)
-	if err != nil {
-		log.Printf("%v", err)
-		return nil, err
-	}
+// NewCAHBot creates a new CAHBot that talks through Messenger and keeps its games in Store.
+func NewCAHBot(Messenger Messenger, Deck *game.Deck, Store GameStore) (*CAHBot, error) {
 	var Settings []Setting
-	err = json.Unmarshal(AllSettings, &Settings)
+	err := json.Unmarshal(AllSettings, &Settings)
 	if err != nil {
 		log.Printf("%v", err)
 		return nil, err
 	}
-	db, err := sql.Open("postgres", os.Getenv("DATABASE_URL"))
-	if err != nil {
-		log.Printf("%v", err)
-	}
-	return &CAHBot{GenericBot, db, AllQuestionCards, AllAnswerCards, Settings}, err
-}
-
-// QuestionCard represents a white card in CAH.
-type QuestionCard struct {
-	ID         int    `json:"id"`
-	Text       string `json:"text"`
-	NumAnswers int    `json:"numAnswers"`
-	Expansion  string `json:"expansion"`
-}
-
-// AnswerCard represents a black card in CAH.
-type AnswerCard struct {
-	ID        int    `json:"id"`
-	Text      string `json:"text"`
-	Expansion string `json:"expansion"`
+	return &CAHBot{Messenger: Messenger, Store: Store, Work: NewDispatcher(QueueSize), Deck: Deck, Settings: Settings}, nil
 }
 
 // Setting represents a setting in the game that can be changed.